@@ -3,8 +3,6 @@ package conferences
 import (
 	"context"
 	"fmt"
-
-	"encore.dev/storage/sqldb"
 )
 
 // AddPaperParams defines the inputs used by the AddPaper API method
@@ -17,39 +15,19 @@ type AddPaperResponse struct {
 	PaperID uint32
 }
 
-// AddPaper inserts a paper into the paper_submissions table
-// encore:api public
+// AddPaper inserts a paper into the paper_submissions table, the speaker is the authenticated user
+// encore:api auth
 func AddPaper(ctx context.Context, params *AddPaperParams) (*AddPaperResponse, error) {
+	if params.Paper == nil {
+		return nil, fmt.Errorf("Paper is required")
+	}
 
-	row := sqldb.QueryRow(ctx,
-		`INSERT INTO paper_submission (
-			user_id,
-  		conference_id,
-  		title,
-  		elevator_pitch,
-  		description,
-  		notes
-		) VALUES (
-			$1,
-			$2,
-			$3,
-			$4,
-			$5,
-			$6
-			) RETURNING id`,
-		params.Paper.UserID,
-		params.Paper.ConferenceID,
-		params.Paper.Title,
-		params.Paper.ElevatorPitch,
-		params.Paper.Description,
-		params.Paper.Notes,
-	)
-
-	var paperID uint32
-	err := row.Scan(
-		&paperID,
-	)
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
 
+	paperID, err := submitPaper(ctx, userID, params.Paper)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add paper: %w", err)
 	}
@@ -0,0 +1,80 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// AddSponsorContactParams defines the inputs used by the AddSponsorContact API method
+type AddSponsorContactParams struct {
+	SponsorID                 uint32
+	SponsorContactInformation *SponsorContactInformation
+}
+
+// AddSponsorContactResponse defines the output returned by the AddSponsorContact API method
+type AddSponsorContactResponse struct {
+	SponsorContactInformation *SponsorContactInformation
+}
+
+// AddSponsorContact adds a contact to a sponsor, only organizers can do so
+// encore:api auth
+func AddSponsorContact(ctx context.Context, params *AddSponsorContactParams) (*AddSponsorContactResponse, error) {
+	if params.SponsorContactInformation == nil {
+		return nil, fmt.Errorf("SponsorContactInformation is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := addSponsorContact(ctx, userID, params.SponsorID, params.SponsorContactInformation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add sponsor contact: %w", err)
+	}
+
+	return &AddSponsorContactResponse{SponsorContactInformation: saved}, nil
+}
+
+// InviteSponsorContactParams defines the inputs used by the InviteSponsorContact API method
+type InviteSponsorContactParams struct {
+	SponsorID uint32
+	ContactID uint32
+}
+
+// InviteSponsorContact emails a new invite to the portal of a sponsor to one of its contacts,
+// only organizers can do so
+// encore:api auth
+func InviteSponsorContact(ctx context.Context, params *InviteSponsorContactParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if _, err := inviteSponsorContact(ctx, userID, params.SponsorID, params.ContactID); err != nil {
+		return fmt.Errorf("failed to invite sponsor contact: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveSponsorContactParams defines the inputs used by the RemoveSponsorContact API method
+type RemoveSponsorContactParams struct {
+	SponsorID uint32
+	ContactID uint32
+}
+
+// RemoveSponsorContact removes a contact from a sponsor, only organizers can do so
+// encore:api auth
+func RemoveSponsorContact(ctx context.Context, params *RemoveSponsorContactParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := removeSponsorContact(ctx, userID, params.SponsorID, params.ContactID); err != nil {
+		return fmt.Errorf("failed to remove sponsor contact: %w", err)
+	}
+
+	return nil
+}
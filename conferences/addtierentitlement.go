@@ -0,0 +1,79 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// AddTierEntitlementParams defines the inputs used by the AddTierEntitlement API method
+type AddTierEntitlementParams struct {
+	Entitlement *Entitlement
+}
+
+// AddTierEntitlementResponse defines the output returned by the AddTierEntitlement API method
+type AddTierEntitlementResponse struct {
+	Entitlement *Entitlement
+}
+
+// AddTierEntitlement adds a benefit to the package of a sponsorship tier, only organizers can do so
+// encore:api auth
+func AddTierEntitlement(ctx context.Context, params *AddTierEntitlementParams) (*AddTierEntitlementResponse, error) {
+	if params.Entitlement == nil {
+		return nil, fmt.Errorf("Entitlement is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := addTierEntitlement(ctx, userID, params.Entitlement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add entitlement: %w", err)
+	}
+
+	return &AddTierEntitlementResponse{Entitlement: saved}, nil
+}
+
+// RemoveTierEntitlementParams defines the inputs used by the RemoveTierEntitlement API method
+type RemoveTierEntitlementParams struct {
+	EntitlementID uint32
+}
+
+// RemoveTierEntitlement removes a benefit no sponsor has used from the package of its tier, only
+// organizers can do so
+// encore:api auth
+func RemoveTierEntitlement(ctx context.Context, params *RemoveTierEntitlementParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := removeTierEntitlement(ctx, userID, params.EntitlementID); err != nil {
+		return fmt.Errorf("failed to remove entitlement: %w", err)
+	}
+
+	return nil
+}
+
+// ListTierEntitlementsParams defines the inputs used by the ListTierEntitlements API method
+type ListTierEntitlementsParams struct {
+	TierID uint32
+}
+
+// ListTierEntitlementsResponse defines the output returned by the ListTierEntitlements API method
+type ListTierEntitlementsResponse struct {
+	Entitlements []Entitlement
+}
+
+// ListTierEntitlements retrieves the package of a sponsorship tier
+// encore:api public
+func ListTierEntitlements(ctx context.Context, params *ListTierEntitlementsParams) (*ListTierEntitlementsResponse, error) {
+
+	entitlements, err := readEntitlementsByTier(ctx, params.TierID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve entitlements: %w", err)
+	}
+
+	return &ListTierEntitlementsResponse{Entitlements: entitlements}, nil
+}
@@ -0,0 +1,72 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// personalAgenda returns the slots a user bookmarked or claimed in a conference, all conferences
+// if zero, and the ones overlapping.
+func personalAgenda(ctx context.Context, userID, conferenceID uint32) ([]AgendaItem, []AgendaOverlap, error) {
+	bookmarked, err := readBookmarkedSlots(ctx, userID, conferenceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	claimed, err := readClaimedSlots(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := []AgendaItem{}
+	index := map[uint32]int{}
+	for _, s := range bookmarked {
+		index[s.ID] = len(items)
+		items = append(items, AgendaItem{Slot: s, Bookmarked: true})
+	}
+	for _, s := range claimed {
+		if conferenceID != 0 && s.ConferenceID != conferenceID {
+			continue
+		}
+		if i, ok := index[s.ID]; ok {
+			items[i].Claimed = true
+			continue
+		}
+		index[s.ID] = len(items)
+		items = append(items, AgendaItem{Slot: s, Claimed: true})
+	}
+	return items, agendaOverlaps(items), nil
+}
+
+// bookmarkSlot adds a slot to the agenda of a user and returns the slots of the agenda it overlaps.
+func bookmarkSlot(ctx context.Context, userID, slotID uint32) ([]AgendaOverlap, error) {
+	slot, err := readScheduleSlot(ctx, slotID)
+	if err != nil {
+		return nil, err
+	}
+	if slot == nil {
+		return nil, fmt.Errorf("no such slot")
+	}
+	if err := insertBookmark(ctx, userID, slotID); err != nil {
+		return nil, err
+	}
+
+	_, overlaps, err := personalAgenda(ctx, userID, slot.ConferenceID)
+	if err != nil {
+		return nil, err
+	}
+	involved := []AgendaOverlap{}
+	for _, o := range overlaps {
+		if o.SlotIDs[0] == slotID || o.SlotIDs[1] == slotID {
+			involved = append(involved, o)
+		}
+	}
+	return involved, nil
+}
+
+// slotInterest returns how popular each slot of a conference is compared to its room.
+func slotInterest(ctx context.Context, userID, conferenceID uint32) ([]SlotInterest, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	return readSlotInterest(ctx, conferenceID)
+}
@@ -0,0 +1,55 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+func TestBookmarkSlot(t *testing.T) {
+	ctx := context.Background()
+
+	attendee, err := createAttendee(ctx, nil, &User{Email: "planner@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	organizer := createOrganizer(t, ctx, "interest@gophercon.com")
+
+	start := time.Date(2020, 11, 12, 17, 0, 0, 0, time.UTC)
+	createSlot := func(name string, from time.Time) uint32 {
+		row := sqldb.QueryRow(ctx, `INSERT INTO conference_slot (name, description, cost, capacity, start_date, end_date,
+		purchaseable_from, purchaseable_until, available_to_public, conference_id, location_id)
+		VALUES ($1, 'Free session', 0, 0, $2, $3, $2, $2, TRUE, 1, 1) RETURNING id`, name, from, from.Add(time.Hour))
+		var slotID uint32
+		assertDatabaseError(t, row.Scan(&slotID))
+		return slotID
+	}
+	first := createSlot("Hallway track", start)
+	second := createSlot("Birds of a feather", start.Add(30*time.Minute))
+
+	t.Run("overlapping bookmarks are reported", func(t *testing.T) {
+		overlaps, err := bookmarkSlot(ctx, attendee.ID, first)
+		assertDatabaseError(t, err)
+		if len(overlaps) != 0 {
+			t.Errorf("unexpected overlaps %+v", overlaps)
+		}
+		overlaps, err = bookmarkSlot(ctx, attendee.ID, second)
+		assertDatabaseError(t, err)
+		if len(overlaps) != 1 || overlaps[0].SlotIDs != [2]uint32{first, second} {
+			t.Errorf("incorrect overlaps got %+v", overlaps)
+		}
+	})
+
+	t.Run("organizers see interest per slot", func(t *testing.T) {
+		interest, err := slotInterest(ctx, organizer.ID, 1)
+		assertDatabaseError(t, err)
+		for _, s := range interest {
+			if s.SlotID == first && (s.Bookmarks != 1 || s.Interested != 1 || s.Location.Capacity == 0) {
+				t.Errorf("incorrect interest got %+v", s)
+			}
+		}
+		if _, err := slotInterest(ctx, attendee.ID, 1); err == nil {
+			t.Errorf("attendees reading slot interest did not cause an error")
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+
+	"encore.dev/storage/sqldb"
+)
+
+// insertBookmark adds a slot to the agenda of a user, bookmarking it twice does nothing.
+func insertBookmark(ctx context.Context, userID, slotID uint32) error {
+	_, err := sqldb.Exec(ctx, `INSERT INTO agenda_bookmark (user_id, conference_slot_id) VALUES ($1, $2)
+	ON CONFLICT DO NOTHING`, userID, slotID)
+	if err != nil {
+		return fmt.Errorf("saving bookmark: %w", err)
+	}
+	return nil
+}
+
+// deleteBookmark removes a slot from the agenda of a user.
+func deleteBookmark(ctx context.Context, userID, slotID uint32) error {
+	_, err := sqldb.Exec(ctx, `DELETE FROM agenda_bookmark WHERE user_id = $1 AND conference_slot_id = $2`,
+		userID, slotID)
+	if err != nil {
+		return fmt.Errorf("removing bookmark: %w", err)
+	}
+	return nil
+}
+
+// readSlotInterest returns how many users bookmarked or claimed each slot of a conference.
+func readSlotInterest(ctx context.Context, conferenceID uint32) ([]SlotInterest, error) {
+	rows, err := sqldb.Query(ctx, `SELECT conference_slot.id, conference_slot.name, conference_slot.start_date,
+	conference_slot.end_date, COALESCE(location.id, 0), COALESCE(location.name, ''), COALESCE(location.capacity, 0),
+	(SELECT COUNT(*) FROM agenda_bookmark WHERE agenda_bookmark.conference_slot_id = conference_slot.id),
+	(SELECT COUNT(*) FROM slot_claim WHERE slot_claim.conference_slot_id = conference_slot.id AND slot_claim.revoked = FALSE),
+	(SELECT COUNT(*) FROM (
+		SELECT user_id FROM agenda_bookmark WHERE agenda_bookmark.conference_slot_id = conference_slot.id
+		UNION
+		SELECT user_id FROM slot_claim WHERE slot_claim.conference_slot_id = conference_slot.id AND slot_claim.revoked = FALSE
+	) AS interested)
+	FROM conference_slot
+	LEFT JOIN location ON conference_slot.location_id = location.id
+	WHERE conference_slot.conference_id = $1
+	ORDER BY conference_slot.start_date, conference_slot.id`, conferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying slot interest: %w", err)
+	}
+	defer rows.Close()
+
+	interest := []SlotInterest{}
+	for rows.Next() {
+		s := SlotInterest{}
+		err := rows.Scan(&s.SlotID,
+			&s.Name,
+			&s.StartDate,
+			&s.EndDate,
+			&s.Location.ID,
+			&s.Location.Name,
+			&s.Location.Capacity,
+			&s.Bookmarks,
+			&s.Claims,
+			&s.Interested)
+		if err != nil {
+			return nil, fmt.Errorf("scanning slot interest: %w", err)
+		}
+		s.compareToCapacity()
+		interest = append(interest, s)
+	}
+	return interest, nil
+}
@@ -0,0 +1,68 @@
+package conferences
+
+import (
+	"sort"
+	"time"
+)
+
+// AgendaItem is a slot in the personal agenda of a user, either bookmarked or claimed
+type AgendaItem struct {
+	Slot       ConferenceSlot
+	Bookmarked bool
+	Claimed    bool
+}
+
+// AgendaOverlap warns about two slots in an agenda happening at the same time
+type AgendaOverlap struct {
+	SlotIDs [2]uint32
+}
+
+// agendaOverlaps returns every pair of overlapping slots in an agenda.
+func agendaOverlaps(items []AgendaItem) []AgendaOverlap {
+	sorted := make([]AgendaItem, len(items))
+	copy(sorted, items)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].Slot.StartDate.Equal(sorted[j].Slot.StartDate) {
+			return sorted[i].Slot.StartDate.Before(sorted[j].Slot.StartDate)
+		}
+		return sorted[i].Slot.ID < sorted[j].Slot.ID
+	})
+
+	overlaps := []AgendaOverlap{}
+	for i := range sorted {
+		a := sorted[i].Slot
+		for _, item := range sorted[i+1:] {
+			b := item.Slot
+			if !b.StartDate.Before(a.EndDate) {
+				break
+			}
+			overlaps = append(overlaps, AgendaOverlap{SlotIDs: [2]uint32{a.ID, b.ID}})
+		}
+	}
+	return overlaps
+}
+
+// SlotInterest compares how many people want to attend a slot with the capacity of its location
+type SlotInterest struct {
+	SlotID    uint32
+	Name      string
+	StartDate time.Time
+	EndDate   time.Time
+	Location  Location
+	Bookmarks int
+	Claims    int
+	// Interested counts users who bookmarked or claimed the slot once.
+	Interested int
+	// FillPercent is Interested as a percentage of Location.Capacity, zero if it is unknown.
+	FillPercent  int
+	OverCapacity bool
+}
+
+// compareToCapacity fills in FillPercent and OverCapacity.
+func (s *SlotInterest) compareToCapacity() {
+	if s.Location.Capacity <= 0 {
+		return
+	}
+	s.FillPercent = s.Interested * 100 / s.Location.Capacity
+	s.OverCapacity = s.Interested > s.Location.Capacity
+}
@@ -0,0 +1,35 @@
+package conferences
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAgendaOverlaps(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2021, 11, 10, hour, 0, 0, 0, time.UTC) }
+	items := []AgendaItem{
+		{Slot: ConferenceSlot{ID: 3, StartDate: at(11), EndDate: at(12)}},
+		{Slot: ConferenceSlot{ID: 1, StartDate: at(9), EndDate: at(11)}},
+		{Slot: ConferenceSlot{ID: 2, StartDate: at(10), EndDate: at(11)}},
+	}
+
+	overlaps := agendaOverlaps(items)
+
+	if len(overlaps) != 1 || overlaps[0].SlotIDs != [2]uint32{1, 2} {
+		t.Errorf("incorrect overlaps got %+v", overlaps)
+	}
+}
+
+func TestSlotInterestCompareToCapacity(t *testing.T) {
+	popular := SlotInterest{Interested: 150, Location: Location{Capacity: 100}}
+	popular.compareToCapacity()
+	if !popular.OverCapacity || popular.FillPercent != 150 {
+		t.Errorf("incorrect comparison got %+v", popular)
+	}
+
+	unknown := SlotInterest{Interested: 150}
+	unknown.compareToCapacity()
+	if unknown.OverCapacity || unknown.FillPercent != 0 {
+		t.Errorf("slots without capacity cannot be over it got %+v", unknown)
+	}
+}
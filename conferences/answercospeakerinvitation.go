@@ -0,0 +1,28 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// AnswerCoSpeakerInvitationParams defines the inputs used by the AnswerCoSpeakerInvitation API method
+type AnswerCoSpeakerInvitationParams struct {
+	PaperID uint32
+	Accept  bool
+}
+
+// AnswerCoSpeakerInvitation accepts or declines an invitation to co-present a paper sent to the
+// email of the authenticated user
+// encore:api auth
+func AnswerCoSpeakerInvitation(ctx context.Context, params *AnswerCoSpeakerInvitationParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := answerCoSpeakerInvitation(ctx, userID, params.PaperID, params.Accept); err != nil {
+		return fmt.Errorf("failed to answer invitation: %w", err)
+	}
+
+	return nil
+}
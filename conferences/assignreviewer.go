@@ -0,0 +1,60 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// AssignReviewerParams defines the inputs used by the AssignReviewer API method
+type AssignReviewerParams struct {
+	ConferenceID uint32
+	UserID       uint32
+}
+
+// AssignReviewer allows a user to review the papers submitted to a conference
+// encore:api auth
+func AssignReviewer(ctx context.Context, params *AssignReviewerParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+
+	if err := assignReviewer(ctx, params.ConferenceID, params.UserID); err != nil {
+		return fmt.Errorf("failed to assign reviewer: %w", err)
+	}
+
+	return nil
+}
+
+// SetScoringRubricParams defines the inputs used by the SetScoringRubric API method
+type SetScoringRubricParams struct {
+	ConferenceID uint32
+	Criteria     []ScoringCriterion
+}
+
+// SetScoringRubricResponse defines the output returned by the SetScoringRubric API method
+type SetScoringRubricResponse struct {
+	Criteria []ScoringCriterion
+}
+
+// SetScoringRubric replaces the criteria reviewers score the papers of a conference with
+// encore:api auth
+func SetScoringRubric(ctx context.Context, params *SetScoringRubricParams) (*SetScoringRubricResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+
+	criteria, err := setRubric(ctx, params.ConferenceID, params.Criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set scoring rubric: %w", err)
+	}
+
+	return &SetScoringRubricResponse{Criteria: criteria}, nil
+}
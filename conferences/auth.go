@@ -2,8 +2,10 @@ package conferences
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"encore.dev/beta/auth"
@@ -21,12 +23,44 @@ type User struct {
 	FamilyName     string
 	CreatedAt      time.Time
 	Claims         []SlotClaim
+	// Flagged is set by the CoC team when a user has been found to breach the code of conduct.
+	Flagged bool
 }
 
-// VerifyToken accepts a JWT token and returns a UserID, or an error.
+// Role is a permission granted to a user on top of being an attendee.
+type Role string
+
+// These are the valid roles
+const (
+	// RoleOrganizer can manage conferences and everything attached to them.
+	RoleOrganizer Role = "organizer"
+	// RoleCoCTeam can see and handle Code of Conduct incident reports.
+	RoleCoCTeam Role = "coc_team"
+)
+
+// valid returns true for the roles that can be granted.
+func (r Role) valid() bool {
+	switch r {
+	case RoleOrganizer, RoleCoCTeam:
+		return true
+	}
+	return false
+}
+
+// VerifyToken accepts a JWT token or one of our session tokens and returns a UserID, or an error.
 // Return a zero-value UID for Unauthorized, return a non-nil error for a 500 error
 // encore:authhandler
 func VerifyToken(ctx context.Context, token string) (auth.UID, error) {
+	if isSessionToken(token) {
+		userID, err := authenticateSession(ctx, token, time.Now())
+		if err != nil {
+			log.Println("verify session token error: ", err)
+			// return nil error and zero value id to trigger unauthorized response
+			return "", nil
+		}
+		return auth.UID(strconv.FormatUint(uint64(userID), 10)), nil
+	}
+
 	provider, err := oidc.NewProvider(ctx, "https://dev-7217861.okta.com")
 	if err != nil {
 		log.Println("provider create error", err)
@@ -41,15 +75,145 @@ func VerifyToken(ctx context.Context, token string) (auth.UID, error) {
 		return "", nil
 	}
 
-	var sqlStatement = `
-	INSERT INTO users (id)
-	VALUES $1
-	ON CONFLICT (id) DO NOTHING`
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	if err := idt.Claims(&claims); err != nil {
+		log.Println("token claims error: ", err)
+		// return nil error and zero value id to trigger unauthorized response
+		return "", nil
+	}
+	if claims.Email == "" || !claims.EmailVerified {
+		log.Println("token without a verified email for subject ", idt.Subject)
+		// return nil error and zero value id to trigger unauthorized response
+		return "", nil
+	}
 
-	_, err = sqldb.Exec(ctx, sqlStatement, idt.Subject)
+	userID, err := oidcUserID(ctx, idt.Subject, claims.Email)
 	if err != nil {
-		return "", fmt.Errorf("failed to insert user: %w", err)
+		return "", fmt.Errorf("failed to find user: %w", err)
 	}
 
-	return auth.UID(idt.Subject), nil
+	return auth.UID(strconv.FormatUint(uint64(userID), 10)), nil
+}
+
+// oidcUserID returns the users.id of the OIDC subject. On their first login the subject is linked
+// to the oldest user with their email, or to a new user when there is none.
+func oidcUserID(ctx context.Context, subject, email string) (uint32, error) {
+	var userID uint32
+	err := sqldb.QueryRow(ctx, `SELECT id FROM users WHERE oidc_subject = $1`, subject).Scan(&userID)
+	if err == nil {
+		return userID, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, fmt.Errorf("reading oidc user: %w", err)
+	}
+
+	err = sqldb.QueryRow(ctx, `UPDATE users SET oidc_subject = $1, email_verified = TRUE
+	WHERE id = (SELECT id FROM users WHERE LOWER(email) = LOWER($2) AND oidc_subject IS NULL AND erased_at IS NULL
+		ORDER BY id LIMIT 1)
+	RETURNING id`, subject, email).Scan(&userID)
+	if err == nil {
+		return userID, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, fmt.Errorf("linking oidc user: %w", err)
+	}
+
+	err = sqldb.QueryRow(ctx, `INSERT INTO users (email, coc_accepted, email_verified, oidc_subject, created_at)
+	VALUES ($1, FALSE, TRUE, $2, NOW())
+	ON CONFLICT (oidc_subject) DO UPDATE SET oidc_subject = EXCLUDED.oidc_subject
+	RETURNING id`, email, subject).Scan(&userID)
+	if err != nil {
+		return 0, fmt.Errorf("creating oidc user: %w", err)
+	}
+	return userID, nil
+}
+
+// authenticatedUserID returns the users.id of the user making the request.
+func authenticatedUserID() (uint32, error) {
+	uid, ok := auth.UserID()
+	if !ok {
+		return 0, fmt.Errorf("authentication required")
+	}
+	id, err := strconv.ParseUint(string(uid), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user id %q: %w", uid, err)
+	}
+	return uint32(id), nil
+}
+
+// hasRole returns true if the user has been granted the passed role.
+func hasRole(ctx context.Context, tx *sqldb.Tx, userID uint32, role Role) (bool, error) {
+	sqlStatement := `SELECT EXISTS(SELECT 1 FROM user_role WHERE user_id = $1 AND role = $2)`
+	sqlArgs := []interface{}{userID, string(role)}
+	var row *sqldb.Row
+
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
+	}
+
+	var granted bool
+	if err := row.Scan(&granted); err != nil {
+		return false, fmt.Errorf("checking user role: %w", err)
+	}
+	return granted, nil
+}
+
+// requireRole returns an error unless the user has been granted the passed role.
+func requireRole(ctx context.Context, userID uint32, role Role) error {
+	granted, err := hasRole(ctx, nil, userID, role)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return fmt.Errorf("user %d is not allowed to do this, %s role required", userID, role)
+	}
+	return nil
+}
+
+// grantRole grants the passed role to a user, granting it twice is a no-op.
+func grantRole(ctx context.Context, tx *sqldb.Tx, userID uint32, role Role) error {
+	sqlStatement := `INSERT INTO user_role (user_id, role) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	sqlArgs := []interface{}{userID, string(role)}
+	var err error
+
+	if tx != nil {
+		_, err = sqldb.ExecTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		_, err = sqldb.Exec(ctx, sqlStatement, sqlArgs...)
+	}
+	if err != nil {
+		return fmt.Errorf("granting role %s: %w", role, err)
+	}
+	return nil
+}
+
+// revokeRole takes the passed role away from a user, revoking a role they do not have is a no-op.
+func revokeRole(ctx context.Context, userID uint32, role Role) error {
+	_, err := sqldb.Exec(ctx, `DELETE FROM user_role WHERE user_id = $1 AND role = $2`, userID, string(role))
+	if err != nil {
+		return fmt.Errorf("revoking role %s: %w", role, err)
+	}
+	return nil
+}
+
+// grantFirstOrganizer makes the user with the verified email an organizer unless there is one
+// already, returns false when nothing was granted.
+func grantFirstOrganizer(ctx context.Context, userID uint32, email string) (bool, error) {
+	res, err := sqldb.Exec(ctx, `INSERT INTO user_role (user_id, role)
+	SELECT id, $1 FROM users WHERE id = $2 AND LOWER(email) = LOWER($3) AND email_verified
+		AND NOT EXISTS (SELECT 1 FROM user_role WHERE role = $1)
+	ON CONFLICT DO NOTHING`, string(RoleOrganizer), userID, email)
+	if err != nil {
+		return false, fmt.Errorf("granting first organizer: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	return ra == 1, nil
 }
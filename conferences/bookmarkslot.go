@@ -0,0 +1,48 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// BookmarkSlotParams defines the inputs used by the BookmarkSlot and RemoveBookmark API methods
+type BookmarkSlotParams struct {
+	ConferenceSlotID uint32
+}
+
+// BookmarkSlotResponse defines the output returned by the BookmarkSlot API method
+type BookmarkSlotResponse struct {
+	// Overlaps warns about slots in the agenda happening at the same time as the bookmarked one.
+	Overlaps []AgendaOverlap
+}
+
+// BookmarkSlot adds a slot to the agenda of the authenticated user, no ticket is needed
+// encore:api auth
+func BookmarkSlot(ctx context.Context, params *BookmarkSlotParams) (*BookmarkSlotResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	overlaps, err := bookmarkSlot(ctx, userID, params.ConferenceSlotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bookmark slot: %w", err)
+	}
+
+	return &BookmarkSlotResponse{Overlaps: overlaps}, nil
+}
+
+// RemoveBookmark removes a slot from the agenda of the authenticated user
+// encore:api auth
+func RemoveBookmark(ctx context.Context, params *BookmarkSlotParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := deleteBookmark(ctx, userID, params.ConferenceSlotID); err != nil {
+		return fmt.Errorf("failed to remove bookmark: %w", err)
+	}
+
+	return nil
+}
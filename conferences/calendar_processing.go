@@ -0,0 +1,108 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CalendarFilter narrows down a conference calendar, zero fields do not filter. Slots have no
+// track so filtering by track leaves only talks.
+type CalendarFilter struct {
+	LocationID uint32
+	Track      string
+}
+
+// conferenceCalendar returns the scheduled talks and public slots of a conference as an iCalendar.
+func conferenceCalendar(ctx context.Context, conferenceID uint32, filter CalendarFilter, now time.Time) (string, error) {
+	name, err := readConferenceName(ctx, conferenceID)
+	if err != nil {
+		return "", err
+	}
+	talks, err := scheduledTalks(ctx, conferenceID)
+	if err != nil {
+		return "", err
+	}
+	slots, err := readPublicConferenceSlots(ctx, conferenceID)
+	if err != nil {
+		return "", err
+	}
+
+	events := []calendarEvent{}
+	for _, t := range talks {
+		if filter.LocationID != 0 && t.Location.ID != filter.LocationID {
+			continue
+		}
+		if filter.Track != "" && t.Track != filter.Track {
+			continue
+		}
+		events = append(events, talkEvent(t))
+	}
+	if filter.Track == "" {
+		for _, s := range slots {
+			if filter.LocationID != 0 && s.Location.ID != filter.LocationID {
+				continue
+			}
+			events = append(events, slotEvent(s))
+		}
+	}
+	return writeCalendar(name, events, now), nil
+}
+
+// issueCalendarFeedToken returns a new token to subscribe to the personal calendar of the user,
+// previous tokens stop working.
+func issueCalendarFeedToken(ctx context.Context, userID uint32) (string, error) {
+	token, tokenHash, err := newOneTimeToken()
+	if err != nil {
+		return "", err
+	}
+	if err := upsertCalendarFeedToken(ctx, userID, tokenHash); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// personalCalendar returns the calendar of the user a feed token belongs to with the sessions
+// scheduled in the slots they bookmarked or hold claims for, slots with no talks scheduled in them
+// appear on their own.
+func personalCalendar(ctx context.Context, token string, now time.Time) (string, error) {
+	userID, err := readCalendarFeedUser(ctx, hashOneTimeToken(token))
+	if err != nil {
+		return "", err
+	}
+	if userID == 0 {
+		return "", fmt.Errorf("no such calendar")
+	}
+	items, _, err := personalAgenda(ctx, userID, 0)
+	if err != nil {
+		return "", err
+	}
+
+	talks := map[uint32][]ScheduledTalk{}
+	read := map[uint32]bool{}
+	for _, item := range items {
+		if read[item.Slot.ConferenceID] {
+			continue
+		}
+		read[item.Slot.ConferenceID] = true
+		scheduled, err := scheduledTalks(ctx, item.Slot.ConferenceID)
+		if err != nil {
+			return "", err
+		}
+		for _, t := range scheduled {
+			talks[t.ConferenceSlotID] = append(talks[t.ConferenceSlotID], t)
+		}
+	}
+
+	events := make([]calendarEvent, 0, len(items))
+	for _, item := range items {
+		if len(talks[item.Slot.ID]) == 0 {
+			events = append(events, slotEvent(item.Slot))
+			continue
+		}
+		for _, t := range talks[item.Slot.ID] {
+			events = append(events, talkEvent(t))
+		}
+	}
+	return writeCalendar("My agenda", events, now), nil
+}
@@ -0,0 +1,79 @@
+package conferences
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"encore.dev/storage/sqldb"
+	"github.com/gofrs/uuid"
+)
+
+func TestPersonalCalendar(t *testing.T) {
+	ctx := context.Background()
+
+	attendee, err := createAttendee(ctx, nil, &User{Email: "calendar@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	start := time.Date(2020, 11, 11, 17, 0, 0, 0, time.UTC)
+	row := sqldb.QueryRow(ctx, `INSERT INTO conference_slot (name, description, cost, capacity, start_date, end_date,
+	purchaseable_from, purchaseable_until, available_to_public, conference_id, location_id)
+	VALUES ('Gopher party', 'Bring your gopher', 0, 100, $1, $2, $1, $1, TRUE, 1, 1) RETURNING id`, start, start.Add(3*time.Hour))
+	var slotID uint32
+	assertDatabaseError(t, row.Scan(&slotID))
+	_, err = createSlotClaim(ctx, nil, &SlotClaim{
+		ConferenceSlot: &ConferenceSlot{ID: slotID},
+		TicketID:       uuid.Must(uuid.NewV4()),
+	}, attendee.ID)
+	assertDatabaseError(t, err)
+
+	token, err := issueCalendarFeedToken(ctx, attendee.ID)
+	assertDatabaseError(t, err)
+
+	t.Run("the feed lists claimed slots", func(t *testing.T) {
+		calendar, err := personalCalendar(ctx, token, time.Now())
+		assertDatabaseError(t, err)
+		if !strings.Contains(calendar, "UID:"+slotEventUID(slotID)+"\r\n") {
+			t.Errorf("claimed slot missing from calendar %q", calendar)
+		}
+	})
+
+	t.Run("the feed lists the talks of bookmarked slots", func(t *testing.T) {
+		organizer := createOrganizer(t, ctx, "calendar-organizer@gophercon.com")
+		speaker, err := createAttendee(ctx, nil, &User{Email: "calendar-speaker@gophercon.com", CoCAccepted: true})
+		assertDatabaseError(t, err)
+
+		row := sqldb.QueryRow(ctx, `INSERT INTO conference_slot (name, description, cost, capacity, start_date, end_date,
+		purchaseable_from, purchaseable_until, available_to_public, conference_id, location_id)
+		VALUES ('Morning talks', 'Main track', 0, 400, $1, $2, $1, $1, TRUE, 1, 1) RETURNING id`, start.Add(24*time.Hour), start.Add(26*time.Hour))
+		var talksSlotID uint32
+		assertDatabaseError(t, row.Scan(&talksSlotID))
+		paperID, err := submitPaper(ctx, speaker.ID, &Paper{
+			ConferenceID:  1,
+			Title:         "Calendars in Go",
+			ElevatorPitch: "Pitch",
+			Description:   "Description",
+			Format:        TalkFormatShort,
+		})
+		assertDatabaseError(t, err)
+		assertDatabaseError(t, updatePaperStatus(ctx, nil, paperID, PaperStatusAccepted))
+		_, _, err = scheduleTalk(ctx, organizer.ID, ScheduleEntry{PaperID: paperID, ConferenceSlotID: talksSlotID}, false)
+		assertDatabaseError(t, err)
+		_, err = bookmarkSlot(ctx, attendee.ID, talksSlotID)
+		assertDatabaseError(t, err)
+
+		calendar, err := personalCalendar(ctx, token, time.Now())
+		assertDatabaseError(t, err)
+		if !strings.Contains(calendar, "UID:"+talkEventUID(paperID)+"\r\n") {
+			t.Errorf("talk of bookmarked slot missing from calendar %q", calendar)
+		}
+	})
+
+	t.Run("a new token revokes the previous one", func(t *testing.T) {
+		_, err := issueCalendarFeedToken(ctx, attendee.ID)
+		assertDatabaseError(t, err)
+		if _, err := personalCalendar(ctx, token, time.Now()); err == nil {
+			t.Errorf("reading a calendar with a revoked token did not cause an error")
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"encore.dev/storage/sqldb"
+)
+
+const calendarSlotColumns = `conference_slot.id, conference_slot.name, conference_slot.description,
+	conference_slot.start_date, conference_slot.end_date, conference_slot.conference_id,
+	COALESCE(location.id, 0), COALESCE(location.name, ''), COALESCE(location.address, '')
+	FROM conference_slot
+	LEFT JOIN location ON conference_slot.location_id = location.id`
+
+// readCalendarSlots runs a query selecting calendarSlotColumns and returns the slots.
+func readCalendarSlots(ctx context.Context, query string, args ...interface{}) ([]ConferenceSlot, error) {
+	rows, err := sqldb.Query(ctx, `SELECT `+calendarSlotColumns+` `+query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying slots: %w", err)
+	}
+	defer rows.Close()
+
+	slots := []ConferenceSlot{}
+	for rows.Next() {
+		s := ConferenceSlot{}
+		err := rows.Scan(&s.ID,
+			&s.Name,
+			&s.Description,
+			&s.StartDate,
+			&s.EndDate,
+			&s.ConferenceID,
+			&s.Location.ID,
+			&s.Location.Name,
+			&s.Location.Address)
+		if err != nil {
+			return nil, fmt.Errorf("scanning slot: %w", err)
+		}
+		slots = append(slots, s)
+	}
+	return slots, nil
+}
+
+// readPublicConferenceSlots returns the slots of a conference that appear on the tickets page.
+func readPublicConferenceSlots(ctx context.Context, conferenceID uint32) ([]ConferenceSlot, error) {
+	return readCalendarSlots(ctx, `WHERE conference_slot.conference_id = $1 AND conference_slot.available_to_public = TRUE
+	ORDER BY conference_slot.start_date, conference_slot.id`, conferenceID)
+}
+
+// readClaimedSlots returns the slots a user holds a claim for that was not revoked.
+func readClaimedSlots(ctx context.Context, userID uint32) ([]ConferenceSlot, error) {
+	return readCalendarSlots(ctx, `WHERE conference_slot.id IN (
+		SELECT conference_slot_id FROM slot_claim WHERE user_id = $1 AND revoked = FALSE
+	) ORDER BY conference_slot.start_date, conference_slot.id`, userID)
+}
+
+// readConferenceName returns the name of a conference.
+func readConferenceName(ctx context.Context, conferenceID uint32) (string, error) {
+	row := sqldb.QueryRow(ctx, `SELECT name FROM conference WHERE id = $1`, conferenceID)
+
+	var name string
+	err := row.Scan(&name)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("no such conference")
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading conference name: %w", err)
+	}
+	return name, nil
+}
+
+// upsertCalendarFeedToken replaces the token a user subscribes to their calendar with.
+func upsertCalendarFeedToken(ctx context.Context, userID uint32, tokenHash string) error {
+	_, err := sqldb.Exec(ctx, `INSERT INTO calendar_feed_token (user_id, token_hash) VALUES ($1, $2)
+	ON CONFLICT (user_id) DO UPDATE SET token_hash = $2, created_at = NOW()`, userID, tokenHash)
+	if err != nil {
+		return fmt.Errorf("saving calendar feed token: %w", err)
+	}
+	return nil
+}
+
+// readCalendarFeedUser returns the user a calendar feed token belongs to, zero if none does.
+func readCalendarFeedUser(ctx context.Context, tokenHash string) (uint32, error) {
+	row := sqldb.QueryRow(ctx, `SELECT user_id FROM calendar_feed_token WHERE token_hash = $1`, tokenHash)
+
+	var userID uint32
+	err := row.Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading calendar feed token: %w", err)
+	}
+	return userID, nil
+}
+
+// readBookmarkedSlots returns the slots a user bookmarked in a conference, all conferences if zero.
+func readBookmarkedSlots(ctx context.Context, userID, conferenceID uint32) ([]ConferenceSlot, error) {
+	return readCalendarSlots(ctx, `WHERE conference_slot.id IN (
+		SELECT conference_slot_id FROM agenda_bookmark WHERE user_id = $1
+	) AND ($2 = 0 OR conference_slot.conference_id = $2)
+	ORDER BY conference_slot.start_date, conference_slot.id`, userID, conferenceID)
+}
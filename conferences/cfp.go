@@ -0,0 +1,126 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+// readCFPRules returns the call for papers rules of a conference, nil if none were defined.
+func readCFPRules(ctx context.Context, tx *sqldb.Tx, conferenceID uint32) (*CFPRules, error) {
+	sqlStatement := `SELECT conference_id, opens_at, closes_at, max_submissions_per_speaker, title_max_length,
+	elevator_pitch_max_length, description_min_length, description_max_length
+	FROM cfp_rules WHERE conference_id = $1`
+	var row *sqldb.Row
+
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, conferenceID)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, conferenceID)
+	}
+
+	rules := CFPRules{}
+	err := row.Scan(&rules.ConferenceID,
+		&rules.OpensAt,
+		&rules.ClosesAt,
+		&rules.MaxSubmissionsPerSpeaker,
+		&rules.TitleMaxLength,
+		&rules.ElevatorPitchMaxLength,
+		&rules.DescriptionMinLength,
+		&rules.DescriptionMaxLength)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading cfp rules: %w", err)
+	}
+	return &rules, nil
+}
+
+// upsertCFPRules saves the call for papers rules of a conference replacing existing ones.
+func upsertCFPRules(ctx context.Context, rules *CFPRules) error {
+	_, err := sqldb.Exec(ctx, `INSERT INTO cfp_rules (conference_id, opens_at, closes_at, max_submissions_per_speaker,
+	title_max_length, elevator_pitch_max_length, description_min_length, description_max_length)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (conference_id) DO UPDATE SET opens_at = $2, closes_at = $3, max_submissions_per_speaker = $4,
+	title_max_length = $5, elevator_pitch_max_length = $6, description_min_length = $7, description_max_length = $8`,
+		rules.ConferenceID,
+		rules.OpensAt,
+		rules.ClosesAt,
+		rules.MaxSubmissionsPerSpeaker,
+		rules.TitleMaxLength,
+		rules.ElevatorPitchMaxLength,
+		rules.DescriptionMinLength,
+		rules.DescriptionMaxLength)
+	if err != nil {
+		return fmt.Errorf("saving cfp rules: %w", err)
+	}
+	return nil
+}
+
+// countSpeakerPapers returns how many papers a user has submitted to a conference and not withdrawn.
+func countSpeakerPapers(ctx context.Context, tx *sqldb.Tx, conferenceID, userID uint32) (int, error) {
+	sqlStatement := `SELECT COUNT(*) FROM paper_submission
+	WHERE conference_id = $1 AND user_id = $2 AND withdrawn = FALSE`
+	sqlArgs := []interface{}{conferenceID, userID}
+	var row *sqldb.Row
+
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
+	}
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting speaker papers: %w", err)
+	}
+	return count, nil
+}
+
+// lockSpeakerSubmissions makes concurrent submissions of the user wait for tx to finish, so the
+// papers counted in tx are still all there are when it inserts another.
+func lockSpeakerSubmissions(ctx context.Context, tx *sqldb.Tx, userID uint32) error {
+	var id uint32
+	err := sqldb.QueryRowTx(tx, ctx, `SELECT id FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("no such user")
+	}
+	if err != nil {
+		return fmt.Errorf("locking speaker submissions: %w", err)
+	}
+	return nil
+}
+
+// checkCFPSubmission returns an error if the paper cannot be submitted, or edited when it is not
+// new, at the passed time. Conferences without rules accept papers at any time. New papers must be
+// checked in the tx that inserts them after lockSpeakerSubmissions.
+func checkCFPSubmission(ctx context.Context, tx *sqldb.Tx, paper *Paper, isNew bool, now time.Time) error {
+	rules, err := readCFPRules(ctx, tx, paper.ConferenceID)
+	if err != nil {
+		return err
+	}
+	if rules == nil {
+		return nil
+	}
+	if !rules.Open(now) {
+		return fmt.Errorf("the call for papers is closed")
+	}
+	if err := rules.validatePaper(paper); err != nil {
+		return err
+	}
+	if !isNew || rules.MaxSubmissionsPerSpeaker == 0 {
+		return nil
+	}
+	submitted, err := countSpeakerPapers(ctx, tx, paper.ConferenceID, paper.UserID)
+	if err != nil {
+		return err
+	}
+	if submitted >= rules.MaxSubmissionsPerSpeaker {
+		return fmt.Errorf("a speaker can submit at most %d papers", rules.MaxSubmissionsPerSpeaker)
+	}
+	return nil
+}
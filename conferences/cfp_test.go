@@ -0,0 +1,97 @@
+package conferences
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+func TestCFPRulesValidatePaper(t *testing.T) {
+	rules := &CFPRules{
+		TitleMaxLength:       10,
+		DescriptionMinLength: 5,
+	}
+	valid := Paper{Title: "Go", ElevatorPitch: "Pitch", Description: "Long enough"}
+
+	tests := []struct {
+		name    string
+		edit    func(p *Paper)
+		wantErr bool
+	}{
+		{name: "valid paper", edit: func(p *Paper) {}},
+		{name: "missing title", edit: func(p *Paper) { p.Title = "  " }, wantErr: true},
+		{name: "missing elevator pitch", edit: func(p *Paper) { p.ElevatorPitch = "" }, wantErr: true},
+		{name: "title too long", edit: func(p *Paper) { p.Title = strings.Repeat("g", 11) }, wantErr: true},
+		{name: "description too short", edit: func(p *Paper) { p.Description = "Go" }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paper := valid
+			tt.edit(&paper)
+			if err := rules.validatePaper(&paper); (err != nil) != tt.wantErr {
+				t.Errorf("validatePaper() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCFPWindow(t *testing.T) {
+	ctx := context.Background()
+
+	row := sqldb.QueryRow(ctx, `INSERT INTO conference (name, slug, start_date, end_date, event_id, venue_id)
+	VALUES ('GopherCon CFP Test', 'gc-cfp-test', NOW() + INTERVAL '90 days', NOW() + INTERVAL '93 days', 1, 1)
+	RETURNING id`)
+	var conferenceID uint32
+	assertDatabaseError(t, row.Scan(&conferenceID))
+
+	speaker, err := createAttendee(ctx, nil, &User{Email: "cfpspeaker@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+
+	now := time.Now()
+	assertDatabaseError(t, upsertCFPRules(ctx, &CFPRules{
+		ConferenceID:             conferenceID,
+		OpensAt:                  now.Add(-time.Hour),
+		ClosesAt:                 now.Add(time.Hour),
+		MaxSubmissionsPerSpeaker: 1,
+	}))
+
+	paper := &Paper{
+		UserID:        speaker.ID,
+		ConferenceID:  conferenceID,
+		Title:         "Generics in practice",
+		ElevatorPitch: "What we learnt",
+		Description:   "A year of generics in production",
+	}
+	paperID, err := submitPaper(ctx, speaker.ID, paper)
+	assertDatabaseError(t, err)
+
+	t.Run("limits submissions per speaker", func(t *testing.T) {
+		if _, err := submitPaper(ctx, speaker.ID, paper); err == nil {
+			t.Fatalf("second submission did not cause an error")
+		}
+	})
+
+	t.Run("blocks edits after the cfp closes", func(t *testing.T) {
+		assertDatabaseError(t, upsertCFPRules(ctx, &CFPRules{
+			ConferenceID: conferenceID,
+			OpensAt:      now.Add(-2 * time.Hour),
+			ClosesAt:     now.Add(-time.Hour),
+		}))
+
+		status, err := GetCFPStatus(ctx, &GetCFPStatusParams{ConferenceID: conferenceID})
+		assertDatabaseError(t, err)
+		if status.Open {
+			t.Errorf("cfp should be closed")
+		}
+
+		edited := *paper
+		edited.ID = paperID
+		edited.Title = "Generics in practice, revisited"
+		if _, err := editPaper(ctx, speaker.ID, &edited); err == nil {
+			t.Fatalf("editing after close did not cause an error")
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"encore.dev/storage/sqldb"
+)
+
+// These are the actions recorded in an incident audit log.
+const (
+	incidentActionReported      = "reported"
+	incidentActionViewed        = "viewed"
+	incidentActionStatusChanged = "status_changed"
+	incidentActionNoteAdded     = "note_added"
+	incidentActionUserFlagged   = "user_flagged"
+)
+
+// fileIncident saves a new incident report, reporterID is zero for anonymous reports.
+func fileIncident(ctx context.Context, incident *Incident, reporterID uint32) (*Incident, error) {
+	if incident.ConferenceID == 0 {
+		return nil, fmt.Errorf("conference is required")
+	}
+	if strings.TrimSpace(incident.Description) == "" {
+		return nil, fmt.Errorf("description is required")
+	}
+	incident.ReporterID = reporterID
+
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	saved, err := createIncident(ctx, tx, incident)
+	if err == nil {
+		err = insertIncidentAudit(ctx, tx, saved.ID, reporterID, incidentActionReported, "")
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return nil, fmt.Errorf("filing incident: %w", err)
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return saved, nil
+}
+
+// viewIncident returns the incident and records that actorID has seen it.
+func viewIncident(ctx context.Context, actorID, incidentID uint32) (*Incident, error) {
+	incident, err := readIncidentByID(ctx, nil, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("viewing incident: %w", err)
+	}
+	if incident == nil {
+		return nil, fmt.Errorf("no such incident")
+	}
+	if err := insertIncidentAudit(ctx, nil, incidentID, actorID, incidentActionViewed, ""); err != nil {
+		return nil, fmt.Errorf("viewing incident: %w", err)
+	}
+	// read it again so the audit log includes this view.
+	incident, err = readIncidentByID(ctx, nil, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("viewing incident: %w", err)
+	}
+	if incident == nil {
+		return nil, fmt.Errorf("no such incident")
+	}
+	return incident, nil
+}
+
+// changeIncidentStatus moves an incident through the CoC workflow.
+func changeIncidentStatus(ctx context.Context, actorID, incidentID uint32, status IncidentStatus) error {
+	if !status.valid() {
+		return fmt.Errorf("invalid incident status %q", status)
+	}
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	err = updateIncidentStatus(ctx, tx, incidentID, status)
+	if err == nil {
+		err = insertIncidentAudit(ctx, tx, incidentID, actorID, incidentActionStatusChanged, string(status))
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return fmt.Errorf("changing incident status: %w", err)
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// addIncidentNote adds a note by actorID to an incident.
+func addIncidentNote(ctx context.Context, actorID, incidentID uint32, body string) (*IncidentNote, error) {
+	if strings.TrimSpace(body) == "" {
+		return nil, fmt.Errorf("note body is required")
+	}
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	note, err := insertIncidentNote(ctx, tx, incidentID, &IncidentNote{AuthorID: actorID, Body: body})
+	if err == nil {
+		err = insertIncidentAudit(ctx, tx, incidentID, actorID, incidentActionNoteAdded, fmt.Sprintf("note %d", note.ID))
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return nil, fmt.Errorf("adding incident note: %w", err)
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return note, nil
+}
+
+// flagUserForIncident flags a user as a result of an incident and, if asked to, revokes their
+// claims for the conference the incident happened in. It returns the number of revoked claims.
+func flagUserForIncident(ctx context.Context, actorID, incidentID, userID uint32, reason string, revokeClaims bool) (int64, error) {
+	if strings.TrimSpace(reason) == "" {
+		return 0, fmt.Errorf("a reason is required to flag a user")
+	}
+	incident, err := readIncidentByID(ctx, nil, incidentID)
+	if err != nil {
+		return 0, fmt.Errorf("flagging user: %w", err)
+	}
+	if incident == nil {
+		return 0, fmt.Errorf("no such incident")
+	}
+
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	var revoked int64
+	_, err = flagUser(ctx, tx, userID, incidentID, actorID, reason)
+	if err == nil && revokeClaims {
+		revoked, err = revokeSlotClaims(ctx, tx, userID, incident.ConferenceID)
+	}
+	if err == nil {
+		err = insertIncidentAudit(ctx, tx, incidentID, actorID, incidentActionUserFlagged,
+			fmt.Sprintf("user %d flagged, %d claims revoked", userID, revoked))
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return 0, fmt.Errorf("flagging user: %w", err)
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+	return revoked, nil
+}
@@ -0,0 +1,96 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+)
+
+func TestIncidentWorkflow(t *testing.T) {
+	ctx := context.Background()
+
+	reporter, err := createAttendee(ctx, nil, &User{Email: "reporter@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	cocMember, err := createAttendee(ctx, nil, &User{Email: "coc@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	offender, err := createAttendee(ctx, nil, &User{Email: "offender@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	assertDatabaseError(t, grantRole(ctx, nil, cocMember.ID, RoleCoCTeam))
+
+	// There is an entry for general admision to gophercon 2021 preloaded in the first migration
+	cslot, err := readConferenceSlotByID(ctx, nil, 1, false)
+	assertDatabaseError(t, err)
+	_, err = claimSlots(ctx, offender, []ConferenceSlot{*cslot})
+	assertDatabaseError(t, err)
+
+	t.Run("rejects a report without description", func(t *testing.T) {
+		_, err := fileIncident(ctx, &Incident{ConferenceID: 1}, 0)
+		if err == nil {
+			t.Fatalf("empty description did not cause an error")
+		}
+	})
+
+	t.Run("anonymous report hides the reporter", func(t *testing.T) {
+		incident, err := fileIncident(ctx, &Incident{ConferenceID: 1, Description: "Something happened"}, 0)
+		assertDatabaseError(t, err)
+
+		got, err := viewIncident(ctx, cocMember.ID, incident.ID)
+		assertDatabaseError(t, err)
+		if got.ReporterID != 0 {
+			t.Errorf("anonymous report has a reporter got %v want 0", got.ReporterID)
+		}
+		if got.Status != IncidentStatusNew {
+			t.Errorf("incorrect status got %v want %v", got.Status, IncidentStatusNew)
+		}
+	})
+
+	t.Run("handles a case from report to resolution", func(t *testing.T) {
+		incident, err := fileIncident(ctx, &Incident{
+			ConferenceID:     1,
+			ConferenceSlotID: cslot.ID,
+			Description:      "Harassment during the workshop",
+		}, reporter.ID)
+		assertDatabaseError(t, err)
+
+		assertDatabaseError(t, changeIncidentStatus(ctx, cocMember.ID, incident.ID, IncidentStatusInvestigating))
+		_, err = addIncidentNote(ctx, cocMember.ID, incident.ID, "Spoke with witnesses")
+		assertDatabaseError(t, err)
+
+		revoked, err := flagUserForIncident(ctx, cocMember.ID, incident.ID, offender.ID, "Confirmed by witnesses", true)
+		assertDatabaseError(t, err)
+		if revoked != 1 {
+			t.Errorf("incorrect number of revoked claims got %v want %v", revoked, 1)
+		}
+		assertDatabaseError(t, changeIncidentStatus(ctx, cocMember.ID, incident.ID, IncidentStatusResolved))
+
+		got, err := viewIncident(ctx, cocMember.ID, incident.ID)
+		assertDatabaseError(t, err)
+		if got.ReporterID != reporter.ID {
+			t.Errorf("incorrect reporter got %v want %v", got.ReporterID, reporter.ID)
+		}
+		if got.Status != IncidentStatusResolved {
+			t.Errorf("incorrect status got %v want %v", got.Status, IncidentStatusResolved)
+		}
+		if len(got.Notes) != 1 {
+			t.Errorf("incorrect number of notes got %v want %v", len(got.Notes), 1)
+		}
+		// reported, investigating, note, flag, resolved and the view itself.
+		if len(got.AuditLog) != 6 {
+			t.Errorf("incorrect audit log length got %v want %v", len(got.AuditLog), 6)
+		}
+
+		flagged, err := readAttendeeByID(ctx, nil, offender.ID)
+		assertDatabaseError(t, err)
+		for _, claim := range flagged.Claims {
+			if !claim.Revoked {
+				t.Errorf("claim %d was not revoked", claim.ID)
+			}
+		}
+	})
+
+	t.Run("rejects an unknown status", func(t *testing.T) {
+		err := changeIncidentStatus(ctx, cocMember.ID, 1, IncidentStatus("closed"))
+		if err == nil {
+			t.Fatalf("invalid status did not cause an error")
+		}
+	})
+}
@@ -0,0 +1,288 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+// nullableTime returns a NULL friendly version of t, zero time is stored as NULL.
+func nullableTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// createIncident saves a new incident report and returns it with the populated ID.
+func createIncident(ctx context.Context, tx *sqldb.Tx, incident *Incident) (*Incident, error) {
+	sqlStatement := `INSERT INTO coc_incident (conference_id, reporter_id, conference_slot_id, location_id, occurred_at, description, contact)
+	VALUES ($1, NULLIF($2, 0), NULLIF($3, 0), NULLIF($4, 0), $5, $6, $7)
+	RETURNING id, status, created_at`
+	sqlArgs := []interface{}{
+		incident.ConferenceID,
+		incident.ReporterID,
+		incident.ConferenceSlotID,
+		incident.LocationID,
+		nullableTime(incident.OccurredAt),
+		incident.Description,
+		incident.Contact,
+	}
+	var row *sqldb.Row
+
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
+	}
+
+	result := *incident
+	if err := row.Scan(&result.ID, &result.Status, &result.CreatedAt); err != nil {
+		return nil, fmt.Errorf("saving incident: %w", err)
+	}
+	return &result, nil
+}
+
+const incidentColumns = `id, conference_id, COALESCE(reporter_id, 0), COALESCE(conference_slot_id, 0),
+	COALESCE(location_id, 0), occurred_at, description, contact, status, created_at`
+
+// scanIncident scans a row selected with incidentColumns.
+func scanIncident(scan func(dest ...interface{}) error) (*Incident, error) {
+	incident := Incident{}
+	var occurredAt sql.NullTime
+	err := scan(&incident.ID,
+		&incident.ConferenceID,
+		&incident.ReporterID,
+		&incident.ConferenceSlotID,
+		&incident.LocationID,
+		&occurredAt,
+		&incident.Description,
+		&incident.Contact,
+		&incident.Status,
+		&incident.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	incident.OccurredAt = occurredAt.Time
+	return &incident, nil
+}
+
+// readIncidentByID returns the incident with its notes and audit log, nil if it does not exist.
+func readIncidentByID(ctx context.Context, tx *sqldb.Tx, id uint32) (*Incident, error) {
+	sqlStatement := `SELECT ` + incidentColumns + ` FROM coc_incident WHERE id = $1`
+	var row *sqldb.Row
+
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, id)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, id)
+	}
+
+	incident, err := scanIncident(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading incident by id: %w", err)
+	}
+
+	var rows *sqldb.Rows
+	sqlStatement = `SELECT id, author_id, body, created_at FROM coc_incident_note
+	WHERE incident_id = $1 ORDER BY id`
+	if tx != nil {
+		rows, err = sqldb.QueryTx(tx, ctx, sqlStatement, id)
+	} else {
+		rows, err = sqldb.Query(ctx, sqlStatement, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying notes for incident: %w", err)
+	}
+	defer rows.Close()
+
+	incident.Notes = []IncidentNote{}
+	for rows.Next() {
+		note := IncidentNote{}
+		if err := rows.Scan(&note.ID, &note.AuthorID, &note.Body, &note.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning note for incident: %w", err)
+		}
+		incident.Notes = append(incident.Notes, note)
+	}
+
+	sqlStatement = `SELECT id, COALESCE(actor_id, 0), action, detail, created_at FROM coc_incident_audit
+	WHERE incident_id = $1 ORDER BY id`
+	if tx != nil {
+		rows, err = sqldb.QueryTx(tx, ctx, sqlStatement, id)
+	} else {
+		rows, err = sqldb.Query(ctx, sqlStatement, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying audit log for incident: %w", err)
+	}
+	defer rows.Close()
+
+	incident.AuditLog = []IncidentAuditEntry{}
+	for rows.Next() {
+		entry := IncidentAuditEntry{}
+		if err := rows.Scan(&entry.ID, &entry.ActorID, &entry.Action, &entry.Detail, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scanning audit entry for incident: %w", err)
+		}
+		incident.AuditLog = append(incident.AuditLog, entry)
+	}
+
+	return incident, nil
+}
+
+// listIncidents returns the incidents for a conference, optionally only those in the passed status.
+func listIncidents(ctx context.Context, conferenceID uint32, status IncidentStatus) ([]Incident, error) {
+	sqlStatement := `SELECT ` + incidentColumns + ` FROM coc_incident
+	WHERE conference_id = $1 AND ($2 = '' OR status::TEXT = $2)
+	ORDER BY created_at DESC, id DESC`
+
+	rows, err := sqldb.Query(ctx, sqlStatement, conferenceID, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("querying incidents: %w", err)
+	}
+	defer rows.Close()
+
+	incidents := []Incident{}
+	for rows.Next() {
+		incident, err := scanIncident(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning incident: %w", err)
+		}
+		incidents = append(incidents, *incident)
+	}
+	return incidents, nil
+}
+
+// updateIncidentStatus moves the incident to the passed status.
+func updateIncidentStatus(ctx context.Context, tx *sqldb.Tx, id uint32, status IncidentStatus) error {
+	sqlStatement := `UPDATE coc_incident SET status = $1 WHERE id = $2`
+	sqlArgs := []interface{}{string(status), id}
+	var res sql.Result
+	var err error
+
+	if tx != nil {
+		res, err = sqldb.ExecTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		res, err = sqldb.Exec(ctx, sqlStatement, sqlArgs...)
+	}
+	if err != nil {
+		return fmt.Errorf("updating incident status: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	if ra == 0 {
+		return fmt.Errorf("no such incident")
+	}
+	return nil
+}
+
+// insertIncidentNote saves a note for an incident.
+func insertIncidentNote(ctx context.Context, tx *sqldb.Tx, incidentID uint32, note *IncidentNote) (*IncidentNote, error) {
+	sqlStatement := `INSERT INTO coc_incident_note (incident_id, author_id, body) VALUES ($1, $2, $3)
+	RETURNING id, author_id, body, created_at`
+	sqlArgs := []interface{}{incidentID, note.AuthorID, note.Body}
+	var row *sqldb.Row
+
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
+	}
+
+	result := IncidentNote{}
+	if err := row.Scan(&result.ID, &result.AuthorID, &result.Body, &result.CreatedAt); err != nil {
+		return nil, fmt.Errorf("saving incident note: %w", err)
+	}
+	return &result, nil
+}
+
+// insertIncidentAudit records an action taken on an incident, actorID 0 means anonymous.
+func insertIncidentAudit(ctx context.Context, tx *sqldb.Tx, incidentID, actorID uint32, action, detail string) error {
+	sqlStatement := `INSERT INTO coc_incident_audit (incident_id, actor_id, action, detail) VALUES ($1, NULLIF($2, 0), $3, $4)`
+	sqlArgs := []interface{}{incidentID, actorID, action, detail}
+	var err error
+
+	if tx != nil {
+		_, err = sqldb.ExecTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		_, err = sqldb.Exec(ctx, sqlStatement, sqlArgs...)
+	}
+	if err != nil {
+		return fmt.Errorf("saving incident audit entry: %w", err)
+	}
+	return nil
+}
+
+// flagUser marks a user as flagged and records why, returning the ID of the flag.
+func flagUser(ctx context.Context, tx *sqldb.Tx, userID, incidentID, flaggedBy uint32, reason string) (uint32, error) {
+	// flagged users are logged out of the sessions they had.
+	sqlStatement := `UPDATE users SET flagged = TRUE, session_version = session_version + 1 WHERE id = $1`
+	var res sql.Result
+	var err error
+
+	if tx != nil {
+		res, err = sqldb.ExecTx(tx, ctx, sqlStatement, userID)
+	} else {
+		res, err = sqldb.Exec(ctx, sqlStatement, userID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("flagging user: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	if ra == 0 {
+		return 0, fmt.Errorf("no such user")
+	}
+
+	sqlStatement = `INSERT INTO user_flag (user_id, incident_id, flagged_by, reason) VALUES ($1, NULLIF($2, 0), $3, $4)
+	RETURNING id`
+	sqlArgs := []interface{}{userID, incidentID, flaggedBy, reason}
+	var row *sqldb.Row
+
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
+	}
+
+	var flagID uint32
+	if err := row.Scan(&flagID); err != nil {
+		return 0, fmt.Errorf("saving user flag: %w", err)
+	}
+	return flagID, nil
+}
+
+// revokeSlotClaims revokes all the user claims for slots of the passed conference and returns
+// how many were revoked.
+func revokeSlotClaims(ctx context.Context, tx *sqldb.Tx, userID, conferenceID uint32) (int64, error) {
+	sqlStatement := `UPDATE slot_claim SET revoked = TRUE
+	WHERE user_id = $1 AND revoked = FALSE AND conference_slot_id IN (
+		SELECT id FROM conference_slot WHERE conference_id = $2
+	)`
+	sqlArgs := []interface{}{userID, conferenceID}
+	var res sql.Result
+	var err error
+
+	if tx != nil {
+		res, err = sqldb.ExecTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		res, err = sqldb.Exec(ctx, sqlStatement, sqlArgs...)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("revoking slot claims: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	return ra, nil
+}
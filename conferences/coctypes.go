@@ -0,0 +1,60 @@
+package conferences
+
+import "time"
+
+// IncidentStatus is the stage of the CoC team workflow an incident is in.
+type IncidentStatus string
+
+// These are the valid incident statuses
+const (
+	IncidentStatusNew           IncidentStatus = "new"
+	IncidentStatusInvestigating IncidentStatus = "investigating"
+	IncidentStatusResolved      IncidentStatus = "resolved"
+)
+
+// valid returns true if the status is one of the known ones.
+func (s IncidentStatus) valid() bool {
+	switch s {
+	case IncidentStatusNew, IncidentStatusInvestigating, IncidentStatusResolved:
+		return true
+	}
+	return false
+}
+
+// Incident is a Code of Conduct report, it is confidential and only visible to the CoC team.
+type Incident struct {
+	ID           uint32
+	ConferenceID uint32
+	// ReporterID is zero when the report was filed anonymously.
+	ReporterID uint32
+	// ConferenceSlotID and LocationID are optional and zero when not known.
+	ConferenceSlotID uint32
+	LocationID       uint32
+	// OccurredAt is optional and zero when not known.
+	OccurredAt  time.Time
+	Description string
+	// Contact is how the reporter wants to be reached, if at all.
+	Contact   string
+	Status    IncidentStatus
+	CreatedAt time.Time
+	Notes     []IncidentNote
+	AuditLog  []IncidentAuditEntry
+}
+
+// IncidentNote is a note left on an incident by a member of the CoC team.
+type IncidentNote struct {
+	ID        uint32
+	AuthorID  uint32
+	Body      string
+	CreatedAt time.Time
+}
+
+// IncidentAuditEntry records one action taken on an incident.
+type IncidentAuditEntry struct {
+	ID uint32
+	// ActorID is zero for actions taken by an anonymous reporter.
+	ActorID   uint32
+	Action    string
+	Detail    string
+	CreatedAt time.Time
+}
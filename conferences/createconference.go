@@ -0,0 +1,93 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CreateConferenceParams defines the inputs used by the CreateConference API method
+type CreateConferenceParams struct {
+	Conference *Conference
+}
+
+// CreateConferenceResponse defines the output returned by the CreateConference API method
+type CreateConferenceResponse struct {
+	Conference *Conference
+}
+
+// CreateConference creates a conference of an event at a venue, its name and slug must not be used
+// by another conference, only organizers can do so
+// encore:api auth
+func CreateConference(ctx context.Context, params *CreateConferenceParams) (*CreateConferenceResponse, error) {
+	if params.Conference == nil {
+		return nil, fmt.Errorf("Conference is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	c := *params.Conference
+	c.ID = 0
+	saved, err := saveConference(ctx, userID, &c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create conference: %w", err)
+	}
+
+	return &CreateConferenceResponse{Conference: saved}, nil
+}
+
+// UpdateConferenceParams defines the inputs used by the UpdateConference API method
+type UpdateConferenceParams struct {
+	Conference *Conference
+}
+
+// UpdateConferenceResponse defines the output returned by the UpdateConference API method
+type UpdateConferenceResponse struct {
+	Conference *Conference
+}
+
+// UpdateConference changes the name, slug, dates or venue of a conference, it can only move to
+// another venue while none of its slots use the locations of the previous one, only organizers can
+// do so
+// encore:api auth
+func UpdateConference(ctx context.Context, params *UpdateConferenceParams) (*UpdateConferenceResponse, error) {
+	if params.Conference == nil || params.Conference.ID == 0 {
+		return nil, fmt.Errorf("Conference with an ID is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := saveConference(ctx, userID, params.Conference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update conference: %w", err)
+	}
+
+	return &UpdateConferenceResponse{Conference: saved}, nil
+}
+
+// ArchiveConferenceParams defines the inputs used by the ArchiveConference API method
+type ArchiveConferenceParams struct {
+	ConferenceID uint32
+}
+
+// ArchiveConference hides a conference from the listings, its slots, claims and papers are kept,
+// only organizers can do so
+// encore:api auth
+func ArchiveConference(ctx context.Context, params *ArchiveConferenceParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := archive(ctx, userID, "conference", params.ConferenceID, time.Now()); err != nil {
+		return fmt.Errorf("failed to archive conference: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CreateConferenceSlotParams defines the inputs used by the CreateConferenceSlot API method
+type CreateConferenceSlotParams struct {
+	Slot *ConferenceSlot
+}
+
+// CreateConferenceSlotResponse defines the output returned by the CreateConferenceSlot API method
+type CreateConferenceSlotResponse struct {
+	Slot *ConferenceSlot
+}
+
+// CreateConferenceSlot creates a slot of a conference at one of the locations of its venue, only
+// organizers can do so
+// encore:api auth
+func CreateConferenceSlot(ctx context.Context, params *CreateConferenceSlotParams) (*CreateConferenceSlotResponse, error) {
+	if params.Slot == nil {
+		return nil, fmt.Errorf("Slot is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	s := *params.Slot
+	s.ID = 0
+	slot, err := saveConferenceSlot(ctx, userID, &s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create conference slot: %w", err)
+	}
+
+	return &CreateConferenceSlotResponse{Slot: slot}, nil
+}
+
+// UpdateConferenceSlotParams defines the inputs used by the UpdateConferenceSlot API method
+type UpdateConferenceSlotParams struct {
+	Slot *ConferenceSlot
+}
+
+// UpdateConferenceSlotResponse defines the output returned by the UpdateConferenceSlot API method
+type UpdateConferenceSlotResponse struct {
+	Slot *ConferenceSlot
+}
+
+// UpdateConferenceSlot changes a slot that was not retired, it stays in its conference and its
+// capacity cannot drop below the claims already made, only organizers can do so
+// encore:api auth
+func UpdateConferenceSlot(ctx context.Context, params *UpdateConferenceSlotParams) (*UpdateConferenceSlotResponse, error) {
+	if params.Slot == nil || params.Slot.ID == 0 {
+		return nil, fmt.Errorf("Slot with an ID is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := saveConferenceSlot(ctx, userID, params.Slot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update conference slot: %w", err)
+	}
+
+	return &UpdateConferenceSlotResponse{Slot: slot}, nil
+}
+
+// CloneConferenceSlotParams defines the inputs used by the CloneConferenceSlot API method
+type CloneConferenceSlotParams struct {
+	SlotID uint32
+	// Name of the copy, the original name is kept if empty.
+	Name string
+	// ConferenceID is the conference to copy the slot into, the same one if zero.
+	ConferenceID uint32
+	// LocationID is where the copy takes place, the original location if zero.
+	LocationID uint32
+	// StartDate moves the copy and its purchase window, the original dates are kept if zero.
+	StartDate time.Time
+}
+
+// CloneConferenceSlotResponse defines the output returned by the CloneConferenceSlot API method
+type CloneConferenceSlotResponse struct {
+	Slot *ConferenceSlot
+}
+
+// CloneConferenceSlot creates a new slot from an existing one, for instance to repeat a workshop
+// or carry it over to next year's conference, only organizers can do so
+// encore:api auth
+func CloneConferenceSlot(ctx context.Context, params *CloneConferenceSlotParams) (*CloneConferenceSlotResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := cloneConferenceSlot(ctx, userID, params.SlotID, params.ConferenceID, params.LocationID,
+		params.StartDate, params.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone conference slot: %w", err)
+	}
+
+	return &CloneConferenceSlotResponse{Slot: slot}, nil
+}
+
+// RetireConferenceSlotParams defines the inputs used by the RetireConferenceSlot API method
+type RetireConferenceSlotParams struct {
+	SlotID uint32
+}
+
+// RetireConferenceSlot takes a slot off sale, claims already made are still valid, only
+// organizers can do so
+// encore:api auth
+func RetireConferenceSlot(ctx context.Context, params *RetireConferenceSlotParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := retireSlot(ctx, userID, params.SlotID, time.Now()); err != nil {
+		return fmt.Errorf("failed to retire conference slot: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CreateEventParams defines the inputs used by the CreateEvent API method
+type CreateEventParams struct {
+	Event *Event
+}
+
+// CreateEventResponse defines the output returned by the CreateEvent API method
+type CreateEventResponse struct {
+	Event *Event
+}
+
+// CreateEvent creates an event such as GopherCon, its slug must not be used by another event, only
+// organizers can do so
+// encore:api auth
+func CreateEvent(ctx context.Context, params *CreateEventParams) (*CreateEventResponse, error) {
+	if params.Event == nil {
+		return nil, fmt.Errorf("Event is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	e := *params.Event
+	e.ID = 0
+	saved, err := saveEvent(ctx, userID, &e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	return &CreateEventResponse{Event: saved}, nil
+}
+
+// UpdateEventParams defines the inputs used by the UpdateEvent API method
+type UpdateEventParams struct {
+	Event *Event
+}
+
+// UpdateEventResponse defines the output returned by the UpdateEvent API method
+type UpdateEventResponse struct {
+	Event *Event
+}
+
+// UpdateEvent renames an event or changes its slug, only organizers can do so
+// encore:api auth
+func UpdateEvent(ctx context.Context, params *UpdateEventParams) (*UpdateEventResponse, error) {
+	if params.Event == nil || params.Event.ID == 0 {
+		return nil, fmt.Errorf("Event with an ID is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := saveEvent(ctx, userID, params.Event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update event: %w", err)
+	}
+
+	return &UpdateEventResponse{Event: saved}, nil
+}
+
+// ArchiveEventParams defines the inputs used by the ArchiveEvent API method
+type ArchiveEventParams struct {
+	EventID uint32
+}
+
+// ArchiveEvent hides an event from the listings, its conferences are kept, only organizers can do
+// so
+// encore:api auth
+func ArchiveEvent(ctx context.Context, params *ArchiveEventParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := archive(ctx, userID, "event", params.EventID, time.Now()); err != nil {
+		return fmt.Errorf("failed to archive event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CreateLocationParams defines the inputs used by the CreateLocation API method
+type CreateLocationParams struct {
+	Location *Location
+}
+
+// CreateLocationResponse defines the output returned by the CreateLocation API method
+type CreateLocationResponse struct {
+	Location *Location
+}
+
+// CreateLocation creates a room or space of a venue, it cannot hold more people than the venue,
+// only organizers can do so
+// encore:api auth
+func CreateLocation(ctx context.Context, params *CreateLocationParams) (*CreateLocationResponse, error) {
+	if params.Location == nil {
+		return nil, fmt.Errorf("Location is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	l := *params.Location
+	l.ID = 0
+	saved, err := saveLocation(ctx, userID, &l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create location: %w", err)
+	}
+
+	return &CreateLocationResponse{Location: saved}, nil
+}
+
+// UpdateLocationParams defines the inputs used by the UpdateLocation API method
+type UpdateLocationParams struct {
+	Location *Location
+}
+
+// UpdateLocationResponse defines the output returned by the UpdateLocation API method
+type UpdateLocationResponse struct {
+	Location *Location
+}
+
+// UpdateLocation changes the details of a location, it can only move to another venue if no
+// conference hosted elsewhere uses it, only organizers can do so
+// encore:api auth
+func UpdateLocation(ctx context.Context, params *UpdateLocationParams) (*UpdateLocationResponse, error) {
+	if params.Location == nil || params.Location.ID == 0 {
+		return nil, fmt.Errorf("Location with an ID is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := saveLocation(ctx, userID, params.Location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update location: %w", err)
+	}
+
+	return &UpdateLocationResponse{Location: saved}, nil
+}
+
+// ArchiveLocationParams defines the inputs used by the ArchiveLocation API method
+type ArchiveLocationParams struct {
+	LocationID uint32
+}
+
+// ArchiveLocation keeps a location from being used by new slots, only organizers can do so
+// encore:api auth
+func ArchiveLocation(ctx context.Context, params *ArchiveLocationParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := archive(ctx, userID, "location", params.LocationID, time.Now()); err != nil {
+		return fmt.Errorf("failed to archive location: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// CreateSponsorParams defines the inputs used by the CreateSponsor API method
+type CreateSponsorParams struct {
+	Sponsor *Sponsor
+}
+
+// CreateSponsorResponse defines the output returned by the CreateSponsor API method
+type CreateSponsorResponse struct {
+	Sponsor *Sponsor
+}
+
+// CreateSponsor adds a sponsor to a conference, contacts are added with AddSponsorContact. Only
+// organizers can do so
+// encore:api auth
+func CreateSponsor(ctx context.Context, params *CreateSponsorParams) (*CreateSponsorResponse, error) {
+	if params.Sponsor == nil {
+		return nil, fmt.Errorf("Sponsor is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	s := *params.Sponsor
+	s.ID = 0
+	saved, err := saveSponsor(ctx, userID, &s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sponsor: %w", err)
+	}
+
+	return &CreateSponsorResponse{Sponsor: saved}, nil
+}
+
+// UpdateSponsorParams defines the inputs used by the UpdateSponsor API method
+type UpdateSponsorParams struct {
+	Sponsor *Sponsor
+}
+
+// UpdateSponsorResponse defines the output returned by the UpdateSponsor API method
+type UpdateSponsorResponse struct {
+	Sponsor *Sponsor
+}
+
+// UpdateSponsor changes the name, address, website and level of a sponsor, only organizers can do so
+// encore:api auth
+func UpdateSponsor(ctx context.Context, params *UpdateSponsorParams) (*UpdateSponsorResponse, error) {
+	if params.Sponsor == nil || params.Sponsor.ID == 0 {
+		return nil, fmt.Errorf("Sponsor with an ID is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := saveSponsor(ctx, userID, params.Sponsor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update sponsor: %w", err)
+	}
+
+	return &UpdateSponsorResponse{Sponsor: saved}, nil
+}
+
+// RemoveSponsorParams defines the inputs used by the RemoveSponsor API method
+type RemoveSponsorParams struct {
+	SponsorID uint32
+}
+
+// RemoveSponsor deletes a sponsor along with its contacts, only organizers can do so
+// encore:api auth
+func RemoveSponsor(ctx context.Context, params *RemoveSponsorParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := removeSponsor(ctx, userID, params.SponsorID); err != nil {
+		return fmt.Errorf("failed to remove sponsor: %w", err)
+	}
+
+	return nil
+}
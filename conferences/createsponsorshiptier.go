@@ -0,0 +1,89 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// CreateSponsorshipTierParams defines the inputs used by the CreateSponsorshipTier API method
+type CreateSponsorshipTierParams struct {
+	Tier *SponsorshipTier
+}
+
+// CreateSponsorshipTierResponse defines the output returned by the CreateSponsorshipTier API method
+type CreateSponsorshipTierResponse struct {
+	Tier *SponsorshipTier
+}
+
+// CreateSponsorshipTier adds a tier sponsors of a conference can be at, only organizers can do so
+// encore:api auth
+func CreateSponsorshipTier(ctx context.Context, params *CreateSponsorshipTierParams) (*CreateSponsorshipTierResponse, error) {
+	if params.Tier == nil {
+		return nil, fmt.Errorf("Tier is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	t := *params.Tier
+	t.ID = 0
+	saved, err := saveTier(ctx, userID, &t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sponsorship tier: %w", err)
+	}
+
+	return &CreateSponsorshipTierResponse{Tier: saved}, nil
+}
+
+// UpdateSponsorshipTierParams defines the inputs used by the UpdateSponsorshipTier API method
+type UpdateSponsorshipTierParams struct {
+	Tier *SponsorshipTier
+}
+
+// UpdateSponsorshipTierResponse defines the output returned by the UpdateSponsorshipTier API method
+type UpdateSponsorshipTierResponse struct {
+	Tier *SponsorshipTier
+}
+
+// UpdateSponsorshipTier changes the name, rank, price, benefits and limit of a tier, only organizers
+// can do so
+// encore:api auth
+func UpdateSponsorshipTier(ctx context.Context, params *UpdateSponsorshipTierParams) (*UpdateSponsorshipTierResponse, error) {
+	if params.Tier == nil || params.Tier.ID == 0 {
+		return nil, fmt.Errorf("Tier with an ID is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := saveTier(ctx, userID, params.Tier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update sponsorship tier: %w", err)
+	}
+
+	return &UpdateSponsorshipTierResponse{Tier: saved}, nil
+}
+
+// RemoveSponsorshipTierParams defines the inputs used by the RemoveSponsorshipTier API method
+type RemoveSponsorshipTierParams struct {
+	TierID uint32
+}
+
+// RemoveSponsorshipTier deletes a tier no sponsor is at, only organizers can do so
+// encore:api auth
+func RemoveSponsorshipTier(ctx context.Context, params *RemoveSponsorshipTierParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := removeTier(ctx, userID, params.TierID); err != nil {
+		return fmt.Errorf("failed to remove sponsorship tier: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CreateVenueParams defines the inputs used by the CreateVenue API method
+type CreateVenueParams struct {
+	Venue *Venue
+}
+
+// CreateVenueResponse defines the output returned by the CreateVenue API method
+type CreateVenueResponse struct {
+	Venue *Venue
+}
+
+// CreateVenue creates a venue that can host conferences, only organizers can do so
+// encore:api auth
+func CreateVenue(ctx context.Context, params *CreateVenueParams) (*CreateVenueResponse, error) {
+	if params.Venue == nil {
+		return nil, fmt.Errorf("Venue is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	v := *params.Venue
+	v.ID = 0
+	saved, err := saveVenue(ctx, userID, &v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create venue: %w", err)
+	}
+
+	return &CreateVenueResponse{Venue: saved}, nil
+}
+
+// UpdateVenueParams defines the inputs used by the UpdateVenue API method
+type UpdateVenueParams struct {
+	Venue *Venue
+}
+
+// UpdateVenueResponse defines the output returned by the UpdateVenue API method
+type UpdateVenueResponse struct {
+	Venue *Venue
+}
+
+// UpdateVenue changes the details of a venue, only organizers can do so
+// encore:api auth
+func UpdateVenue(ctx context.Context, params *UpdateVenueParams) (*UpdateVenueResponse, error) {
+	if params.Venue == nil || params.Venue.ID == 0 {
+		return nil, fmt.Errorf("Venue with an ID is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := saveVenue(ctx, userID, params.Venue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update venue: %w", err)
+	}
+
+	return &UpdateVenueResponse{Venue: saved}, nil
+}
+
+// ArchiveVenueParams defines the inputs used by the ArchiveVenue API method
+type ArchiveVenueParams struct {
+	VenueID uint32
+}
+
+// ArchiveVenue keeps a venue from hosting new conferences once it has no upcoming ones, only
+// organizers can do so
+// encore:api auth
+func ArchiveVenue(ctx context.Context, params *ArchiveVenueParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := archiveVenue(ctx, userID, params.VenueID, time.Now()); err != nil {
+		return fmt.Errorf("failed to archive venue: %w", err)
+	}
+
+	return nil
+}
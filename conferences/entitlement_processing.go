@@ -0,0 +1,219 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"encore.dev/storage/sqldb"
+)
+
+// complimentarySponsorTicketDetail describes the discount covering the tickets of sponsors.
+const complimentarySponsorTicketDetail = "100% sponsor"
+
+// addTierEntitlement adds an entitlement to the package of a tier, only organizers can do so.
+func addTierEntitlement(ctx context.Context, userID uint32, entitlement *Entitlement) (*Entitlement, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	e := *entitlement
+	tier, err := readTierByID(ctx, e.TierID)
+	if err != nil {
+		return nil, err
+	}
+	if tier == nil {
+		return nil, fmt.Errorf("no such tier")
+	}
+	var slot *ConferenceSlot
+	if e.SlotID != 0 {
+		slot, err = readConferenceSlotByID(ctx, nil, uint64(e.SlotID), false)
+		if err != nil {
+			return nil, err
+		}
+		if slot == nil {
+			return nil, fmt.Errorf("no such slot")
+		}
+	}
+	if err := e.normalize(tier, slot); err != nil {
+		return nil, err
+	}
+	return insertEntitlement(ctx, &e)
+}
+
+// removeTierEntitlement removes an entitlement from the package of its tier once no sponsor has
+// used it, only organizers can do so.
+func removeTierEntitlement(ctx context.Context, userID, entitlementID uint32) error {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+	uses, err := countEntitlementUses(ctx, entitlementID)
+	if err != nil {
+		return err
+	}
+	if uses > 0 {
+		return fmt.Errorf("sponsors already used the entitlement %d times", uses)
+	}
+	return deleteEntitlement(ctx, entitlementID)
+}
+
+// readSponsorEntitlements returns how much of each entitlement of its tier the sponsor has used.
+func readSponsorEntitlements(ctx context.Context, sponsor *Sponsor) ([]EntitlementUsage, error) {
+	entitlements, err := readEntitlementsByTier(ctx, sponsor.Tier.ID)
+	if err != nil {
+		return nil, err
+	}
+	uses, err := readSponsorEntitlementUses(ctx, sponsor.ID)
+	if err != nil {
+		return nil, err
+	}
+	usage := make([]EntitlementUsage, 0, len(entitlements))
+	for i := range entitlements {
+		usage = append(usage, entitlements[i].usage(uses[entitlements[i].ID]))
+	}
+	return usage, nil
+}
+
+// sponsorEntitlements returns the entitlement usage of a sponsor, only organizers can see it.
+func sponsorEntitlements(ctx context.Context, userID, sponsorID uint32) ([]EntitlementUsage, error) {
+	sponsor, err := sponsorWithContacts(ctx, userID, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	return readSponsorEntitlements(ctx, sponsor)
+}
+
+// availableEntitlement returns the entitlement of the sponsor's tier if it has at least n uses
+// left. The entitlement stays locked until tx ends, so the uses the caller records in tx cannot
+// exceed its quantity.
+func availableEntitlement(ctx context.Context, tx *sqldb.Tx, sponsor *Sponsor, entitlementID uint32, n int) (*Entitlement, error) {
+	e, err := lockTierEntitlement(ctx, tx, sponsor.Tier.ID, entitlementID)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, fmt.Errorf("no such entitlement for tier %s", sponsor.Tier.Name)
+	}
+	used, err := countSponsorEntitlementUses(ctx, tx, sponsor.ID, entitlementID)
+	if err != nil {
+		return nil, err
+	}
+	if u := e.usage(used); u.Remaining < n {
+		return nil, fmt.Errorf("only %d of %d %s entitlements left", u.Remaining, e.Quantity, e.Kind)
+	}
+	return e, nil
+}
+
+// issueTickets claims the slot of a ticket entitlement for each email, creating the attendees
+// that do not exist yet, fully paid with a conference discount. Either every ticket is issued or
+// none is.
+func issueTickets(ctx context.Context, sponsor *Sponsor, entitlementID uint32, emails []string) ([]SlotClaim, error) {
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("at least one email is required")
+	}
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	issued, err := issueTicketsTx(ctx, tx, sponsor, entitlementID, emails)
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return nil, err
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return issued, nil
+}
+
+// issueTicketsTx is issueTickets within a transaction the caller commits.
+func issueTicketsTx(ctx context.Context, tx *sqldb.Tx, sponsor *Sponsor, entitlementID uint32, emails []string) ([]SlotClaim, error) {
+	e, err := availableEntitlement(ctx, tx, sponsor, entitlementID, len(emails))
+	if err != nil {
+		return nil, err
+	}
+	if e.Kind != EntitlementTicket {
+		return nil, fmt.Errorf("entitlement %d is not for tickets", e.ID)
+	}
+	slot, err := readConferenceSlotByID(ctx, tx, uint64(e.SlotID), false)
+	if err != nil {
+		return nil, err
+	}
+	if slot == nil || slot.Retired {
+		return nil, fmt.Errorf("the slot of the entitlement is no longer available")
+	}
+
+	issued := make([]SlotClaim, 0, len(emails))
+	for _, email := range emails {
+		email = strings.TrimSpace(email)
+		if !strings.Contains(email, "@") {
+			return nil, fmt.Errorf("invalid email %q", email)
+		}
+		attendee, err := readAttendeeByEmail(ctx, tx, email)
+		if err != nil {
+			return nil, err
+		}
+		if attendee == nil {
+			attendee, err = createAttendee(ctx, tx, &User{Email: email})
+			if err != nil {
+				return nil, err
+			}
+		}
+		claims, err := claimSlotsTx(ctx, tx, attendee, []ConferenceSlot{*slot})
+		if err != nil {
+			return nil, fmt.Errorf("claiming sponsor ticket: %w", err)
+		}
+		_, err = payClaimsTx(ctx, tx, attendee, claims, []FinancialInstrument{
+			&PaymentMethodConferenceDiscount{Detail: complimentarySponsorTicketDetail, AmountCents: int64(slot.Cost)},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("paying sponsor ticket: %w", err)
+		}
+		// the claim tells who the ticket went to, the use does not keep their email.
+		if err := insertEntitlementUse(ctx, tx, sponsor.ID, e.ID, claims[0].ID, ""); err != nil {
+			return nil, err
+		}
+		issued = append(issued, claims[0])
+	}
+	return issued, nil
+}
+
+// issueSponsorTickets issues tickets of a sponsor to the emails, only organizers can do so.
+func issueSponsorTickets(ctx context.Context, userID, sponsorID, entitlementID uint32, emails []string) ([]SlotClaim, error) {
+	sponsor, err := sponsorWithContacts(ctx, userID, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	return issueTickets(ctx, sponsor, entitlementID, emails)
+}
+
+// useEntitlement records that a sponsor got one of an entitlement other than tickets, such as
+// its booth, only organizers can do so.
+func useEntitlement(ctx context.Context, userID, sponsorID, entitlementID uint32, note string) error {
+	sponsor, err := sponsorWithContacts(ctx, userID, sponsorID)
+	if err != nil {
+		return err
+	}
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	e, err := availableEntitlement(ctx, tx, sponsor, entitlementID, 1)
+	if err == nil && e.Kind == EntitlementTicket {
+		err = fmt.Errorf("tickets are used by issuing them")
+	}
+	if err == nil {
+		err = insertEntitlementUse(ctx, tx, sponsor.ID, e.ID, 0, strings.TrimSpace(note))
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return err
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
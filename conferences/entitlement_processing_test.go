@@ -0,0 +1,88 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+
+	"encore.dev/storage/sqldb"
+)
+
+func TestSponsorEntitlements(t *testing.T) {
+	ctx := context.Background()
+
+	organizer := createOrganizer(t, ctx, "entitlement-admin@gophercon.com")
+
+	row := sqldb.QueryRow(ctx, `INSERT INTO conference_slot (name, description, cost, capacity, start_date, end_date,
+	purchaseable_from, purchaseable_until, available_to_public, conference_id, location_id)
+	VALUES ('Sponsor pass', 'For sponsor staff', 40000, 100, NOW() + INTERVAL '90 days', NOW() + INTERVAL '92 days',
+	NOW(), NOW() + INTERVAL '89 days', FALSE, 2, 2) RETURNING id`)
+	var slotID uint32
+	assertDatabaseError(t, row.Scan(&slotID))
+
+	tier, err := saveTier(ctx, organizer.ID, &SponsorshipTier{ConferenceID: 2, Name: "Entitlement Test", Rank: 40})
+	assertDatabaseError(t, err)
+	tickets, err := addTierEntitlement(ctx, organizer.ID, &Entitlement{TierID: tier.ID, Kind: EntitlementTicket, Quantity: 2, SlotID: slotID})
+	assertDatabaseError(t, err)
+	booth, err := addTierEntitlement(ctx, organizer.ID, &Entitlement{TierID: tier.ID, Kind: EntitlementBooth, Quantity: 1})
+	assertDatabaseError(t, err)
+	sponsor, err := saveSponsor(ctx, organizer.ID, &Sponsor{Name: "Entitled Gophers", ConferenceID: 2, Tier: SponsorshipTier{ID: tier.ID}})
+	assertDatabaseError(t, err)
+
+	t.Run("a batch with an invalid email issues no ticket", func(t *testing.T) {
+		if _, err := issueSponsorTickets(ctx, organizer.ID, sponsor.ID, tickets.ID,
+			[]string{"rolled-back-staff@gophercon.com", "not an email"}); err == nil {
+			t.Fatalf("invalid email did not cause an error")
+		}
+		used, err := countSponsorEntitlementUses(ctx, nil, sponsor.ID, tickets.ID)
+		assertDatabaseError(t, err)
+		attendee, err := readAttendeeByEmail(ctx, nil, "rolled-back-staff@gophercon.com")
+		assertDatabaseError(t, err)
+		if used != 0 || attendee != nil {
+			t.Errorf("failed batch left %d uses and attendee %+v", used, attendee)
+		}
+	})
+
+	t.Run("tickets are complimentary claims of the sponsor slot", func(t *testing.T) {
+		claims, err := issueSponsorTickets(ctx, organizer.ID, sponsor.ID, tickets.ID,
+			[]string{"entitlement-admin@gophercon.com", "new-booth-staff@gophercon.com"})
+		assertDatabaseError(t, err)
+		if len(claims) != 2 || claims[0].ConferenceSlot.ID != slotID {
+			t.Fatalf("incorrect claims got %+v", claims)
+		}
+
+		var detail string
+		var amount int64
+		assertDatabaseError(t, sqldb.QueryRow(ctx, `SELECT d.detail, d.amount_cents FROM payment_method_conference_discount d
+		JOIN claim_payment_slot_claim c ON c.claim_payment_id = d.claim_payment_id
+		WHERE c.slot_claim_id = $1`, claims[1].ID).Scan(&detail, &amount))
+		if detail != complimentarySponsorTicketDetail || amount != 40000 {
+			t.Errorf("incorrect discount got %q for %d", detail, amount)
+		}
+	})
+
+	t.Run("entitlements cannot be overused", func(t *testing.T) {
+		if _, err := issueSponsorTickets(ctx, organizer.ID, sponsor.ID, tickets.ID, []string{"one-too-many@gophercon.com"}); err == nil {
+			t.Errorf("issuing more tickets than entitled did not cause an error")
+		}
+		assertDatabaseError(t, useEntitlement(ctx, organizer.ID, sponsor.ID, booth.ID, "Booth 12"))
+		if err := useEntitlement(ctx, organizer.ID, sponsor.ID, booth.ID, "Booth 13"); err == nil {
+			t.Errorf("a second booth did not cause an error")
+		}
+	})
+
+	t.Run("usage is tracked per sponsor", func(t *testing.T) {
+		usage, err := sponsorEntitlements(ctx, organizer.ID, sponsor.ID)
+		assertDatabaseError(t, err)
+		if len(usage) != 2 {
+			t.Fatalf("expected two entitlements, got %+v", usage)
+		}
+		for _, u := range usage {
+			if u.Remaining != 0 {
+				t.Errorf("expected entitlement to be used up, got %+v", u)
+			}
+		}
+		if err := removeTierEntitlement(ctx, organizer.ID, booth.ID); err == nil {
+			t.Errorf("removing a used entitlement did not cause an error")
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"encore.dev/storage/sqldb"
+)
+
+const entitlementColumns = `id, tier_id, kind, quantity, COALESCE(conference_slot_id, 0), description`
+
+// scanEntitlement scans a row selected with entitlementColumns.
+func scanEntitlement(scan func(dest ...interface{}) error) (*Entitlement, error) {
+	var e Entitlement
+	if err := scan(&e.ID, &e.TierID, &e.Kind, &e.Quantity, &e.SlotID, &e.Description); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// readEntitlementsByTier returns the package of a tier.
+func readEntitlementsByTier(ctx context.Context, tierID uint32) ([]Entitlement, error) {
+	rows, err := sqldb.Query(ctx, `SELECT `+entitlementColumns+` FROM sponsorship_entitlement
+	WHERE tier_id = $1 ORDER BY kind, id`, tierID)
+	if err != nil {
+		return nil, fmt.Errorf("querying entitlements: %w", err)
+	}
+	defer rows.Close()
+
+	entitlements := []Entitlement{}
+	for rows.Next() {
+		e, err := scanEntitlement(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning entitlement: %w", err)
+		}
+		entitlements = append(entitlements, *e)
+	}
+	return entitlements, nil
+}
+
+// readEntitlementByID returns an entitlement, nil if it does not exist.
+func readEntitlementByID(ctx context.Context, id uint32) (*Entitlement, error) {
+	row := sqldb.QueryRow(ctx, `SELECT `+entitlementColumns+` FROM sponsorship_entitlement WHERE id = $1`, id)
+
+	e, err := scanEntitlement(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading entitlement: %w", err)
+	}
+	return e, nil
+}
+
+// insertEntitlement adds an entitlement to the package of its tier.
+func insertEntitlement(ctx context.Context, e *Entitlement) (*Entitlement, error) {
+	row := sqldb.QueryRow(ctx, `INSERT INTO sponsorship_entitlement (tier_id, kind, quantity, conference_slot_id, description)
+	VALUES ($1, $2, $3, NULLIF($4, 0), $5) RETURNING `+entitlementColumns,
+		e.TierID, e.Kind, e.Quantity, e.SlotID, e.Description)
+
+	saved, err := scanEntitlement(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("saving entitlement: %w", err)
+	}
+	return saved, nil
+}
+
+// deleteEntitlement removes an entitlement no sponsor has used.
+func deleteEntitlement(ctx context.Context, id uint32) error {
+	res, err := sqldb.Exec(ctx, `DELETE FROM sponsorship_entitlement WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("deleting entitlement: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting entitlement: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("no such entitlement")
+	}
+	return nil
+}
+
+// countEntitlementUses returns how many times sponsors used the entitlement.
+func countEntitlementUses(ctx context.Context, entitlementID uint32) (int, error) {
+	row := sqldb.QueryRow(ctx, `SELECT COUNT(*) FROM sponsor_entitlement_use WHERE entitlement_id = $1`, entitlementID)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting entitlement uses: %w", err)
+	}
+	return count, nil
+}
+
+// readSponsorEntitlementUses returns how many times the sponsor used each of its entitlements.
+func readSponsorEntitlementUses(ctx context.Context, sponsorID uint32) (map[uint32]int, error) {
+	rows, err := sqldb.Query(ctx, `SELECT entitlement_id, COUNT(*) FROM sponsor_entitlement_use
+	WHERE sponsor_id = $1 GROUP BY entitlement_id`, sponsorID)
+	if err != nil {
+		return nil, fmt.Errorf("querying entitlement uses: %w", err)
+	}
+	defer rows.Close()
+
+	uses := map[uint32]int{}
+	for rows.Next() {
+		var entitlementID uint32
+		var count int
+		if err := rows.Scan(&entitlementID, &count); err != nil {
+			return nil, fmt.Errorf("scanning entitlement uses: %w", err)
+		}
+		uses[entitlementID] = count
+	}
+	return uses, nil
+}
+
+// lockTierEntitlement returns an entitlement of the tier locked until tx ends, so its uses are
+// counted and recorded by one request at a time. It returns nil if the tier has no such entitlement.
+func lockTierEntitlement(ctx context.Context, tx *sqldb.Tx, tierID, entitlementID uint32) (*Entitlement, error) {
+	row := sqldb.QueryRowTx(tx, ctx, `SELECT `+entitlementColumns+` FROM sponsorship_entitlement
+	WHERE id = $1 AND tier_id = $2 FOR UPDATE`, entitlementID, tierID)
+
+	e, err := scanEntitlement(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("locking entitlement: %w", err)
+	}
+	return e, nil
+}
+
+// countSponsorEntitlementUses returns how many times the sponsor used the entitlement.
+func countSponsorEntitlementUses(ctx context.Context, tx *sqldb.Tx, sponsorID, entitlementID uint32) (int, error) {
+	sqlStatement := `SELECT COUNT(*) FROM sponsor_entitlement_use WHERE sponsor_id = $1 AND entitlement_id = $2`
+	var row *sqldb.Row
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sponsorID, entitlementID)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sponsorID, entitlementID)
+	}
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting entitlement uses: %w", err)
+	}
+	return count, nil
+}
+
+// insertEntitlementUse records a use of an entitlement by a sponsor, slotClaimID is zero unless a
+// ticket was issued.
+func insertEntitlementUse(ctx context.Context, tx *sqldb.Tx, sponsorID, entitlementID uint32, slotClaimID int64, note string) error {
+	sqlStatement := `INSERT INTO sponsor_entitlement_use (sponsor_id, entitlement_id, slot_claim_id, note)
+	VALUES ($1, $2, NULLIF($3, 0), $4)`
+	var err error
+	if tx != nil {
+		_, err = sqldb.ExecTx(tx, ctx, sqlStatement, sponsorID, entitlementID, slotClaimID, note)
+	} else {
+		_, err = sqldb.Exec(ctx, sqlStatement, sponsorID, entitlementID, slotClaimID, note)
+	}
+	if err != nil {
+		return fmt.Errorf("recording entitlement use: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package conferences
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EntitlementKind is a kind of benefit a sponsorship comes with
+type EntitlementKind string
+
+// Kinds of benefits sponsors get
+const (
+	// EntitlementTicket is a complimentary claim of a slot not available to the public.
+	EntitlementTicket  EntitlementKind = "ticket"
+	EntitlementBooth   EntitlementKind = "booth"
+	EntitlementJobPost EntitlementKind = "job_post"
+	EntitlementLogo    EntitlementKind = "logo"
+	EntitlementOther   EntitlementKind = "other"
+)
+
+// Entitlement is a benefit in the package of a sponsorship tier, such as 10 complimentary tickets
+type Entitlement struct {
+	ID       uint32
+	TierID   uint32
+	Kind     EntitlementKind
+	Quantity int
+	// SlotID is the slot claimed for ticket entitlements, it must not be available to the public.
+	SlotID      uint32
+	Description string
+}
+
+// EntitlementUsage is how much of an entitlement a sponsor has used
+type EntitlementUsage struct {
+	Entitlement Entitlement
+	Used        int
+	Remaining   int
+}
+
+// normalize trims the entitlement details and checks they can be saved, slot is the slot of
+// ticket entitlements and nil for the other kinds.
+func (e *Entitlement) normalize(tier *SponsorshipTier, slot *ConferenceSlot) error {
+	e.Description = strings.TrimSpace(e.Description)
+	switch e.Kind {
+	case EntitlementTicket, EntitlementBooth, EntitlementJobPost, EntitlementLogo, EntitlementOther:
+	default:
+		return fmt.Errorf("unknown entitlement kind %q", e.Kind)
+	}
+	if e.Quantity < 1 {
+		return fmt.Errorf("quantity must be 1 or more")
+	}
+	if e.Kind != EntitlementTicket {
+		if e.SlotID != 0 {
+			return fmt.Errorf("only ticket entitlements claim a slot")
+		}
+		return nil
+	}
+	switch {
+	case slot == nil:
+		return fmt.Errorf("ticket entitlements need a slot")
+	case slot.ConferenceID != tier.ConferenceID:
+		return fmt.Errorf("slot %d is not part of the conference of the tier", slot.ID)
+	case slot.AvailableToPublic:
+		return fmt.Errorf("slot %d is available to the public, sponsor tickets need their own slot", slot.ID)
+	case slot.Retired:
+		return fmt.Errorf("slot %d is retired", slot.ID)
+	}
+	return nil
+}
+
+// usage returns how much of the entitlement is left after used uses.
+func (e *Entitlement) usage(used int) EntitlementUsage {
+	remaining := e.Quantity - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return EntitlementUsage{Entitlement: *e, Used: used, Remaining: remaining}
+}
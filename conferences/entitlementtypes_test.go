@@ -0,0 +1,48 @@
+package conferences
+
+import (
+	"testing"
+)
+
+func TestEntitlementNormalize(t *testing.T) {
+	tier := &SponsorshipTier{ID: 1, ConferenceID: 2}
+	sponsorSlot := &ConferenceSlot{ID: 7, ConferenceID: 2}
+
+	tests := []struct {
+		name        string
+		entitlement Entitlement
+		slot        *ConferenceSlot
+		wantErr     bool
+	}{
+		{name: "tickets", entitlement: Entitlement{Kind: EntitlementTicket, Quantity: 10, SlotID: 7}, slot: sponsorSlot},
+		{name: "booth", entitlement: Entitlement{Kind: EntitlementBooth, Quantity: 1, Description: " 3x3m "}},
+		{name: "unknown kind", entitlement: Entitlement{Kind: "yacht", Quantity: 1}, wantErr: true},
+		{name: "no quantity", entitlement: Entitlement{Kind: EntitlementLogo}, wantErr: true},
+		{name: "slot for a booth", entitlement: Entitlement{Kind: EntitlementBooth, Quantity: 1, SlotID: 7}, wantErr: true},
+		{name: "tickets without a slot", entitlement: Entitlement{Kind: EntitlementTicket, Quantity: 10}, wantErr: true},
+		{name: "slot of another conference", entitlement: Entitlement{Kind: EntitlementTicket, Quantity: 10, SlotID: 8},
+			slot: &ConferenceSlot{ID: 8, ConferenceID: 1}, wantErr: true},
+		{name: "public slot", entitlement: Entitlement{Kind: EntitlementTicket, Quantity: 10, SlotID: 9},
+			slot: &ConferenceSlot{ID: 9, ConferenceID: 2, AvailableToPublic: true}, wantErr: true},
+		{name: "retired slot", entitlement: Entitlement{Kind: EntitlementTicket, Quantity: 10, SlotID: 10},
+			slot: &ConferenceSlot{ID: 10, ConferenceID: 2, Retired: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.entitlement
+			if err := e.normalize(tier, tt.slot); (err != nil) != tt.wantErr {
+				t.Errorf("normalize() got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEntitlementUsage(t *testing.T) {
+	e := Entitlement{ID: 3, Kind: EntitlementTicket, Quantity: 5}
+	if u := e.usage(2); u.Used != 2 || u.Remaining != 3 || u.Entitlement.ID != 3 {
+		t.Errorf("usage() got %+v", u)
+	}
+	if u := e.usage(6); u.Remaining != 0 {
+		t.Errorf("usage() over the quantity got %d remaining, want 0", u.Remaining)
+	}
+}
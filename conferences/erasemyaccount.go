@@ -0,0 +1,66 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+// EraseMyAccountParams defines the inputs used by the EraseMyAccount API method
+type EraseMyAccountParams struct {
+	// ConfirmEmail must match the account email, erasure cannot be undone.
+	ConfirmEmail string
+}
+
+// EraseMyAccount anonymizes the authenticated user keeping only what accounting requires
+// encore:api auth
+func EraseMyAccount(ctx context.Context, params *EraseMyAccountParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := eraseAccount(ctx, userID, params.ConfirmEmail); err != nil {
+		return fmt.Errorf("failed to erase account: %w", err)
+	}
+
+	return nil
+}
+
+// eraseAccount anonymizes a user once the passed email confirms it is the right account.
+func eraseAccount(ctx context.Context, userID uint32, confirmEmail string) error {
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+
+	user, err := readAttendeeByID(ctx, tx, userID)
+	if err == nil && user == nil {
+		err = fmt.Errorf("no such user")
+	}
+	if err == nil && !strings.EqualFold(user.Email, strings.TrimSpace(confirmEmail)) {
+		err = fmt.Errorf("confirmation email does not match the account")
+	}
+	if err == nil {
+		err = erasePersonalData(ctx, tx, userID, time.Now())
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return err
+	}
+
+	if err := sqldb.Commit(tx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	// once the profile is gone nothing points to the headshot anymore, it only goes once the
+	// erasure can no longer be rolled back.
+	if err := uploads.remove(headshotKey(userID)); err != nil {
+		return fmt.Errorf("removing headshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package conferences
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// saveEvent creates an event, or edits it if it has an ID, making sure its slug is not taken.
+func saveEvent(ctx context.Context, userID uint32, event *Event) (*Event, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	e := *event
+	if err := e.normalize(); err != nil {
+		return nil, err
+	}
+	taken, err := eventSlugTaken(ctx, e.Slug, e.ID)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, fmt.Errorf("slug %q is already used by another event", e.Slug)
+	}
+	if e.ID == 0 {
+		return insertEvent(ctx, &e)
+	}
+	return updateEvent(ctx, &e)
+}
+
+// requireActiveVenue returns the venue unless it does not exist or was archived.
+func requireActiveVenue(ctx context.Context, venueID uint32) (*Venue, error) {
+	venue, err := readVenueByID(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	if venue == nil || venue.Archived {
+		return nil, fmt.Errorf("no such venue")
+	}
+	return venue, nil
+}
+
+// saveConference creates a conference of an event, or edits it if it has an ID. Moving a
+// conference to another venue is only possible while none of its slots use locations of the
+// previous one.
+func saveConference(ctx context.Context, userID uint32, conference *Conference) (*Conference, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	c := *conference
+	if err := c.normalize(); err != nil {
+		return nil, err
+	}
+	if _, err := requireActiveVenue(ctx, c.Venue.ID); err != nil {
+		return nil, err
+	}
+	taken, err := conferenceTaken(ctx, c.Name, c.Slug, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, fmt.Errorf("name or slug %q is already used by another conference", c.Slug)
+	}
+
+	if c.ID == 0 {
+		event, err := readEventByID(ctx, c.EventID)
+		if err != nil {
+			return nil, err
+		}
+		if event == nil || event.Archived {
+			return nil, fmt.Errorf("no such event")
+		}
+		c.ID, err = insertConference(ctx, &c)
+		if err != nil {
+			return nil, err
+		}
+		return readConferenceByID(ctx, c.ID)
+	}
+
+	misplaced, err := countSlotsAtOtherLocations(ctx, c.ID, c.Venue.ID)
+	if err != nil {
+		return nil, err
+	}
+	if misplaced > 0 {
+		return nil, fmt.Errorf("%d slots of the conference use locations outside of venue %d", misplaced, c.Venue.ID)
+	}
+	if err := updateConference(ctx, &c); err != nil {
+		return nil, err
+	}
+	return readConferenceByID(ctx, c.ID)
+}
+
+// saveVenue creates a venue, or edits it if it has an ID.
+func saveVenue(ctx context.Context, userID uint32, venue *Venue) (*Venue, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	v := *venue
+	if err := v.normalize(); err != nil {
+		return nil, err
+	}
+	if v.ID == 0 {
+		return insertVenue(ctx, &v)
+	}
+	return updateVenue(ctx, &v)
+}
+
+// saveLocation creates a location in a venue, or edits it if it has an ID. A location can only
+// move to another venue if no slot of a conference hosted elsewhere uses it.
+func saveLocation(ctx context.Context, userID uint32, location *Location) (*Location, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	venue, err := requireActiveVenue(ctx, location.VenueID)
+	if err != nil {
+		return nil, err
+	}
+	l := *location
+	if err := l.normalize(venue); err != nil {
+		return nil, err
+	}
+	if l.ID == 0 {
+		return insertLocation(ctx, &l)
+	}
+
+	misplaced, err := countSlotsAtOtherVenues(ctx, l.ID, l.VenueID)
+	if err != nil {
+		return nil, err
+	}
+	if misplaced > 0 {
+		return nil, fmt.Errorf("%d slots of conferences at other venues use the location", misplaced)
+	}
+	return updateLocation(ctx, &l)
+}
+
+// archiveVenue archives a venue once it no longer hosts upcoming conferences.
+func archiveVenue(ctx context.Context, userID, venueID uint32, now time.Time) error {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+	upcoming, err := countUpcomingConferencesAtVenue(ctx, venueID, now)
+	if err != nil {
+		return err
+	}
+	if upcoming > 0 {
+		return fmt.Errorf("the venue still hosts %d upcoming conferences", upcoming)
+	}
+	return markArchived(ctx, "venue", venueID, now)
+}
+
+// archive archives an event, conference or location.
+func archive(ctx context.Context, userID uint32, table string, id uint32, now time.Time) error {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+	return markArchived(ctx, table, id, now)
+}
+
+// errNoCurrentConference is returned for events none of whose conferences can be current.
+var errNoCurrentConference = errors.New("the event has no current conference")
+
+// currentConference returns an event with only its current conference.
+func currentConference(ctx context.Context, eventID uint32, now time.Time) (*Event, error) {
+	event, err := readEventByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil || event.Archived {
+		return nil, fmt.Errorf("no such event")
+	}
+	conferences, overrides, err := readConferencesByEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	i := currentConferenceIndex(conferences, overrides, now)
+	if i == -1 {
+		return nil, errNoCurrentConference
+	}
+	conferences[i].Current = true
+	event.Conferences = []Conference{conferences[i]}
+	return event, nil
+}
+
+// conferenceBySlug returns an event with only the conference matching conferenceSlug, which can
+// leave out the slug of the event, so both gc-2021 and 2021 find GopherCon 2021.
+func conferenceBySlug(ctx context.Context, eventSlug, conferenceSlug string, now time.Time) (*Event, error) {
+	event, err := readEventBySlug(ctx, eventSlug)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, fmt.Errorf("no such event")
+	}
+	conferences, overrides, err := readConferencesByEvent(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	current := currentConferenceIndex(conferences, overrides, now)
+	for i, c := range conferences {
+		if c.Slug == conferenceSlug || c.Slug == event.Slug+"-"+conferenceSlug {
+			c.Current = i == current
+			event.Conferences = []Conference{c}
+			return event, nil
+		}
+	}
+	return nil, fmt.Errorf("no such conference")
+}
+
+// setCurrentOverride decides whether a conference is current regardless of its dates, only
+// organizers can do so.
+func setCurrentOverride(ctx context.Context, userID, conferenceID uint32, override CurrentOverride) error {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+	return updateCurrentOverride(ctx, conferenceID, override)
+}
+
+// listEvents returns a page of the events with their conferences by start date, the current one
+// of each event marked.
+func listEvents(ctx context.Context, page Page, now time.Time) ([]Event, PageInfo, error) {
+	events, info, err := readEvents(ctx, page)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	for i := range events {
+		conferences, overrides, err := readConferencesByEvent(ctx, events[i].ID)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		if current := currentConferenceIndex(conferences, overrides, now); current != -1 {
+			conferences[current].Current = true
+		}
+		events[i].Conferences = conferences
+	}
+	return events, info, nil
+}
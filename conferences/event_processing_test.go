@@ -0,0 +1,152 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+func TestEventAdministration(t *testing.T) {
+	ctx := context.Background()
+
+	organizer := createOrganizer(t, ctx, "event-admin@gophercon.com")
+	attendee, err := createAttendee(ctx, nil, &User{Email: "not-an-admin@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+
+	event, err := saveEvent(ctx, organizer.ID, &Event{Name: "GopherCon EU", Slug: "gceu"})
+	assertDatabaseError(t, err)
+	venue, err := saveVenue(ctx, organizer.ID, &Venue{Name: "Tivoli", Address: "Vesterbrogade 3, Copenhagen", Capacity: 800})
+	assertDatabaseError(t, err)
+	hall, err := saveLocation(ctx, organizer.ID, &Location{Name: "Concert Hall", Capacity: 600, VenueID: venue.ID})
+	assertDatabaseError(t, err)
+
+	start := time.Now().Add(120 * 24 * time.Hour)
+	conference := &Conference{
+		Name:      "GopherCon EU Admin Test",
+		Slug:      "gceu-admin-test",
+		StartDate: start,
+		EndDate:   start.Add(48 * time.Hour),
+		Venue:     Venue{ID: venue.ID},
+		EventID:   event.ID,
+	}
+
+	t.Run("only organizers administer events", func(t *testing.T) {
+		if _, err := saveEvent(ctx, attendee.ID, &Event{Name: "Rogue", Slug: "rogue"}); err == nil {
+			t.Errorf("attendees creating events did not cause an error")
+		}
+	})
+
+	t.Run("slugs are unique", func(t *testing.T) {
+		if _, err := saveEvent(ctx, organizer.ID, &Event{Name: "GopherCon EU again", Slug: "gceu"}); err == nil {
+			t.Errorf("duplicate event slug did not cause an error")
+		}
+		saved, err := saveConference(ctx, organizer.ID, conference)
+		assertDatabaseError(t, err)
+		if saved.Venue.Name != "Tivoli" || saved.EventID != event.ID {
+			t.Errorf("incorrect conference got %+v", saved)
+		}
+		_, overrides, err := readConferencesByEvent(ctx, event.ID)
+		assertDatabaseError(t, err)
+		if overrides[saved.ID] != CurrentFromDates {
+			t.Errorf("new conference should be current from its dates got %v", overrides[saved.ID])
+		}
+		conference.ID = saved.ID
+		if _, err := saveConference(ctx, organizer.ID, &Conference{
+			Name: "Another", Slug: "gceu-admin-test", StartDate: start, EndDate: start.Add(time.Hour),
+			Venue: Venue{ID: venue.ID}, EventID: event.ID,
+		}); err == nil {
+			t.Errorf("duplicate conference slug did not cause an error")
+		}
+	})
+
+	t.Run("locations stay at the venue of the conference", func(t *testing.T) {
+		_, err := sqldb.Exec(ctx, `INSERT INTO conference_slot (name, description, cost, capacity, start_date, end_date,
+		purchaseable_from, purchaseable_until, available_to_public, conference_id, location_id)
+		VALUES ('Keynotes', 'Opening', 0, 600, $1, $2, NOW(), $1, TRUE, $3, $4)`,
+			start, start.Add(time.Hour), conference.ID, hall.ID)
+		assertDatabaseError(t, err)
+
+		moved := *hall
+		moved.VenueID = 1
+		if _, err := saveLocation(ctx, organizer.ID, &moved); err == nil {
+			t.Errorf("moving a location in use to another venue did not cause an error")
+		}
+		elsewhere := *conference
+		elsewhere.Venue = Venue{ID: 1}
+		if _, err := saveConference(ctx, organizer.ID, &elsewhere); err == nil {
+			t.Errorf("moving a conference away from the locations of its slots did not cause an error")
+		}
+	})
+
+	t.Run("venues with upcoming conferences cannot be archived", func(t *testing.T) {
+		if err := archiveVenue(ctx, organizer.ID, venue.ID, time.Now()); err == nil {
+			t.Errorf("archiving a venue in use did not cause an error")
+		}
+		assertDatabaseError(t, archive(ctx, organizer.ID, "conference", conference.ID, time.Now()))
+		assertDatabaseError(t, archiveVenue(ctx, organizer.ID, venue.ID, time.Now()))
+		if _, err := saveLocation(ctx, organizer.ID, &Location{Name: "Annex", VenueID: venue.ID}); err == nil {
+			t.Errorf("adding a location to an archived venue did not cause an error")
+		}
+	})
+}
+
+func TestCurrentConference(t *testing.T) {
+	ctx := context.Background()
+
+	organizer := createOrganizer(t, ctx, "current-admin@gophercon.com")
+
+	event, err := saveEvent(ctx, organizer.ID, &Event{Name: "GopherCon UK", Slug: "gcuk"})
+	assertDatabaseError(t, err)
+
+	t.Run("events without conferences are not found", func(t *testing.T) {
+		if _, err := currentConference(ctx, event.ID, time.Now()); err != errNoCurrentConference {
+			t.Errorf("currentConference() error = %v, want %v", err, errNoCurrentConference)
+		}
+	})
+
+	now := time.Now()
+	create := func(slug string, start time.Time) *Conference {
+		c, err := saveConference(ctx, organizer.ID, &Conference{
+			Name:      "GopherCon UK " + slug,
+			Slug:      "gcuk-" + slug,
+			StartDate: start,
+			EndDate:   start.Add(48 * time.Hour),
+			Venue:     Venue{ID: 1},
+			EventID:   event.ID,
+		})
+		assertDatabaseError(t, err)
+		return c
+	}
+	past := create("past", now.Add(-365*24*time.Hour))
+	next := create("next", now.Add(30*24*time.Hour))
+
+	t.Run("the next conference is current", func(t *testing.T) {
+		got, err := currentConference(ctx, event.ID, now)
+		assertDatabaseError(t, err)
+		if len(got.Conferences) != 1 || got.Conferences[0].ID != next.ID || !got.Conferences[0].Current {
+			t.Errorf("incorrect current conference got %+v", got.Conferences)
+		}
+	})
+
+	t.Run("organizers override the current conference", func(t *testing.T) {
+		assertDatabaseError(t, setCurrentOverride(ctx, organizer.ID, past.ID, CurrentAlways))
+		got, err := currentConference(ctx, event.ID, now)
+		assertDatabaseError(t, err)
+		if got.Conferences[0].ID != past.ID {
+			t.Errorf("override was ignored, got %+v", got.Conferences)
+		}
+	})
+
+	t.Run("conferences are found by slug", func(t *testing.T) {
+		got, err := conferenceBySlug(ctx, "gcuk", "next", now)
+		assertDatabaseError(t, err)
+		if got.ID != event.ID || got.Conferences[0].ID != next.ID || got.Conferences[0].Current {
+			t.Errorf("incorrect conference got %+v", got)
+		}
+		if _, err := conferenceBySlug(ctx, "gcuk", "gc-2021", now); err == nil {
+			t.Errorf("conference of another event was found")
+		}
+	})
+}
@@ -0,0 +1,460 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+const eventColumns = `id, name, slug, archived_at IS NOT NULL`
+
+// scanEvent scans a row selected with eventColumns.
+func scanEvent(scan func(dest ...interface{}) error) (*Event, error) {
+	e := Event{Conferences: []Conference{}}
+	if err := scan(&e.ID, &e.Name, &e.Slug, &e.Archived); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// readEventByID returns an event without its conferences, nil if it does not exist.
+func readEventByID(ctx context.Context, id uint32) (*Event, error) {
+	row := sqldb.QueryRow(ctx, `SELECT `+eventColumns+` FROM event WHERE id = $1`, id)
+
+	e, err := scanEvent(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading event: %w", err)
+	}
+	return e, nil
+}
+
+// eventSlugTaken returns true if another event than exceptID uses the slug.
+func eventSlugTaken(ctx context.Context, slug string, exceptID uint32) (bool, error) {
+	row := sqldb.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM event WHERE slug = $1 AND id != $2)`, slug, exceptID)
+
+	var taken bool
+	if err := row.Scan(&taken); err != nil {
+		return false, fmt.Errorf("checking event slug: %w", err)
+	}
+	return taken, nil
+}
+
+// insertEvent saves a new event.
+func insertEvent(ctx context.Context, e *Event) (*Event, error) {
+	row := sqldb.QueryRow(ctx, `INSERT INTO event (name, slug) VALUES ($1, $2) RETURNING `+eventColumns, e.Name, e.Slug)
+
+	saved, err := scanEvent(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("saving event: %w", err)
+	}
+	return saved, nil
+}
+
+// updateEvent saves the name and slug of an event.
+func updateEvent(ctx context.Context, e *Event) (*Event, error) {
+	row := sqldb.QueryRow(ctx, `UPDATE event SET name = $1, slug = $2 WHERE id = $3 RETURNING `+eventColumns,
+		e.Name, e.Slug, e.ID)
+
+	saved, err := scanEvent(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no such event")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("updating event: %w", err)
+	}
+	return saved, nil
+}
+
+// markArchived archives a row of table, which must be one of event, conference, venue or location.
+func markArchived(ctx context.Context, table string, id uint32, archivedAt time.Time) error {
+	switch table {
+	case "event", "conference", "venue", "location":
+	default:
+		return fmt.Errorf("%s cannot be archived", table)
+	}
+	res, err := sqldb.Exec(ctx, `UPDATE `+table+` SET archived_at = $1 WHERE id = $2 AND archived_at IS NULL`,
+		archivedAt, id)
+	if err != nil {
+		return fmt.Errorf("archiving %s: %w", table, err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	if ra == 0 {
+		return fmt.Errorf("no such %s or it was already archived", table)
+	}
+	return nil
+}
+
+const venueColumns = `venue.id, venue.name, venue.description, venue.address, venue.directions, venue.google_maps_url,
+	venue.capacity, venue.archived_at IS NOT NULL`
+
+// scanVenue scans a row selected with venueColumns.
+func scanVenue(scan func(dest ...interface{}) error) (*Venue, error) {
+	var v Venue
+	err := scan(&v.ID,
+		&v.Name,
+		&v.Description,
+		&v.Address,
+		&v.Directions,
+		&v.GoogleMapsURL,
+		&v.Capacity,
+		&v.Archived)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// readVenueByID returns a venue, nil if it does not exist.
+func readVenueByID(ctx context.Context, id uint32) (*Venue, error) {
+	row := sqldb.QueryRow(ctx, `SELECT `+venueColumns+` FROM venue WHERE id = $1`, id)
+
+	v, err := scanVenue(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading venue: %w", err)
+	}
+	return v, nil
+}
+
+// insertVenue saves a new venue.
+func insertVenue(ctx context.Context, v *Venue) (*Venue, error) {
+	row := sqldb.QueryRow(ctx, `INSERT INTO venue (name, description, address, directions, google_maps_url, capacity)
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING `+venueColumns,
+		v.Name, v.Description, v.Address, v.Directions, v.GoogleMapsURL, v.Capacity)
+
+	saved, err := scanVenue(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("saving venue: %w", err)
+	}
+	return saved, nil
+}
+
+// updateVenue saves the details of a venue.
+func updateVenue(ctx context.Context, v *Venue) (*Venue, error) {
+	row := sqldb.QueryRow(ctx, `UPDATE venue SET name = $1, description = $2, address = $3, directions = $4,
+	google_maps_url = $5, capacity = $6 WHERE id = $7 RETURNING `+venueColumns,
+		v.Name, v.Description, v.Address, v.Directions, v.GoogleMapsURL, v.Capacity, v.ID)
+
+	saved, err := scanVenue(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no such venue")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("updating venue: %w", err)
+	}
+	return saved, nil
+}
+
+// countUpcomingConferencesAtVenue returns how many conferences that did not end nor were archived
+// the venue hosts.
+func countUpcomingConferencesAtVenue(ctx context.Context, venueID uint32, now time.Time) (int, error) {
+	row := sqldb.QueryRow(ctx, `SELECT COUNT(*) FROM conference
+	WHERE venue_id = $1 AND archived_at IS NULL AND end_date > $2`, venueID, now)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting conferences at venue: %w", err)
+	}
+	return count, nil
+}
+
+const locationColumns = `id, name, description, address, directions, COALESCE(google_maps_url, ''), capacity, venue_id,
+	archived_at IS NOT NULL`
+
+// scanLocation scans a row selected with locationColumns.
+func scanLocation(scan func(dest ...interface{}) error) (*Location, error) {
+	var l Location
+	err := scan(&l.ID,
+		&l.Name,
+		&l.Description,
+		&l.Address,
+		&l.Directions,
+		&l.GoogleMapsURL,
+		&l.Capacity,
+		&l.VenueID,
+		&l.Archived)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// readLocationByID returns a location, nil if it does not exist.
+func readLocationByID(ctx context.Context, id uint32) (*Location, error) {
+	row := sqldb.QueryRow(ctx, `SELECT `+locationColumns+` FROM location WHERE id = $1`, id)
+
+	l, err := scanLocation(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading location: %w", err)
+	}
+	return l, nil
+}
+
+// insertLocation saves a new location.
+func insertLocation(ctx context.Context, l *Location) (*Location, error) {
+	row := sqldb.QueryRow(ctx, `INSERT INTO location (name, description, address, directions, google_maps_url,
+	capacity, venue_id) VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7) RETURNING `+locationColumns,
+		l.Name, l.Description, l.Address, l.Directions, l.GoogleMapsURL, l.Capacity, l.VenueID)
+
+	saved, err := scanLocation(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("saving location: %w", err)
+	}
+	return saved, nil
+}
+
+// updateLocation saves the details of a location.
+func updateLocation(ctx context.Context, l *Location) (*Location, error) {
+	row := sqldb.QueryRow(ctx, `UPDATE location SET name = $1, description = $2, address = $3, directions = $4,
+	google_maps_url = NULLIF($5, ''), capacity = $6, venue_id = $7 WHERE id = $8 RETURNING `+locationColumns,
+		l.Name, l.Description, l.Address, l.Directions, l.GoogleMapsURL, l.Capacity, l.VenueID, l.ID)
+
+	saved, err := scanLocation(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no such location")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("updating location: %w", err)
+	}
+	return saved, nil
+}
+
+// countSlotsAtOtherVenues returns how many slots use the location in a conference hosted by
+// another venue than venueID.
+func countSlotsAtOtherVenues(ctx context.Context, locationID, venueID uint32) (int, error) {
+	row := sqldb.QueryRow(ctx, `SELECT COUNT(*) FROM conference_slot
+	JOIN conference ON conference_slot.conference_id = conference.id
+	WHERE conference_slot.location_id = $1 AND conference.venue_id != $2`, locationID, venueID)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting slots at location: %w", err)
+	}
+	return count, nil
+}
+
+const conferenceColumns = `conference.id, conference.name, conference.slug, conference.start_date, conference.end_date,
+	conference.event_id, conference.archived_at IS NOT NULL, ` + venueColumns
+
+// scanConference scans a row selected with conferenceColumns from conference joined with venue.
+func scanConference(scan func(dest ...interface{}) error) (*Conference, error) {
+	var c Conference
+	venue, err := scanVenue(func(dest ...interface{}) error {
+		return scan(append([]interface{}{&c.ID, &c.Name, &c.Slug, &c.StartDate, &c.EndDate, &c.EventID, &c.Archived},
+			dest...)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.Venue = *venue
+	return &c, nil
+}
+
+// readConferenceByID returns a conference and its venue, nil if it does not exist.
+func readConferenceByID(ctx context.Context, id uint32) (*Conference, error) {
+	row := sqldb.QueryRow(ctx, `SELECT `+conferenceColumns+` FROM conference
+	JOIN venue ON conference.venue_id = venue.id WHERE conference.id = $1`, id)
+
+	c, err := scanConference(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading conference: %w", err)
+	}
+	return c, nil
+}
+
+// conferenceTaken returns true if another conference than exceptID uses the name or slug.
+func conferenceTaken(ctx context.Context, name, slug string, exceptID uint32) (bool, error) {
+	row := sqldb.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM conference WHERE (name = $1 OR slug = $2) AND id != $3)`,
+		name, slug, exceptID)
+
+	var taken bool
+	if err := row.Scan(&taken); err != nil {
+		return false, fmt.Errorf("checking conference slug: %w", err)
+	}
+	return taken, nil
+}
+
+// insertConference saves a new conference of an event, its dates decide whether it is current.
+func insertConference(ctx context.Context, c *Conference) (uint32, error) {
+	row := sqldb.QueryRow(ctx, `INSERT INTO conference (name, slug, start_date, end_date, event_id, venue_id, current)
+	VALUES ($1, $2, $3, $4, $5, $6, NULL) RETURNING id`, c.Name, c.Slug, c.StartDate, c.EndDate, c.EventID, c.Venue.ID)
+
+	var id uint32
+	if err := row.Scan(&id); err != nil {
+		return 0, fmt.Errorf("saving conference: %w", err)
+	}
+	return id, nil
+}
+
+// updateConference saves the name, slug, dates and venue of a conference.
+func updateConference(ctx context.Context, c *Conference) error {
+	res, err := sqldb.Exec(ctx, `UPDATE conference SET name = $1, slug = $2, start_date = $3, end_date = $4,
+	venue_id = $5 WHERE id = $6`, c.Name, c.Slug, c.StartDate, c.EndDate, c.Venue.ID, c.ID)
+	if err != nil {
+		return fmt.Errorf("updating conference: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	if ra == 0 {
+		return fmt.Errorf("no such conference")
+	}
+	return nil
+}
+
+// countSlotsAtOtherLocations returns how many slots of a conference use a location that is not
+// part of venueID.
+func countSlotsAtOtherLocations(ctx context.Context, conferenceID, venueID uint32) (int, error) {
+	row := sqldb.QueryRow(ctx, `SELECT COUNT(*) FROM conference_slot
+	JOIN location ON conference_slot.location_id = location.id
+	WHERE conference_slot.conference_id = $1 AND location.venue_id != $2`, conferenceID, venueID)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting slots of conference: %w", err)
+	}
+	return count, nil
+}
+
+// scanCurrentOverride converts the nullable current column of a conference into an override.
+func scanCurrentOverride(current sql.NullBool) CurrentOverride {
+	switch {
+	case !current.Valid:
+		return CurrentFromDates
+	case current.Bool:
+		return CurrentAlways
+	default:
+		return CurrentNever
+	}
+}
+
+// readConferencesByEvent returns the conferences of an event that were not archived, in order of
+// start date, and their current overrides by ID.
+func readConferencesByEvent(ctx context.Context, eventID uint32) ([]Conference, map[uint32]CurrentOverride, error) {
+	rows, err := sqldb.Query(ctx, `SELECT conference.current, `+conferenceColumns+` FROM conference
+	JOIN venue ON conference.venue_id = venue.id
+	WHERE conference.event_id = $1 AND conference.archived_at IS NULL
+	ORDER BY conference.start_date, conference.id`, eventID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("querying conferences of event: %w", err)
+	}
+	defer rows.Close()
+
+	conferences := []Conference{}
+	overrides := map[uint32]CurrentOverride{}
+	for rows.Next() {
+		var current sql.NullBool
+		c, err := scanConference(func(dest ...interface{}) error {
+			return rows.Scan(append([]interface{}{&current}, dest...)...)
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("scanning conference: %w", err)
+		}
+		conferences = append(conferences, *c)
+		overrides[c.ID] = scanCurrentOverride(current)
+	}
+	return conferences, overrides, nil
+}
+
+// readEventBySlug returns an event that was not archived without its conferences, nil if there
+// is none.
+func readEventBySlug(ctx context.Context, slug string) (*Event, error) {
+	row := sqldb.QueryRow(ctx, `SELECT `+eventColumns+` FROM event WHERE slug = $1 AND archived_at IS NULL`, slug)
+
+	e, err := scanEvent(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading event: %w", err)
+	}
+	return e, nil
+}
+
+// updateCurrentOverride saves whether a conference is current regardless of its dates, making it
+// always current drops that override from the other conferences of its event.
+func updateCurrentOverride(ctx context.Context, conferenceID uint32, override CurrentOverride) error {
+	var current interface{}
+	switch override {
+	case CurrentFromDates:
+		current = nil
+	case CurrentAlways:
+		current = true
+	case CurrentNever:
+		current = false
+	default:
+		return fmt.Errorf("unknown current override %q", override)
+	}
+	res, err := sqldb.Exec(ctx, `UPDATE conference SET current = CASE WHEN id = $2 THEN $1::BOOLEAN ELSE NULL END
+	WHERE id = $2 OR ($1::BOOLEAN = TRUE AND current = TRUE
+		AND event_id = (SELECT event_id FROM conference WHERE id = $2))`, current, conferenceID)
+	if err != nil {
+		return fmt.Errorf("updating current override: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	if ra == 0 {
+		return fmt.Errorf("no such conference")
+	}
+	return nil
+}
+
+// eventSortColumns are the fields events can be sorted by.
+var eventSortColumns = map[string]sortColumn{
+	"name": {expr: "name", sqlType: "TEXT"},
+	"slug": {expr: "slug", sqlType: "TEXT"},
+	"id":   {expr: "id", sqlType: "INT"},
+}
+
+// readEvents returns a page of the events not archived, without their conferences.
+func readEvents(ctx context.Context, page Page) ([]Event, PageInfo, error) {
+	q, err := newPageQuery(page, "id", eventSortColumns, "name")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	condition, pageArgs := q.condition(1)
+	rows, err := sqldb.Query(ctx, `SELECT `+eventColumns+`, `+q.keyColumn()+` FROM event
+	WHERE archived_at IS NULL AND `+condition+` `+q.orderBy(), pageArgs...)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("querying events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []Event{}
+	keys := []string{}
+	ids := []uint32{}
+	for rows.Next() {
+		var key string
+		e, err := scanEvent(func(dest ...interface{}) error {
+			return rows.Scan(append(dest, &key)...)
+		})
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("scanning event: %w", err)
+		}
+		events = append(events, *e)
+		keys = append(keys, key)
+		ids = append(ids, e.ID)
+	}
+	n, info := q.pageInfo(keys, ids)
+	return events[:n], info, nil
+}
@@ -0,0 +1,153 @@
+package conferences
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// slugPattern matches lowercase words separated by single dashes, such as gc-2021.
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// validateSlug returns an error unless the slug can be used in a URL as is.
+func validateSlug(slug string) error {
+	if !slugPattern.MatchString(slug) {
+		return fmt.Errorf("slug %q must be lowercase letters and digits separated by dashes", slug)
+	}
+	return nil
+}
+
+// validateLink returns an error unless link is empty or an http or https URL.
+func validateLink(name, link string) error {
+	if link != "" && !strings.HasPrefix(link, "https://") && !strings.HasPrefix(link, "http://") {
+		return fmt.Errorf("%s must be an http or https link", name)
+	}
+	return nil
+}
+
+// normalize trims the event and returns an error if it is not valid.
+func (e *Event) normalize() error {
+	e.Name = strings.TrimSpace(e.Name)
+	e.Slug = strings.TrimSpace(e.Slug)
+	if e.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	return validateSlug(e.Slug)
+}
+
+// normalize trims the conference and returns an error if it is not valid.
+func (c *Conference) normalize() error {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Slug = strings.TrimSpace(c.Slug)
+	if c.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if err := validateSlug(c.Slug); err != nil {
+		return err
+	}
+	if c.StartDate.IsZero() || c.EndDate.IsZero() {
+		return fmt.Errorf("start and end dates are required")
+	}
+	if !c.EndDate.After(c.StartDate) {
+		return fmt.Errorf("end date must be after start date")
+	}
+	if c.Venue.ID == 0 {
+		return fmt.Errorf("venue is required")
+	}
+	return nil
+}
+
+// normalize trims the venue and returns an error if it is not valid.
+func (v *Venue) normalize() error {
+	v.Name = strings.TrimSpace(v.Name)
+	v.Description = strings.TrimSpace(v.Description)
+	v.Address = strings.TrimSpace(v.Address)
+	v.Directions = strings.TrimSpace(v.Directions)
+	v.GoogleMapsURL = strings.TrimSpace(v.GoogleMapsURL)
+	if v.Name == "" || v.Address == "" {
+		return fmt.Errorf("name and address are required")
+	}
+	if v.Capacity < 0 {
+		return fmt.Errorf("capacity cannot be negative")
+	}
+	return validateLink("google maps url", v.GoogleMapsURL)
+}
+
+// normalize trims the location and returns an error if it is not valid or does not fit in the
+// venue hosting it, that is the venue of l.VenueID.
+func (l *Location) normalize(venue *Venue) error {
+	l.Name = strings.TrimSpace(l.Name)
+	l.Description = strings.TrimSpace(l.Description)
+	l.Address = strings.TrimSpace(l.Address)
+	l.Directions = strings.TrimSpace(l.Directions)
+	l.GoogleMapsURL = strings.TrimSpace(l.GoogleMapsURL)
+	if l.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if l.Capacity < 0 {
+		return fmt.Errorf("capacity cannot be negative")
+	}
+	if venue.Capacity > 0 && l.Capacity > venue.Capacity {
+		return fmt.Errorf("capacity cannot exceed the %d people the venue holds", venue.Capacity)
+	}
+	if l.Address == "" {
+		l.Address = venue.Address
+	}
+	return validateLink("google maps url", l.GoogleMapsURL)
+}
+
+// atVenue returns an error unless the location is in the venue, which is the venue of the
+// conference using it.
+func (l *Location) atVenue(venueID uint32) error {
+	if l == nil || l.VenueID != venueID {
+		return fmt.Errorf("no such location at the venue of the conference")
+	}
+	return nil
+}
+
+// CurrentOverride decides whether a conference is the current one of its event regardless of dates
+type CurrentOverride string
+
+// Overrides of the current conference
+const (
+	CurrentFromDates CurrentOverride = "dates"
+	CurrentAlways    CurrentOverride = "always"
+	CurrentNever     CurrentOverride = "never"
+)
+
+// currentConferenceIndex returns the index of the current conference of an event, -1 if there is
+// none. A conference overridden to always be current wins, otherwise it is the one taking place at
+// now, else the next one, else the last one that took place. overrides holds the conferences with
+// an override by ID.
+func currentConferenceIndex(conferences []Conference, overrides map[uint32]CurrentOverride, now time.Time) int {
+	current := -1
+	better := func(i int) bool {
+		if current == -1 {
+			return true
+		}
+		c, best := conferences[i], conferences[current]
+		cUpcoming, bestUpcoming := c.EndDate.After(now), best.EndDate.After(now)
+		switch {
+		case cUpcoming != bestUpcoming:
+			return cUpcoming
+		case cUpcoming:
+			// Ongoing conferences started before the upcoming ones.
+			return c.StartDate.Before(best.StartDate)
+		default:
+			return c.EndDate.After(best.EndDate)
+		}
+	}
+	for i, c := range conferences {
+		switch overrides[c.ID] {
+		case CurrentAlways:
+			return i
+		case CurrentNever:
+			continue
+		}
+		if better(i) {
+			current = i
+		}
+	}
+	return current
+}
@@ -0,0 +1,106 @@
+package conferences
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConferenceNormalize(t *testing.T) {
+	start := time.Date(2022, 11, 9, 17, 0, 0, 0, time.UTC)
+	valid := Conference{Name: " GopherCon 2022 ", Slug: "gc-2022", StartDate: start, EndDate: start.Add(72 * time.Hour), Venue: Venue{ID: 1}}
+
+	tests := []struct {
+		name    string
+		edit    func(c *Conference)
+		wantErr bool
+	}{
+		{name: "valid conference", edit: func(c *Conference) {}},
+		{name: "missing name", edit: func(c *Conference) { c.Name = " " }, wantErr: true},
+		{name: "uppercase slug", edit: func(c *Conference) { c.Slug = "GC-2022" }, wantErr: true},
+		{name: "slug with double dash", edit: func(c *Conference) { c.Slug = "gc--2022" }, wantErr: true},
+		{name: "ends before it starts", edit: func(c *Conference) { c.EndDate = start.Add(-time.Hour) }, wantErr: true},
+		{name: "missing dates", edit: func(c *Conference) { c.StartDate = time.Time{} }, wantErr: true},
+		{name: "missing venue", edit: func(c *Conference) { c.Venue.ID = 0 }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.edit(&c)
+			if err := c.normalize(); (err != nil) != tt.wantErr {
+				t.Errorf("normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLocationNormalize(t *testing.T) {
+	venue := &Venue{ID: 2, Address: "1500 Epcot Resorts Boulevard", Capacity: 300}
+
+	tests := []struct {
+		name    string
+		l       Location
+		wantErr bool
+	}{
+		{name: "valid location", l: Location{Name: "Ballroom", Capacity: 300, VenueID: 2}},
+		{name: "larger than the venue", l: Location{Name: "Ballroom", Capacity: 301, VenueID: 2}, wantErr: true},
+		{name: "maps link is not a link", l: Location{Name: "Ballroom", VenueID: 2, GoogleMapsURL: "somewhere"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.l.normalize(venue); (err != nil) != tt.wantErr {
+				t.Errorf("normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.l.Address != venue.Address {
+				t.Errorf("normalize() did not default the address to the venue, got %q", tt.l.Address)
+			}
+		})
+	}
+}
+
+func TestLocationAtVenue(t *testing.T) {
+	location := &Location{ID: 1, Name: "Ballroom", VenueID: 2}
+	if err := location.atVenue(2); err != nil {
+		t.Errorf("location at the venue of the conference caused an error: %v", err)
+	}
+	if err := location.atVenue(1); err == nil {
+		t.Errorf("location of another venue did not cause an error")
+	}
+	var missing *Location
+	if err := missing.atVenue(2); err == nil {
+		t.Errorf("missing location did not cause an error")
+	}
+}
+
+func TestCurrentConferenceIndex(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	conference := func(id uint32, startsIn time.Duration) Conference {
+		return Conference{ID: id, StartDate: now.Add(startsIn), EndDate: now.Add(startsIn + 72*time.Hour)}
+	}
+	past := conference(1, -365*24*time.Hour)
+	older := conference(2, -2*365*24*time.Hour)
+	ongoing := conference(3, -time.Hour)
+	next := conference(4, 30*24*time.Hour)
+	later := conference(5, 365*24*time.Hour)
+
+	tests := []struct {
+		name        string
+		conferences []Conference
+		overrides   map[uint32]CurrentOverride
+		want        int
+	}{
+		{name: "no conferences", want: -1},
+		{name: "ongoing wins", conferences: []Conference{past, next, ongoing}, want: 2},
+		{name: "next upcoming", conferences: []Conference{later, past, next}, want: 2},
+		{name: "latest past", conferences: []Conference{older, past}, want: 1},
+		{name: "override always", conferences: []Conference{past, ongoing}, overrides: map[uint32]CurrentOverride{1: CurrentAlways}, want: 0},
+		{name: "override never", conferences: []Conference{past, ongoing}, overrides: map[uint32]CurrentOverride{3: CurrentNever}, want: 0},
+		{name: "every one overridden never", conferences: []Conference{past}, overrides: map[uint32]CurrentOverride{1: CurrentNever}, want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := currentConferenceIndex(tt.conferences, tt.overrides, now); got != tt.want {
+				t.Errorf("currentConferenceIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
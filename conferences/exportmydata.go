@@ -0,0 +1,30 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExportMyDataResponse defines the output returned by the ExportMyData API method
+type ExportMyDataResponse struct {
+	PersonalData *PersonalData
+}
+
+// ExportMyData returns everything we hold about the authenticated user
+// encore:api auth
+func ExportMyData(ctx context.Context) (*ExportMyDataResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := readPersonalData(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export personal data: %w", err)
+	}
+	if data == nil {
+		return nil, fmt.Errorf("no such user")
+	}
+
+	return &ExportMyDataResponse{PersonalData: data}, nil
+}
@@ -0,0 +1,130 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// submitSessionFeedback saves the rating of an attendee for a session that ended, either a talk
+// or a whole slot. Only attendees who redeemed their admission can rate, once per session.
+func submitSessionFeedback(ctx context.Context, userID uint32, feedback *SessionFeedback, now time.Time) (*SessionFeedback, error) {
+	f := *feedback
+	f.UserID = userID
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+
+	var endsAt time.Time
+	if f.PaperID != 0 {
+		entry, err := readScheduleEntryByPaper(ctx, f.PaperID)
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil {
+			return nil, fmt.Errorf("talk is not scheduled")
+		}
+		if f.ConferenceSlotID != 0 && f.ConferenceSlotID != entry.ConferenceSlotID {
+			return nil, fmt.Errorf("talk is not scheduled in this slot")
+		}
+		speakers, err := readPaperSpeakerIDs(ctx, []uint32{f.PaperID})
+		if err != nil {
+			return nil, err
+		}
+		for _, speakerID := range speakers[f.PaperID] {
+			if speakerID == userID {
+				return nil, fmt.Errorf("speakers cannot rate their own talk")
+			}
+		}
+		f.ConferenceSlotID = entry.ConferenceSlotID
+		endsAt = entry.EndsAt
+	} else {
+		slot, err := readScheduleSlot(ctx, f.ConferenceSlotID)
+		if err != nil {
+			return nil, err
+		}
+		if slot == nil {
+			return nil, fmt.Errorf("no such slot")
+		}
+		endsAt = slot.EndDate
+	}
+	if now.Before(endsAt) {
+		return nil, fmt.Errorf("feedback opens once the session ends")
+	}
+
+	admitted, err := hasRedeemedAdmission(ctx, userID, f.ConferenceSlotID)
+	if err != nil {
+		return nil, err
+	}
+	if !admitted {
+		return nil, fmt.Errorf("only attendees of the session can give feedback")
+	}
+	return insertSessionFeedback(ctx, &f)
+}
+
+// readSpeakerFeedback aggregates the feedback on the talks of a conference by speaker.
+func readSpeakerFeedback(ctx context.Context, conferenceID uint32) ([]SpeakerFeedback, error) {
+	papers, paperSpeakers, err := readAcceptedTalks(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+	paperIDs := make([]uint32, 0, len(papers))
+	for _, p := range papers {
+		paperIDs = append(paperIDs, p.ID)
+	}
+	feedback, err := readPapersFeedback(ctx, paperIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	speakers := summarizeSpeakerFeedback(papers, paperSpeakers, feedback)
+	userIDs := make([]uint32, 0, len(speakers))
+	for _, s := range speakers {
+		userIDs = append(userIDs, s.UserID)
+	}
+	contacts, err := readUserContacts(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range speakers {
+		speakers[i].GivenName = contacts[speakers[i].UserID].GivenName
+		speakers[i].FamilyName = contacts[speakers[i].UserID].FamilyName
+	}
+	return speakers, nil
+}
+
+// speakerFeedback returns the feedback of every speaker of a conference for organizers.
+func speakerFeedback(ctx context.Context, userID, conferenceID uint32) ([]SpeakerFeedback, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	return readSpeakerFeedback(ctx, conferenceID)
+}
+
+// sessionFeedback returns the feedback given to slots as a whole, rather than to their talks,
+// for organizers.
+func sessionFeedback(ctx context.Context, userID, conferenceID uint32) ([]SlotFeedback, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	slots, feedback, err := readSlotsFeedback(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeSlotFeedback(slots, feedback), nil
+}
+
+// mySpeakerFeedback returns the feedback on the talks the user gave at a conference, it is only
+// shown to them.
+func mySpeakerFeedback(ctx context.Context, userID, conferenceID uint32) (*SpeakerFeedback, error) {
+	speakers, err := readSpeakerFeedback(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range speakers {
+		if speakers[i].UserID == userID {
+			return &speakers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("not a speaker of the conference")
+}
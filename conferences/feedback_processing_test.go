@@ -0,0 +1,109 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"encore.dev/storage/sqldb"
+	"github.com/gofrs/uuid"
+)
+
+func TestSessionFeedback(t *testing.T) {
+	ctx := context.Background()
+
+	organizer := createOrganizer(t, ctx, "feedback-organizer@gophercon.com")
+	speaker, err := createAttendee(ctx, nil, &User{Email: "rated-speaker@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	attendee, err := createAttendee(ctx, nil, &User{Email: "rating-attendee@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	absentee, err := createAttendee(ctx, nil, &User{Email: "absent-attendee@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+
+	start := time.Date(2020, 11, 11, 17, 0, 0, 0, time.UTC)
+	createSlot := func(name string, dependsOn uint32) uint32 {
+		row := sqldb.QueryRow(ctx, `INSERT INTO conference_slot (name, description, cost, capacity, start_date, end_date,
+		purchaseable_from, purchaseable_until, available_to_public, conference_id, depends_on, location_id)
+		VALUES ($1, 'Rated', 0, 100, $2, $3, $2, $2, TRUE, 1, NULLIF($4, 0), 1) RETURNING id`,
+			name, start, start.Add(4*time.Hour), dependsOn)
+		var slotID uint32
+		assertDatabaseError(t, row.Scan(&slotID))
+		return slotID
+	}
+	admissionID := createSlot("Feedback admission", 0)
+	talksID := createSlot("Feedback talks", admissionID)
+
+	_, err = createSlotClaim(ctx, nil, &SlotClaim{
+		ConferenceSlot: &ConferenceSlot{ID: admissionID},
+		TicketID:       uuid.Must(uuid.NewV4()),
+		Redeemed:       true,
+	}, attendee.ID)
+	assertDatabaseError(t, err)
+
+	paperID, err := submitPaper(ctx, speaker.ID, &Paper{
+		ConferenceID:  1,
+		Title:         "Rated talk",
+		ElevatorPitch: "Pitch",
+		Description:   "Description",
+		Format:        TalkFormatShort,
+	})
+	assertDatabaseError(t, err)
+	assertDatabaseError(t, updatePaperStatus(ctx, nil, paperID, PaperStatusAccepted))
+	_, _, err = scheduleTalk(ctx, organizer.ID, ScheduleEntry{PaperID: paperID, ConferenceSlotID: talksID}, true)
+	assertDatabaseError(t, err)
+
+	talkEnd := start.Add(25 * time.Minute)
+	rating := &SessionFeedback{PaperID: paperID, Rating: 4, Comment: "Clear and useful"}
+
+	t.Run("feedback opens once the talk ends", func(t *testing.T) {
+		if _, err := submitSessionFeedback(ctx, attendee.ID, rating, talkEnd.Add(-time.Minute)); err == nil {
+			t.Errorf("rating before the end did not cause an error")
+		}
+	})
+
+	t.Run("only admitted attendees rate, once", func(t *testing.T) {
+		if _, err := submitSessionFeedback(ctx, absentee.ID, rating, talkEnd); err == nil {
+			t.Errorf("rating without admission did not cause an error")
+		}
+		saved, err := submitSessionFeedback(ctx, attendee.ID, rating, talkEnd)
+		assertDatabaseError(t, err)
+		if saved.ConferenceSlotID != talksID {
+			t.Errorf("feedback saved for slot %d, want %d", saved.ConferenceSlotID, talksID)
+		}
+		if _, err := submitSessionFeedback(ctx, attendee.ID, rating, talkEnd); err == nil {
+			t.Errorf("rating twice did not cause an error")
+		}
+	})
+
+	t.Run("speakers see their own feedback", func(t *testing.T) {
+		mine, err := mySpeakerFeedback(ctx, speaker.ID, 1)
+		assertDatabaseError(t, err)
+		if mine.Overall.Count != 1 || mine.Overall.AverageRating != 4 || len(mine.Overall.Comments) != 1 {
+			t.Errorf("incorrect feedback got %+v", mine.Overall)
+		}
+		if _, err := speakerFeedback(ctx, speaker.ID, 1); err == nil {
+			t.Errorf("speakers reading every feedback did not cause an error")
+		}
+		all, err := speakerFeedback(ctx, organizer.ID, 1)
+		assertDatabaseError(t, err)
+		if len(all) == 0 {
+			t.Errorf("organizers got no feedback")
+		}
+	})
+
+	t.Run("organizers see the feedback on whole slots", func(t *testing.T) {
+		_, err := submitSessionFeedback(ctx, attendee.ID, &SessionFeedback{ConferenceSlotID: talksID, Rating: 2},
+			start.Add(4*time.Hour))
+		assertDatabaseError(t, err)
+		if _, err := sessionFeedback(ctx, speaker.ID, 1); err == nil {
+			t.Errorf("speakers reading slot feedback did not cause an error")
+		}
+		slots, err := sessionFeedback(ctx, organizer.ID, 1)
+		assertDatabaseError(t, err)
+		for _, s := range slots {
+			if s.ConferenceSlotID == talksID && (s.Count != 1 || s.AverageRating != 2) {
+				t.Errorf("incorrect slot feedback got %+v", s)
+			}
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"encore.dev/storage/sqldb"
+	"github.com/lib/pq"
+)
+
+// errFeedbackGiven is returned when an attendee rates the same session twice.
+var errFeedbackGiven = fmt.Errorf("feedback was already given for this session")
+
+// insertSessionFeedback saves the feedback of an attendee, only once per session.
+func insertSessionFeedback(ctx context.Context, f *SessionFeedback) (*SessionFeedback, error) {
+	row := sqldb.QueryRow(ctx, `INSERT INTO session_feedback (conference_slot_id, paper_id, user_id, rating, comment)
+	VALUES ($1, NULLIF($2, 0), $3, $4, $5)
+	ON CONFLICT DO NOTHING RETURNING id, created_at`, f.ConferenceSlotID, f.PaperID, f.UserID, f.Rating, f.Comment)
+
+	saved := *f
+	err := row.Scan(&saved.ID, &saved.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, errFeedbackGiven
+	}
+	if err != nil {
+		return nil, fmt.Errorf("saving session feedback: %w", err)
+	}
+	return &saved, nil
+}
+
+// readPapersFeedback returns the feedback given to the passed papers, oldest first.
+func readPapersFeedback(ctx context.Context, paperIDs []uint32) ([]SessionFeedback, error) {
+	ids := make(pq.Int64Array, 0, len(paperIDs))
+	for _, id := range paperIDs {
+		ids = append(ids, int64(id))
+	}
+	rows, err := sqldb.Query(ctx, `SELECT id, conference_slot_id, paper_id, COALESCE(user_id, 0), rating, comment, created_at
+	FROM session_feedback WHERE paper_id = ANY($1) ORDER BY created_at, id`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("querying session feedback: %w", err)
+	}
+	defer rows.Close()
+
+	feedback := []SessionFeedback{}
+	for rows.Next() {
+		f := SessionFeedback{}
+		err := rows.Scan(&f.ID, &f.ConferenceSlotID, &f.PaperID, &f.UserID, &f.Rating, &f.Comment, &f.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scanning session feedback: %w", err)
+		}
+		feedback = append(feedback, f)
+	}
+	return feedback, nil
+}
+
+// readSlotsFeedback returns the slots of a conference rated as a whole and the feedback they
+// were given, oldest first.
+func readSlotsFeedback(ctx context.Context, conferenceID uint32) ([]ConferenceSlot, []SessionFeedback, error) {
+	slots, err := readCalendarSlots(ctx, `WHERE conference_slot.conference_id = $1 AND conference_slot.id IN (
+		SELECT conference_slot_id FROM session_feedback WHERE paper_id IS NULL
+	) ORDER BY conference_slot.start_date, conference_slot.id`, conferenceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := sqldb.Query(ctx, `SELECT session_feedback.id, session_feedback.conference_slot_id,
+	COALESCE(session_feedback.user_id, 0), session_feedback.rating, session_feedback.comment, session_feedback.created_at
+	FROM session_feedback
+	JOIN conference_slot ON session_feedback.conference_slot_id = conference_slot.id
+	WHERE conference_slot.conference_id = $1 AND session_feedback.paper_id IS NULL
+	ORDER BY session_feedback.created_at, session_feedback.id`, conferenceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("querying slot feedback: %w", err)
+	}
+	defer rows.Close()
+
+	feedback := []SessionFeedback{}
+	for rows.Next() {
+		f := SessionFeedback{}
+		err := rows.Scan(&f.ID, &f.ConferenceSlotID, &f.UserID, &f.Rating, &f.Comment, &f.CreatedAt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("scanning slot feedback: %w", err)
+		}
+		feedback = append(feedback, f)
+	}
+	return slots, feedback, nil
+}
+
+// hasRedeemedAdmission returns true if the user redeemed a claim for the slot or for the general
+// admission it depends on.
+func hasRedeemedAdmission(ctx context.Context, userID, slotID uint32) (bool, error) {
+	row := sqldb.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM slot_claim
+	JOIN conference_slot ON conference_slot.id = $2
+	WHERE slot_claim.user_id = $1 AND slot_claim.redeemed = TRUE AND slot_claim.revoked = FALSE
+	AND slot_claim.conference_slot_id IN (conference_slot.id, conference_slot.depends_on))`, userID, slotID)
+
+	var admitted bool
+	if err := row.Scan(&admitted); err != nil {
+		return false, fmt.Errorf("checking admission: %w", err)
+	}
+	return admitted, nil
+}
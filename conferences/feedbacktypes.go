@@ -0,0 +1,138 @@
+package conferences
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SessionFeedback is how an attendee rated a session after it ended, PaperID is zero when the
+// feedback is about the slot itself rather than a talk scheduled in it
+type SessionFeedback struct {
+	ID               uint32
+	ConferenceSlotID uint32
+	PaperID          uint32
+	UserID           uint32
+	Rating           int
+	Comment          string
+	CreatedAt        time.Time
+}
+
+const (
+	minRating             = 1
+	maxRating             = 5
+	maxFeedbackCommentLen = 2000
+)
+
+// validate trims the comment and returns an error if the feedback is not valid.
+func (f *SessionFeedback) validate() error {
+	if f.Rating < minRating || f.Rating > maxRating {
+		return fmt.Errorf("rating must be between %d and %d", minRating, maxRating)
+	}
+	f.Comment = strings.TrimSpace(f.Comment)
+	if utf8.RuneCountInString(f.Comment) > maxFeedbackCommentLen {
+		return fmt.Errorf("comment must be at most %d characters long", maxFeedbackCommentLen)
+	}
+	return nil
+}
+
+// FeedbackSummary aggregates ratings without telling who gave them
+type FeedbackSummary struct {
+	Count         int
+	AverageRating float64
+	// Ratings counts how many times each rating was given, Ratings[0] being the lowest.
+	Ratings  [maxRating]int
+	Comments []string
+}
+
+// add counts a feedback in the summary.
+func (s *FeedbackSummary) add(f SessionFeedback) {
+	s.AverageRating = (s.AverageRating*float64(s.Count) + float64(f.Rating)) / float64(s.Count+1)
+	s.Count++
+	s.Ratings[f.Rating-minRating]++
+	if f.Comment != "" {
+		s.Comments = append(s.Comments, f.Comment)
+	}
+}
+
+// PaperFeedback is the feedback given to a talk
+type PaperFeedback struct {
+	PaperID uint32
+	Title   string
+	FeedbackSummary
+}
+
+// SpeakerFeedback is the feedback given to every talk of a speaker at a conference
+type SpeakerFeedback struct {
+	UserID     uint32
+	GivenName  string
+	FamilyName string
+	Overall    FeedbackSummary
+	Papers     []PaperFeedback
+}
+
+// summarizeSpeakerFeedback aggregates feedback on papers by speaker, in order of user ID. Talks
+// with several speakers count towards each of them.
+func summarizeSpeakerFeedback(papers []Paper, paperSpeakers map[uint32][]uint32, feedback []SessionFeedback) []SpeakerFeedback {
+	byPaper := map[uint32]*PaperFeedback{}
+	for _, p := range papers {
+		byPaper[p.ID] = &PaperFeedback{PaperID: p.ID, Title: p.Title, FeedbackSummary: FeedbackSummary{Comments: []string{}}}
+	}
+	for _, f := range feedback {
+		if pf, ok := byPaper[f.PaperID]; ok {
+			pf.add(f)
+		}
+	}
+
+	bySpeaker := map[uint32]*SpeakerFeedback{}
+	for _, p := range papers {
+		for _, userID := range paperSpeakers[p.ID] {
+			sf, ok := bySpeaker[userID]
+			if !ok {
+				sf = &SpeakerFeedback{UserID: userID, Overall: FeedbackSummary{Comments: []string{}}, Papers: []PaperFeedback{}}
+				bySpeaker[userID] = sf
+			}
+			sf.Papers = append(sf.Papers, *byPaper[p.ID])
+		}
+	}
+
+	speakers := make([]SpeakerFeedback, 0, len(bySpeaker))
+	for _, sf := range bySpeaker {
+		for _, pf := range sf.Papers {
+			for _, f := range feedback {
+				if f.PaperID == pf.PaperID {
+					sf.Overall.add(f)
+				}
+			}
+		}
+		speakers = append(speakers, *sf)
+	}
+	sort.Slice(speakers, func(i, j int) bool { return speakers[i].UserID < speakers[j].UserID })
+	return speakers
+}
+
+// SlotFeedback is the feedback given to a whole slot rather than one of its talks
+type SlotFeedback struct {
+	ConferenceSlotID uint32
+	Name             string
+	FeedbackSummary
+}
+
+// summarizeSlotFeedback aggregates feedback on whole slots, in the order of the slots. Feedback
+// on talks is left to summarizeSpeakerFeedback.
+func summarizeSlotFeedback(slots []ConferenceSlot, feedback []SessionFeedback) []SlotFeedback {
+	summaries := make([]SlotFeedback, 0, len(slots))
+	index := map[uint32]int{}
+	for _, s := range slots {
+		index[s.ID] = len(summaries)
+		summaries = append(summaries, SlotFeedback{ConferenceSlotID: s.ID, Name: s.Name, FeedbackSummary: FeedbackSummary{Comments: []string{}}})
+	}
+	for _, f := range feedback {
+		if i, ok := index[f.ConferenceSlotID]; ok && f.PaperID == 0 {
+			summaries[i].add(f)
+		}
+	}
+	return summaries
+}
@@ -0,0 +1,80 @@
+package conferences
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSessionFeedbackValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       SessionFeedback
+		wantErr bool
+	}{
+		{name: "lowest rating", f: SessionFeedback{Rating: 1}},
+		{name: "highest rating with comment", f: SessionFeedback{Rating: 5, Comment: " Great talk "}},
+		{name: "no rating", f: SessionFeedback{}, wantErr: true},
+		{name: "rating too high", f: SessionFeedback{Rating: 6}, wantErr: true},
+		{name: "comment too long", f: SessionFeedback{Rating: 3, Comment: strings.Repeat("g", maxFeedbackCommentLen+1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.f.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSummarizeSpeakerFeedback(t *testing.T) {
+	papers := []Paper{{ID: 1, Title: "Solo talk"}, {ID: 2, Title: "Joint talk"}}
+	paperSpeakers := map[uint32][]uint32{1: {10}, 2: {10, 20}}
+	feedback := []SessionFeedback{
+		{PaperID: 1, Rating: 5, Comment: "Loved it"},
+		{PaperID: 1, Rating: 3},
+		{PaperID: 2, Rating: 4, Comment: "Good demo"},
+		{PaperID: 99, Rating: 1},
+	}
+
+	speakers := summarizeSpeakerFeedback(papers, paperSpeakers, feedback)
+	if len(speakers) != 2 || speakers[0].UserID != 10 || speakers[1].UserID != 20 {
+		t.Fatalf("incorrect speakers got %+v", speakers)
+	}
+
+	solo := speakers[0]
+	if solo.Overall.Count != 3 || solo.Overall.AverageRating != 4 || len(solo.Overall.Comments) != 2 {
+		t.Errorf("incorrect overall feedback got %+v", solo.Overall)
+	}
+	if solo.Overall.Ratings != [maxRating]int{0, 0, 1, 1, 1} {
+		t.Errorf("incorrect rating distribution got %v", solo.Overall.Ratings)
+	}
+	if len(solo.Papers) != 2 || solo.Papers[0].Count != 2 || solo.Papers[1].Count != 1 {
+		t.Errorf("incorrect paper feedback got %+v", solo.Papers)
+	}
+
+	joint := speakers[1]
+	if joint.Overall.Count != 1 || joint.Overall.AverageRating != 4 || len(joint.Papers) != 1 {
+		t.Errorf("incorrect co-speaker feedback got %+v", joint)
+	}
+}
+
+func TestSummarizeSlotFeedback(t *testing.T) {
+	slots := []ConferenceSlot{{ID: 1, Name: "Workshop"}, {ID: 2, Name: "Party"}}
+	feedback := []SessionFeedback{
+		{ConferenceSlotID: 1, Rating: 5, Comment: "Great hands-on"},
+		{ConferenceSlotID: 1, Rating: 3},
+		{ConferenceSlotID: 1, PaperID: 7, Rating: 1},
+		{ConferenceSlotID: 99, Rating: 1},
+	}
+
+	summaries := summarizeSlotFeedback(slots, feedback)
+	if len(summaries) != 2 || summaries[0].ConferenceSlotID != 1 || summaries[1].ConferenceSlotID != 2 {
+		t.Fatalf("incorrect slots got %+v", summaries)
+	}
+	if summaries[0].Count != 2 || summaries[0].AverageRating != 4 || len(summaries[0].Comments) != 1 {
+		t.Errorf("incorrect slot feedback got %+v", summaries[0])
+	}
+	if summaries[1].Count != 0 {
+		t.Errorf("incorrect feedback for unrated slot got %+v", summaries[1])
+	}
+}
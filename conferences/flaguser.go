@@ -0,0 +1,39 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// FlagUserParams defines the inputs used by the FlagUser API method
+type FlagUserParams struct {
+	IncidentID uint32
+	UserID     uint32
+	Reason     string
+	// RevokeClaims revokes the user SlotClaims for the conference the incident happened in.
+	RevokeClaims bool
+}
+
+// FlagUserResponse defines the output returned by the FlagUser API method
+type FlagUserResponse struct {
+	RevokedClaims int64
+}
+
+// FlagUser flags a user as part of handling an incident, optionally revoking their claims
+// encore:api auth
+func FlagUser(ctx context.Context, params *FlagUserParams) (*FlagUserResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRole(ctx, userID, RoleCoCTeam); err != nil {
+		return nil, err
+	}
+
+	revoked, err := flagUserForIncident(ctx, userID, params.IncidentID, params.UserID, params.Reason, params.RevokeClaims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to flag user: %w", err)
+	}
+
+	return &FlagUserResponse{RevokedClaims: revoked}, nil
+}
@@ -0,0 +1,33 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetCFPStatusParams defines the inputs used by the GetCFPStatus API method
+type GetCFPStatusParams struct {
+	ConferenceID uint32
+}
+
+// GetCFPStatusResponse defines the output returned by the GetCFPStatus API method
+type GetCFPStatusResponse struct {
+	Open bool
+	// Rules is nil when the conference does not restrict submissions.
+	Rules *CFPRules
+}
+
+// GetCFPStatus reports whether the call for papers of a conference is open and when it closes
+// encore:api public
+func GetCFPStatus(ctx context.Context, params *GetCFPStatusParams) (*GetCFPStatusResponse, error) {
+	rules, err := readCFPRules(ctx, nil, params.ConferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve cfp status: %w", err)
+	}
+
+	return &GetCFPStatusResponse{
+		Open:  rules == nil || rules.Open(time.Now()),
+		Rules: rules,
+	}, nil
+}
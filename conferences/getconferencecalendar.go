@@ -0,0 +1,61 @@
+package conferences
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+// writeCalendarResponse serves an .ics body as calendar apps expect it, errors as plain text.
+func writeCalendarResponse(w http.ResponseWriter, calendar string, err error) {
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "no such") {
+			status = http.StatusNotFound
+		}
+		http.Error(w, "failed to retrieve calendar: "+err.Error(), status)
+		return
+	}
+	w.Header().Set("Content-Type", calendarContentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(calendar))
+}
+
+// queryID returns a numeric query parameter, zero if it is not set.
+func queryID(req *http.Request, name string) (uint32, error) {
+	value := req.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(id), nil
+}
+
+// GetConferenceCalendar serves the schedule of a conference in iCalendar format, it takes the
+// conference_id and optionally a location_id and track to restrict the calendar to one room or
+// one track
+// encore:api public raw
+func GetConferenceCalendar(w http.ResponseWriter, req *http.Request) {
+	conferenceID, err := queryID(req, "conference_id")
+	if err != nil {
+		http.Error(w, "invalid conference_id", http.StatusBadRequest)
+		return
+	}
+	locationID, err := queryID(req, "location_id")
+	if err != nil {
+		http.Error(w, "invalid location_id", http.StatusBadRequest)
+		return
+	}
+
+	calendar, err := conferenceCalendar(req.Context(), conferenceID, CalendarFilter{
+		LocationID: locationID,
+		Track:      req.URL.Query().Get("track"),
+	}, time.Now())
+	writeCalendarResponse(w, calendar, err)
+}
@@ -3,8 +3,7 @@ package conferences
 import (
 	"context"
 	"fmt"
-
-	"encore.dev/storage/sqldb"
+	"time"
 )
 
 // GetCurrentByEventParams defines the inputs used by the GetCurrentByEvent API method
@@ -17,72 +16,43 @@ type GetCurrentByEventResponse struct {
 	Event Event
 }
 
-// GetCurrentByEvent retrieves the current conference and event information for a specific event
+// GetCurrentByEvent retrieves the current conference and event information for a specific event,
+// the current conference is the one taking place, else the next one, else the last one, unless
+// organizers override it
 // encore:api public
 func GetCurrentByEvent(ctx context.Context, params *GetCurrentByEventParams) (*GetCurrentByEventResponse, error) {
-
-	rows, err := sqldb.Query(ctx,
-		`SELECT event.id,
-		 event.name,
-		 event.slug,
-		 conference.id,
-		 conference.name,
-		 conference.slug,
-		 conference.start_date,
-		 conference.end_date,
-		 venue.id,
-		 venue.name,
-		 venue.description,
-		 venue.address,
-		 venue.directions,
-		 venue.google_maps_url,
-		 venue.capacity 
-		 FROM event 
-		 LEFT JOIN conference ON conference.event_id = event.id LEFT JOIN venue ON conference.venue_id = venue.id
-		 WHERE event.id = $1 and conference.current = true
-		`, params.EventID)
+	event, err := currentConference(ctx, params.EventID, time.Now())
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve current conference: %w", err)
 	}
 
-	defer rows.Close()
-
-	idToEvent := map[uint32]*Event{}
+	return &GetCurrentByEventResponse{
+		Event: *event,
+	}, nil
+}
 
-	for rows.Next() {
-		var event Event
-		var conference Conference
+// GetConferenceBySlugParams defines the inputs used by the GetConferenceBySlug API method
+type GetConferenceBySlugParams struct {
+	EventSlug string
+	// ConferenceSlug is the slug of the conference, with or without the slug of the event in front.
+	ConferenceSlug string
+}
 
-		err := rows.Scan(
-			&event.ID,
-			&event.Name,
-			&event.Slug,
-			&conference.ID,
-			&conference.Name,
-			&conference.Slug,
-			&conference.StartDate,
-			&conference.EndDate,
-			&conference.Venue.ID,
-			&conference.Venue.Name,
-			&conference.Venue.Description,
-			&conference.Venue.Address,
-			&conference.Venue.Directions,
-			&conference.Venue.GoogleMapsURL,
-			&conference.Venue.Capacity,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan rows: %w", err)
-		}
+// GetConferenceBySlugResponse defines the output returned by the GetConferenceBySlug API method
+type GetConferenceBySlugResponse struct {
+	Event Event
+}
 
-		if existingEvent, ok := idToEvent[event.ID]; ok {
-			existingEvent.Conferences = append(existingEvent.Conferences, conference)
-		} else {
-			event.Conferences = append(event.Conferences, conference)
-			idToEvent[event.ID] = &event
-		}
+// GetConferenceBySlug retrieves an event with one of its conferences by their slugs, so the
+// website can route /gc/2021 to GopherCon 2021
+// encore:api public
+func GetConferenceBySlug(ctx context.Context, params *GetConferenceBySlugParams) (*GetConferenceBySlugResponse, error) {
+	event, err := conferenceBySlug(ctx, params.EventSlug, params.ConferenceSlug, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve conference: %w", err)
 	}
 
-	return &GetCurrentByEventResponse{
-		Event: *idToEvent[params.EventID],
+	return &GetConferenceBySlugResponse{
+		Event: *event,
 	}, nil
 }
@@ -0,0 +1,35 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetIncidentParams defines the inputs used by the GetIncident API method
+type GetIncidentParams struct {
+	IncidentID uint32
+}
+
+// GetIncidentResponse defines the output returned by the GetIncident API method
+type GetIncidentResponse struct {
+	Incident *Incident
+}
+
+// GetIncident retrieves an incident with its notes and audit trail, only the CoC team can see it
+// encore:api auth
+func GetIncident(ctx context.Context, params *GetIncidentParams) (*GetIncidentResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRole(ctx, userID, RoleCoCTeam); err != nil {
+		return nil, err
+	}
+
+	incident, err := viewIncident(ctx, userID, params.IncidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve incident: %w", err)
+	}
+
+	return &GetIncidentResponse{Incident: incident}, nil
+}
@@ -0,0 +1,34 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetMyAgendaParams defines the inputs used by the GetMyAgenda API method
+type GetMyAgendaParams struct {
+	ConferenceID uint32
+}
+
+// GetMyAgendaResponse defines the output returned by the GetMyAgenda API method
+type GetMyAgendaResponse struct {
+	Items    []AgendaItem
+	Overlaps []AgendaOverlap
+}
+
+// GetMyAgenda retrieves the slots the authenticated user bookmarked or claimed in a conference
+// with warnings for those happening at the same time
+// encore:api auth
+func GetMyAgenda(ctx context.Context, params *GetMyAgendaParams) (*GetMyAgendaResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	items, overlaps, err := personalAgenda(ctx, userID, params.ConferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve agenda: %w", err)
+	}
+
+	return &GetMyAgendaResponse{Items: items, Overlaps: overlaps}, nil
+}
@@ -0,0 +1,61 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetMySponsorParams defines the inputs used by the GetMySponsor API method
+type GetMySponsorParams struct {
+	SponsorID uint32
+}
+
+// GetMySponsorResponse defines the output returned by the GetMySponsor API method
+type GetMySponsorResponse struct {
+	Sponsor      *Sponsor
+	Entitlements []EntitlementUsage
+}
+
+// GetMySponsor retrieves a sponsor the authenticated user is a contact of, with its contacts and
+// how much of its package it has used
+// encore:api auth
+func GetMySponsor(ctx context.Context, params *GetMySponsorParams) (*GetMySponsorResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	sponsor, usage, err := mySponsor(ctx, userID, params.SponsorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve sponsor: %w", err)
+	}
+
+	return &GetMySponsorResponse{Sponsor: sponsor, Entitlements: usage}, nil
+}
+
+// UpdateMySponsorProfileParams defines the inputs used by the UpdateMySponsorProfile API method
+type UpdateMySponsorProfileParams struct {
+	SponsorID uint32
+	Profile   SponsorProfile
+}
+
+// UpdateMySponsorProfileResponse defines the output returned by the UpdateMySponsorProfile API method
+type UpdateMySponsorProfileResponse struct {
+	Sponsor *Sponsor
+}
+
+// UpdateMySponsorProfile saves the public profile of a sponsor the authenticated user is a contact of
+// encore:api auth
+func UpdateMySponsorProfile(ctx context.Context, params *UpdateMySponsorProfileParams) (*UpdateMySponsorProfileResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	sponsor, err := updateMySponsorProfile(ctx, userID, params.SponsorID, params.Profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update sponsor profile: %w", err)
+	}
+
+	return &UpdateMySponsorProfileResponse{Sponsor: sponsor}, nil
+}
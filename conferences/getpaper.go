@@ -3,8 +3,6 @@ package conferences
 import (
 	"context"
 	"fmt"
-
-	"encore.dev/storage/sqldb"
 )
 
 // GetPaperParams defines the inputs used by the GetPaper API method
@@ -17,41 +15,19 @@ type GetPaperResponse struct {
 	Paper Paper
 }
 
-// GetPaper retrieves information for a specific paper id
-// encore:api public
+// GetPaper retrieves information for a specific paper id, only its speakers and organizers can see it
+// encore:api auth
 func GetPaper(ctx context.Context, params *GetPaperParams) (*GetPaperResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
 
-	row := sqldb.QueryRow(
-		ctx,
-		`
-		SELECT id,
-		user_id,
-		conference_id,
-		title,
-		elevator_pitch,
-		description,
-		notes
-		FROM paper_submission
-		WHERE id = $1
-		`, params.PaperID,
-	)
-
-	var paper Paper
-
-	err := row.Scan(
-		&paper.ID,
-		&paper.UserID,
-		&paper.ConferenceID,
-		&paper.Title,
-		&paper.ElevatorPitch,
-		&paper.Description,
-		&paper.Notes,
-	)
-
+	paper, err := readManagedPaper(ctx, userID, params.PaperID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve paper: %w", err)
 	}
 
-	return &GetPaperResponse{Paper: paper}, nil
+	return &GetPaperResponse{Paper: *paper}, nil
 
 }
@@ -0,0 +1,35 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetPaperRankingParams defines the inputs used by the GetPaperRanking API method
+type GetPaperRankingParams struct {
+	ConferenceID uint32
+}
+
+// GetPaperRankingResponse defines the output returned by the GetPaperRanking API method
+type GetPaperRankingResponse struct {
+	Rankings []PaperRanking
+}
+
+// GetPaperRanking retrieves the papers of a conference ranked by their aggregated review scores
+// encore:api auth
+func GetPaperRanking(ctx context.Context, params *GetPaperRankingParams) (*GetPaperRankingResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+
+	rankings, err := paperRanking(ctx, params.ConferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank papers: %w", err)
+	}
+
+	return &GetPaperRankingResponse{Rankings: rankings}, nil
+}
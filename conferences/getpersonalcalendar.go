@@ -0,0 +1,38 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// IssueCalendarFeedTokenResponse defines the output returned by the IssueCalendarFeedToken API method
+type IssueCalendarFeedTokenResponse struct {
+	Token string
+}
+
+// IssueCalendarFeedToken returns a token for the authenticated user to subscribe to their agenda
+// from calendar apps, which cannot authenticate, issuing a new one revokes the previous one
+// encore:api auth
+func IssueCalendarFeedToken(ctx context.Context) (*IssueCalendarFeedTokenResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := issueCalendarFeedToken(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue calendar feed token: %w", err)
+	}
+
+	return &IssueCalendarFeedTokenResponse{Token: token}, nil
+}
+
+// GetPersonalCalendar serves the agenda of the user the token query parameter was issued to in
+// iCalendar format
+// encore:api public raw
+func GetPersonalCalendar(w http.ResponseWriter, req *http.Request) {
+	calendar, err := personalCalendar(req.Context(), req.URL.Query().Get("token"), time.Now())
+	writeCalendarResponse(w, calendar, err)
+}
@@ -0,0 +1,35 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetScheduleParams defines the inputs used by the GetSchedule API method
+type GetScheduleParams struct {
+	ConferenceID uint32
+	// TimeZone is the IANA name of the zone days are split in, such as America/New_York, UTC if empty.
+	TimeZone string
+}
+
+// GetScheduleResponse defines the output returned by the GetSchedule API method
+type GetScheduleResponse struct {
+	Days []ScheduleDay
+}
+
+// GetSchedule retrieves the scheduled talks of a conference grouped by day and track
+// encore:api public
+func GetSchedule(ctx context.Context, params *GetScheduleParams) (*GetScheduleResponse, error) {
+	loc, err := time.LoadLocation(params.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", params.TimeZone)
+	}
+
+	days, err := conferenceSchedule(ctx, params.ConferenceID, loc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve schedule: %w", err)
+	}
+
+	return &GetScheduleResponse{Days: days}, nil
+}
@@ -0,0 +1,33 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetScheduleConflictsParams defines the inputs used by the GetScheduleConflicts API method
+type GetScheduleConflictsParams struct {
+	ConferenceID uint32
+}
+
+// GetScheduleConflictsResponse defines the output returned by the GetScheduleConflicts API method
+type GetScheduleConflictsResponse struct {
+	Conflicts []ScheduleConflict
+}
+
+// GetScheduleConflicts retrieves every conflict in the schedule of a conference, only organizers
+// can see them
+// encore:api auth
+func GetScheduleConflicts(ctx context.Context, params *GetScheduleConflictsParams) (*GetScheduleConflictsResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, err := scheduleConflicts(ctx, userID, params.ConferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve schedule conflicts: %w", err)
+	}
+
+	return &GetScheduleConflictsResponse{Conflicts: conflicts}, nil
+}
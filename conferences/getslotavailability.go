@@ -0,0 +1,31 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetSlotAvailabilityParams defines the inputs used by the GetSlotAvailability API method
+type GetSlotAvailabilityParams struct {
+	ConferenceID uint32
+	// IncludeClosed also returns the slots whose sale is over.
+	IncludeClosed bool
+}
+
+// GetSlotAvailabilityResponse defines the output returned by the GetSlotAvailability API method
+type GetSlotAvailabilityResponse struct {
+	Slots []SlotAvailability
+}
+
+// GetSlotAvailability retrieves the slots of a conference on the tickets page, with how many are
+// left and whether they are on sale, the counts can be a few seconds old
+// encore:api public
+func GetSlotAvailability(ctx context.Context, params *GetSlotAvailabilityParams) (*GetSlotAvailabilityResponse, error) {
+	slots, err := slotAvailability(ctx, params.ConferenceID, params.IncludeClosed, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve slot availability: %w", err)
+	}
+
+	return &GetSlotAvailabilityResponse{Slots: slots}, nil
+}
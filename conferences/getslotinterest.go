@@ -0,0 +1,33 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetSlotInterestParams defines the inputs used by the GetSlotInterest API method
+type GetSlotInterestParams struct {
+	ConferenceID uint32
+}
+
+// GetSlotInterestResponse defines the output returned by the GetSlotInterest API method
+type GetSlotInterestResponse struct {
+	Slots []SlotInterest
+}
+
+// GetSlotInterest retrieves how many attendees bookmarked or claimed each slot of a conference
+// compared to the capacity of its location, only organizers can see it
+// encore:api auth
+func GetSlotInterest(ctx context.Context, params *GetSlotInterestParams) (*GetSlotInterestResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := slotInterest(ctx, userID, params.ConferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve slot interest: %w", err)
+	}
+
+	return &GetSlotInterestResponse{Slots: slots}, nil
+}
@@ -0,0 +1,87 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetSpeakerFeedbackParams defines the inputs used by the GetSpeakerFeedback API method
+type GetSpeakerFeedbackParams struct {
+	ConferenceID uint32
+}
+
+// GetSpeakerFeedbackResponse defines the output returned by the GetSpeakerFeedback API method
+type GetSpeakerFeedbackResponse struct {
+	Speakers []SpeakerFeedback
+}
+
+// GetSpeakerFeedback retrieves the ratings and comments given to the talks of every speaker of a
+// conference, only organizers can see it
+// encore:api auth
+func GetSpeakerFeedback(ctx context.Context, params *GetSpeakerFeedbackParams) (*GetSpeakerFeedbackResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	speakers, err := speakerFeedback(ctx, userID, params.ConferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve speaker feedback: %w", err)
+	}
+
+	return &GetSpeakerFeedbackResponse{Speakers: speakers}, nil
+}
+
+// GetMyFeedbackParams defines the inputs used by the GetMyFeedback API method
+type GetMyFeedbackParams struct {
+	ConferenceID uint32
+}
+
+// GetMyFeedbackResponse defines the output returned by the GetMyFeedback API method
+type GetMyFeedbackResponse struct {
+	Feedback *SpeakerFeedback
+}
+
+// GetMyFeedback retrieves the ratings and comments attendees gave to the talks of the speaker
+// at a conference, without telling who gave them
+// encore:api auth
+func GetMyFeedback(ctx context.Context, params *GetMyFeedbackParams) (*GetMyFeedbackResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	feedback, err := mySpeakerFeedback(ctx, userID, params.ConferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve feedback: %w", err)
+	}
+
+	return &GetMyFeedbackResponse{Feedback: feedback}, nil
+}
+
+// GetSessionFeedbackParams defines the inputs used by the GetSessionFeedback API method
+type GetSessionFeedbackParams struct {
+	ConferenceID uint32
+}
+
+// GetSessionFeedbackResponse defines the output returned by the GetSessionFeedback API method
+type GetSessionFeedbackResponse struct {
+	Slots []SlotFeedback
+}
+
+// GetSessionFeedback retrieves the ratings and comments given to slots of a conference as a
+// whole rather than to one of their talks, only organizers can see it
+// encore:api auth
+func GetSessionFeedback(ctx context.Context, params *GetSessionFeedbackParams) (*GetSessionFeedbackResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := sessionFeedback(ctx, userID, params.ConferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve session feedback: %w", err)
+	}
+
+	return &GetSessionFeedbackResponse{Slots: slots}, nil
+}
@@ -0,0 +1,61 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetSpeakerLogisticsParams defines the inputs used by the GetSpeakerLogistics API method
+type GetSpeakerLogisticsParams struct {
+	ConferenceID uint32
+}
+
+// GetSpeakerLogisticsResponse defines the output returned by the GetSpeakerLogistics API method
+type GetSpeakerLogisticsResponse struct {
+	Speakers []SpeakerLogistics
+}
+
+// GetSpeakerLogistics retrieves the confirmation status, travel needs and money owed to every
+// speaker of a conference, only organizers can see it
+// encore:api auth
+func GetSpeakerLogistics(ctx context.Context, params *GetSpeakerLogisticsParams) (*GetSpeakerLogisticsResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	speakers, err := speakerLogistics(ctx, userID, params.ConferenceID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve speaker logistics: %w", err)
+	}
+
+	return &GetSpeakerLogisticsResponse{Speakers: speakers}, nil
+}
+
+// GetMySpeakerLogisticsParams defines the inputs used by the GetMySpeakerLogistics API method
+type GetMySpeakerLogisticsParams struct {
+	ConferenceID uint32
+}
+
+// GetMySpeakerLogisticsResponse defines the output returned by the GetMySpeakerLogistics API method
+type GetMySpeakerLogisticsResponse struct {
+	Logistics *SpeakerLogistics
+}
+
+// GetMySpeakerLogistics retrieves the confirmation status, travel needs and money owed to the
+// speaker for a conference
+// encore:api auth
+func GetMySpeakerLogistics(ctx context.Context, params *GetMySpeakerLogisticsParams) (*GetMySpeakerLogisticsResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	logistics, err := mySpeakerLogistics(ctx, userID, params.ConferenceID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve speaker logistics: %w", err)
+	}
+
+	return &GetMySpeakerLogisticsResponse{Logistics: logistics}, nil
+}
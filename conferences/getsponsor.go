@@ -0,0 +1,32 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetSponsorParams defines the inputs used by the GetSponsor API method
+type GetSponsorParams struct {
+	SponsorID uint32
+}
+
+// GetSponsorResponse defines the output returned by the GetSponsor API method
+type GetSponsorResponse struct {
+	Sponsor *Sponsor
+}
+
+// GetSponsor retrieves a sponsor with its address, website and contacts, only organizers can do so
+// encore:api auth
+func GetSponsor(ctx context.Context, params *GetSponsorParams) (*GetSponsorResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	sponsor, err := sponsorWithContacts(ctx, userID, params.SponsorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve sponsor: %w", err)
+	}
+
+	return &GetSponsorResponse{Sponsor: sponsor}, nil
+}
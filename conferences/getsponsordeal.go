@@ -0,0 +1,67 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetSponsorDealParams defines the inputs used by the GetSponsorDeal API method
+type GetSponsorDealParams struct {
+	SponsorID uint32
+}
+
+// GetSponsorDealResponse defines the output returned by the GetSponsorDeal API method
+type GetSponsorDealResponse struct {
+	Deal *SponsorDeal
+}
+
+// GetSponsorDeal retrieves the contract with a sponsor, its invoices and their payments, only
+// organizers can do so
+// encore:api auth
+func GetSponsorDeal(ctx context.Context, params *GetSponsorDealParams) (*GetSponsorDealResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	deal, err := sponsorDeal(ctx, userID, params.SponsorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve sponsor deal: %w", err)
+	}
+
+	return &GetSponsorDealResponse{Deal: deal}, nil
+}
+
+// ListSponsorPipelineParams defines the inputs used by the ListSponsorPipeline API method
+type ListSponsorPipelineParams struct {
+	ConferenceID uint32
+	Filter       SponsorFilter
+	// Page sorts by tier by default, or by name or id.
+	Page Page
+}
+
+// ListSponsorPipelineResponse defines the output returned by the ListSponsorPipeline API method
+type ListSponsorPipelineResponse struct {
+	Sponsors []Sponsor
+	Page     PageInfo
+}
+
+// ListSponsorPipeline retrieves the sponsors of a conference at every stage of their deal, one page
+// at a time. Only organizers can do so
+// encore:api auth
+func ListSponsorPipeline(ctx context.Context, params *ListSponsorPipelineParams) (*ListSponsorPipelineResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	sponsors, page, err := sponsorPipeline(ctx, userID, params.ConferenceID, params.Filter, params.Page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve sponsor pipeline: %w", err)
+	}
+
+	return &ListSponsorPipelineResponse{
+		Sponsors: sponsors,
+		Page:     page,
+	}, nil
+}
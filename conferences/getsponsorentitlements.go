@@ -0,0 +1,33 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetSponsorEntitlementsParams defines the inputs used by the GetSponsorEntitlements API method
+type GetSponsorEntitlementsParams struct {
+	SponsorID uint32
+}
+
+// GetSponsorEntitlementsResponse defines the output returned by the GetSponsorEntitlements API method
+type GetSponsorEntitlementsResponse struct {
+	Entitlements []EntitlementUsage
+}
+
+// GetSponsorEntitlements retrieves how much of each benefit of its package a sponsor has used, only
+// organizers can do so
+// encore:api auth
+func GetSponsorEntitlements(ctx context.Context, params *GetSponsorEntitlementsParams) (*GetSponsorEntitlementsResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := sponsorEntitlements(ctx, userID, params.SponsorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve sponsor entitlements: %w", err)
+	}
+
+	return &GetSponsorEntitlementsResponse{Entitlements: usage}, nil
+}
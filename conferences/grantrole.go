@@ -0,0 +1,58 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// GrantRoleParams defines the inputs used by the GrantRole and RevokeRole API methods
+type GrantRoleParams struct {
+	UserID uint32
+	Role   Role
+}
+
+// GrantRole grants a role to a user, only organizers can do so
+// encore:api auth
+func GrantRole(ctx context.Context, params *GrantRoleParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := grantUserRole(ctx, userID, params.UserID, params.Role); err != nil {
+		return fmt.Errorf("failed to grant role: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeRole takes a role away from a user, only organizers can do so
+// encore:api auth
+func RevokeRole(ctx context.Context, params *GrantRoleParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := revokeUserRole(ctx, userID, params.UserID, params.Role); err != nil {
+		return fmt.Errorf("failed to revoke role: %w", err)
+	}
+
+	return nil
+}
+
+// BootstrapOrganizer makes the authenticated user the first organizer of a new deployment, only
+// the user with the configured email can do so and only while there is no organizer
+// encore:api auth
+func BootstrapOrganizer(ctx context.Context) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := bootstrapOrganizer(ctx, userID); err != nil {
+		return fmt.Errorf("failed to bootstrap organizer: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package conferences
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	calendarProductID = "-//GopherAcademy//Showrunner//EN"
+	// calendarUIDDomain makes event UIDs globally unique as RFC 5545 asks.
+	calendarUIDDomain  = "showrunner.gopheracademy.com"
+	calendarTimeLayout = "20060102T150405Z"
+	// calendarLineLength is the longest a content line can be in octets, without its CRLF.
+	calendarLineLength = 75
+)
+
+// calendarEvent is a VEVENT, UID must not change between feeds so calendar apps update the event
+// instead of adding a new one.
+type calendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+}
+
+// slotEventUID returns the UID of the event for a conference slot.
+func slotEventUID(slotID uint32) string {
+	return fmt.Sprintf("slot-%d@%s", slotID, calendarUIDDomain)
+}
+
+// talkEventUID returns the UID of the event for a scheduled paper, it follows the paper when it
+// moves around the schedule.
+func talkEventUID(paperID uint32) string {
+	return fmt.Sprintf("talk-%d@%s", paperID, calendarUIDDomain)
+}
+
+// escapeCalendarText escapes a TEXT value as defined in RFC 5545 section 3.3.11.
+func escapeCalendarText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// foldCalendarLine splits a content line in lines of at most calendarLineLength octets, never in
+// the middle of a UTF-8 sequence.
+func foldCalendarLine(line string) string {
+	var b strings.Builder
+	limit := calendarLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines start with a space that counts towards the limit.
+		limit = calendarLineLength - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+// writeCalendar returns an RFC 5545 calendar with the passed events sorted by start, stamped at now.
+func writeCalendar(name string, events []calendarEvent, now time.Time) string {
+	sorted := make([]calendarEvent, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var b strings.Builder
+	line := func(l string) { b.WriteString(foldCalendarLine(l)) }
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:" + calendarProductID)
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escapeCalendarText(name))
+	for _, e := range sorted {
+		line("BEGIN:VEVENT")
+		line("UID:" + e.UID)
+		line("DTSTAMP:" + now.UTC().Format(calendarTimeLayout))
+		line("DTSTART:" + e.Start.UTC().Format(calendarTimeLayout))
+		line("DTEND:" + e.End.UTC().Format(calendarTimeLayout))
+		line("SUMMARY:" + escapeCalendarText(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION:" + escapeCalendarText(e.Description))
+		}
+		if e.Location != "" {
+			line("LOCATION:" + escapeCalendarText(e.Location))
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return b.String()
+}
+
+// calendarLocation describes where an event happens for calendar apps.
+func calendarLocation(l Location) string {
+	if l.Address == "" {
+		return l.Name
+	}
+	if l.Name == "" {
+		return l.Address
+	}
+	return l.Name + ", " + l.Address
+}
+
+// talkEvent returns the calendar event of a scheduled talk.
+func talkEvent(t ScheduledTalk) calendarEvent {
+	names := make([]string, 0, len(t.Speakers))
+	for _, s := range t.Speakers {
+		names = append(names, strings.TrimSpace(s.GivenName+" "+s.FamilyName))
+	}
+	description := t.Talk.ElevatorPitch
+	if len(names) > 0 {
+		description = strings.Join(names, ", ") + "\n\n" + description
+	}
+	return calendarEvent{
+		UID:         talkEventUID(t.Talk.PaperID),
+		Summary:     t.Talk.Title,
+		Description: description,
+		Location:    calendarLocation(t.Location),
+		Start:       t.StartsAt,
+		End:         t.EndsAt,
+	}
+}
+
+// slotEvent returns the calendar event of a conference slot.
+func slotEvent(s ConferenceSlot) calendarEvent {
+	return calendarEvent{
+		UID:         slotEventUID(s.ID),
+		Summary:     s.Name,
+		Description: s.Description,
+		Location:    calendarLocation(s.Location),
+		Start:       s.StartDate,
+		End:         s.EndDate,
+	}
+}
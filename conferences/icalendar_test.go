@@ -0,0 +1,49 @@
+package conferences
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteCalendar(t *testing.T) {
+	start := time.Date(2021, 11, 10, 9, 0, 0, 0, time.FixedZone("EST", -5*3600))
+	events := []calendarEvent{
+		{UID: talkEventUID(2), Summary: "Later, talk", Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)},
+		{
+			UID:         slotEventUID(1),
+			Summary:     "Workshop; hands on",
+			Description: strings.Repeat("é", 60) + "\nBring a laptop",
+			Location:    "The Den, GopherTown",
+			Start:       start,
+			End:         start.Add(time.Hour),
+		},
+	}
+
+	calendar := writeCalendar("GopherCon", events, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	if !strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n") || !strings.HasSuffix(calendar, "END:VCALENDAR\r\n") {
+		t.Fatalf("malformed calendar %q", calendar)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(calendar, "\r\n"), "\r\n") {
+		if len(line) > calendarLineLength {
+			t.Errorf("line longer than %d octets: %q", calendarLineLength, line)
+		}
+	}
+	unfolded := strings.ReplaceAll(calendar, "\r\n ", "")
+	for _, want := range []string{
+		"UID:slot-1@" + calendarUIDDomain,
+		"DTSTART:20211110T140000Z",
+		"SUMMARY:Workshop\\; hands on",
+		"DESCRIPTION:" + strings.Repeat("é", 60) + "\\nBring a laptop",
+		"LOCATION:The Den\\, GopherTown",
+		"SUMMARY:Later\\, talk",
+	} {
+		if !strings.Contains(unfolded, want+"\r\n") {
+			t.Errorf("calendar is missing %q", want)
+		}
+	}
+	if strings.Index(unfolded, "slot-1@") > strings.Index(unfolded, "talk-2@") {
+		t.Errorf("events are not sorted by start")
+	}
+}
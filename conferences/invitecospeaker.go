@@ -0,0 +1,49 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// InviteCoSpeakerParams defines the inputs used by the InviteCoSpeaker API method
+type InviteCoSpeakerParams struct {
+	PaperID uint32
+	Email   string
+}
+
+// InviteCoSpeaker invites someone by email to co-present a paper, only the speaker who submitted it
+// and organizers can invite co-speakers
+// encore:api auth
+func InviteCoSpeaker(ctx context.Context, params *InviteCoSpeakerParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := inviteCoSpeaker(ctx, userID, params.PaperID, params.Email); err != nil {
+		return fmt.Errorf("failed to invite co-speaker: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveCoSpeakerParams defines the inputs used by the RemoveCoSpeaker API method
+type RemoveCoSpeakerParams struct {
+	PaperID uint32
+	Email   string
+}
+
+// RemoveCoSpeaker removes a co-speaker, or a pending invitation, from a paper
+// encore:api auth
+func RemoveCoSpeaker(ctx context.Context, params *RemoveCoSpeakerParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := removeCoSpeaker(ctx, userID, params.PaperID, params.Email); err != nil {
+		return fmt.Errorf("failed to remove co-speaker: %w", err)
+	}
+
+	return nil
+}
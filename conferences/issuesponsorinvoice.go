@@ -0,0 +1,66 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// IssueSponsorInvoiceParams defines the inputs used by the IssueSponsorInvoice API method
+type IssueSponsorInvoiceParams struct {
+	SponsorID     uint32
+	InstallmentID uint32
+}
+
+// IssueSponsorInvoiceResponse defines the output returned by the IssueSponsorInvoice API method
+type IssueSponsorInvoiceResponse struct {
+	Invoice *SponsorInvoice
+}
+
+// IssueSponsorInvoice invoices an installment of a signed contract to the billing contact of the
+// sponsor, only organizers can do so
+// encore:api auth
+func IssueSponsorInvoice(ctx context.Context, params *IssueSponsorInvoiceParams) (*IssueSponsorInvoiceResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := issueSponsorInvoice(ctx, userID, params.SponsorID, params.InstallmentID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue sponsor invoice: %w", err)
+	}
+
+	return &IssueSponsorInvoiceResponse{Invoice: invoice}, nil
+}
+
+// RecordSponsorPaymentParams defines the inputs used by the RecordSponsorPayment API method
+type RecordSponsorPaymentParams struct {
+	// Payment is received now if its ReceivedAt is zero.
+	Payment *SponsorPayment
+}
+
+// RecordSponsorPaymentResponse defines the output returned by the RecordSponsorPayment API method
+type RecordSponsorPaymentResponse struct {
+	Invoice *SponsorInvoice
+}
+
+// RecordSponsorPayment adds a payment to the ledger of a sponsor invoice, only organizers can do so
+// encore:api auth
+func RecordSponsorPayment(ctx context.Context, params *RecordSponsorPaymentParams) (*RecordSponsorPaymentResponse, error) {
+	if params.Payment == nil {
+		return nil, fmt.Errorf("Payment is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := recordSponsorPayment(ctx, userID, params.Payment, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to record sponsor payment: %w", err)
+	}
+
+	return &RecordSponsorPaymentResponse{Invoice: invoice}, nil
+}
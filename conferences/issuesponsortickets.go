@@ -0,0 +1,60 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// IssueSponsorTicketsParams defines the inputs used by the IssueSponsorTickets API method
+type IssueSponsorTicketsParams struct {
+	SponsorID     uint32
+	EntitlementID uint32
+	// Emails are the attendees to issue a ticket to, one each. Attendees are created for the emails
+	// that are not known yet.
+	Emails []string
+}
+
+// IssueSponsorTicketsResponse defines the output returned by the IssueSponsorTickets API method
+type IssueSponsorTicketsResponse struct {
+	Claims []SlotClaim
+}
+
+// IssueSponsorTickets issues complimentary tickets of a sponsor's package, only organizers can do so
+// encore:api auth
+func IssueSponsorTickets(ctx context.Context, params *IssueSponsorTicketsParams) (*IssueSponsorTicketsResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := issueSponsorTickets(ctx, userID, params.SponsorID, params.EntitlementID, params.Emails)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue sponsor tickets: %w", err)
+	}
+
+	return &IssueSponsorTicketsResponse{Claims: claims}, nil
+}
+
+// UseSponsorEntitlementParams defines the inputs used by the UseSponsorEntitlement API method
+type UseSponsorEntitlementParams struct {
+	SponsorID     uint32
+	EntitlementID uint32
+	// Note tells what was given, such as the number of the booth.
+	Note string
+}
+
+// UseSponsorEntitlement records that a sponsor got one of a benefit other than tickets, only
+// organizers can do so
+// encore:api auth
+func UseSponsorEntitlement(ctx context.Context, params *UseSponsorEntitlementParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := useEntitlement(ctx, userID, params.SponsorID, params.EntitlementID, params.Note); err != nil {
+		return fmt.Errorf("failed to use sponsor entitlement: %w", err)
+	}
+
+	return nil
+}
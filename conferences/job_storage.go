@@ -0,0 +1,79 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+
+	"encore.dev/storage/sqldb"
+)
+
+// JobFilter narrows down the jobs returned by ListJobs and ListApprovedJobs, zero fields do not filter.
+type JobFilter struct {
+	// CompanyName matches regardless of case.
+	CompanyName string
+}
+
+// jobSortColumns are the fields jobs can be sorted by.
+var jobSortColumns = map[string]sortColumn{
+	"rank":    {expr: "rank", sqlType: "INT"},
+	"company": {expr: "company_name", sqlType: "TEXT"},
+	"title":   {expr: "title", sqlType: "TEXT"},
+	"id":      {expr: "id", sqlType: "INT"},
+}
+
+const jobColumns = `id, company_name, title, description, link, discord, rank, COALESCE(approved, FALSE),
+	COALESCE(sponsor_id, 0)`
+
+// scanJob scans a row selected with jobColumns.
+func scanJob(scan func(dest ...interface{}) error) (*Job, error) {
+	var job Job
+	err := scan(&job.ID,
+		&job.CompanyName,
+		&job.Title,
+		&job.Description,
+		&job.Link,
+		&job.Discord,
+		&job.Rank,
+		&job.Approved,
+		&job.SponsorID)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// readJobs returns a page of the jobs matching the filter, only approved ones if approvedOnly is set.
+func readJobs(ctx context.Context, approvedOnly bool, filter JobFilter, page Page) ([]Job, PageInfo, error) {
+	q, err := newPageQuery(page, "id", jobSortColumns, "rank")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	condition, pageArgs := q.condition(3)
+	rows, err := sqldb.Query(ctx, `SELECT `+jobColumns+`, `+q.keyColumn()+` FROM job_board
+	WHERE ($1 = FALSE OR approved = TRUE)
+	AND ($2 = '' OR LOWER(company_name) = LOWER($2))
+	AND `+condition+` `+q.orderBy(),
+		append([]interface{}{approvedOnly, filter.CompanyName}, pageArgs...)...)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("querying jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []Job{}
+	keys := []string{}
+	ids := []uint32{}
+	for rows.Next() {
+		var key string
+		job, err := scanJob(func(dest ...interface{}) error {
+			return rows.Scan(append(dest, &key)...)
+		})
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("scanning job: %w", err)
+		}
+		jobs = append(jobs, *job)
+		keys = append(keys, key)
+		ids = append(ids, job.ID)
+	}
+	n, info := q.pageInfo(keys, ids)
+	return jobs[:n], info, nil
+}
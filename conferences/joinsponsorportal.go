@@ -0,0 +1,55 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// JoinSponsorPortalParams defines the inputs used by the JoinSponsorPortal API method
+type JoinSponsorPortalParams struct {
+	// Token is the invite an organizer emailed to the contact.
+	Token string
+}
+
+// JoinSponsorPortalResponse defines the output returned by the JoinSponsorPortal API method
+type JoinSponsorPortalResponse struct {
+	SponsorID uint32
+}
+
+// JoinSponsorPortal links the authenticated user to the contact of a sponsor the invite was
+// emailed to so they can manage the sponsor
+// encore:api auth
+func JoinSponsorPortal(ctx context.Context, params *JoinSponsorPortalParams) (*JoinSponsorPortalResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	sponsorID, err := joinSponsorPortal(ctx, userID, params.Token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join sponsor portal: %w", err)
+	}
+
+	return &JoinSponsorPortalResponse{SponsorID: sponsorID}, nil
+}
+
+// ListMySponsorsResponse defines the output returned by the ListMySponsors API method
+type ListMySponsorsResponse struct {
+	Sponsors []Sponsor
+}
+
+// ListMySponsors retrieves the sponsors the authenticated user joined the portal of
+// encore:api auth
+func ListMySponsors(ctx context.Context) (*ListMySponsorsResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	sponsors, err := readSponsorsByContact(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve sponsors: %w", err)
+	}
+
+	return &ListMySponsorsResponse{Sponsors: sponsors}, nil
+}
@@ -3,69 +3,33 @@ package conferences
 import (
 	"context"
 	"fmt"
-
-	"encore.dev/storage/sqldb"
 )
 
 // ListApprovedJobsParams defines the inputs used by
-// the ListJobs API method
+// the ListApprovedJobs API method
 type ListApprovedJobsParams struct {
+	Filter JobFilter
+	// Page sorts by rank by default, or by company, title or id.
+	Page Page
 }
 
 // ListApprovedJobsResponse defines the output returned
 // by the ListApprovedJobs API method
 type ListApprovedJobsResponse struct {
 	Jobs []Job
+	Page PageInfo
 }
 
-// ListApprovedJobs retrieves all jobs (approved or not) from
-// the job_board table
+// ListApprovedJobs retrieves the approved jobs from
+// the job_board table, one page at a time
 // encore:api public
-func ListApprovedJobs(ctx context.Context) (*ListApprovedJobsResponse, error) {
+func ListApprovedJobs(ctx context.Context, params *ListApprovedJobsParams) (*ListApprovedJobsResponse, error) {
 
-	rows, err := sqldb.Query(ctx,
-		`
-		SELECT id,
-			company_name,
-			title,
-			description,
-			link,
-			discord,
-			rank,
-			approved
-		FROM job_board
-		WHERE approved = true
-		ORDER BY rank
-`)
+	jobs, page, err := readJobs(ctx, true, params.Filter, params.Page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve all jobs: %w", err)
 	}
 
-	defer rows.Close()
-
-	var jobs []Job
-
-	for rows.Next() {
-
-		var job Job
-
-		err := rows.Scan(
-			&job.ID,
-			&job.CompanyName,
-			&job.Title,
-			&job.Description,
-			&job.Link,
-			&job.Discord,
-			&job.Rank,
-			&job.Approved,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan rows: %w", err)
-		}
-
-		jobs = append(jobs, job)
-	}
-
-	return &ListApprovedJobsResponse{Jobs: jobs}, nil
+	return &ListApprovedJobsResponse{Jobs: jobs, Page: page}, nil
 
 }
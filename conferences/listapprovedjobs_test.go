@@ -9,7 +9,7 @@ func TestListApprovedJobs(t *testing.T) {
 
 	t.Run("expects a known approved job to be returned", func(t *testing.T) {
 		ctx := context.Background()
-		result, err := ListApprovedJobs(ctx)
+		result, err := ListApprovedJobs(ctx, &ListApprovedJobsParams{})
 
 		if err != nil {
 			t.Fatalf("failed to retrieve jobs: %v", err)
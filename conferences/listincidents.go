@@ -0,0 +1,41 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListIncidentsParams defines the inputs used by the ListIncidents API method
+type ListIncidentsParams struct {
+	ConferenceID uint32
+	// Status filters by status when set.
+	Status IncidentStatus
+}
+
+// ListIncidentsResponse defines the output returned by the ListIncidents API method
+type ListIncidentsResponse struct {
+	Incidents []Incident
+}
+
+// ListIncidents retrieves the incidents reported for a conference, only the CoC team can see them
+// encore:api auth
+func ListIncidents(ctx context.Context, params *ListIncidentsParams) (*ListIncidentsResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRole(ctx, userID, RoleCoCTeam); err != nil {
+		return nil, err
+	}
+
+	if params.Status != "" && !params.Status.valid() {
+		return nil, fmt.Errorf("invalid incident status %q", params.Status)
+	}
+
+	incidents, err := listIncidents(ctx, params.ConferenceID, params.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve incidents: %w", err)
+	}
+
+	return &ListIncidentsResponse{Incidents: incidents}, nil
+}
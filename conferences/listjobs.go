@@ -3,68 +3,33 @@ package conferences
 import (
 	"context"
 	"fmt"
-
-	"encore.dev/storage/sqldb"
 )
 
 // ListJobsParams defines the inputs used by
 // the ListJobs API method
 type ListJobsParams struct {
+	Filter JobFilter
+	// Page sorts by rank by default, or by company, title or id.
+	Page Page
 }
 
 // ListJobsResponse defines the output returned
 // by the ListJobs API method
 type ListJobsResponse struct {
 	Jobs []Job
+	Page PageInfo
 }
 
 // ListJobs retrieves all jobs (approved or not) from
-// the job_board table
+// the job_board table, one page at a time
 // encore:api public
-func ListJobs(ctx context.Context) (*ListJobsResponse, error) {
+func ListJobs(ctx context.Context, params *ListJobsParams) (*ListJobsResponse, error) {
 
-	rows, err := sqldb.Query(ctx,
-		`
-		SELECT id,
-			company_name,
-			title,
-			description,
-			link,
-			discord,
-			rank,
-			approved
-		FROM job_board
-		ORDER BY rank
-`)
+	jobs, page, err := readJobs(ctx, false, params.Filter, params.Page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve all jobs: %w", err)
 	}
 
-	defer rows.Close()
-
-	var jobs []Job
-
-	for rows.Next() {
-
-		var job Job
-
-		err := rows.Scan(
-			&job.ID,
-			&job.CompanyName,
-			&job.Title,
-			&job.Description,
-			&job.Link,
-			&job.Discord,
-			&job.Rank,
-			&job.Approved,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan rows: %w", err)
-		}
-
-		jobs = append(jobs, job)
-	}
-
-	return &ListJobsResponse{Jobs: jobs}, nil
+	return &ListJobsResponse{Jobs: jobs, Page: page}, nil
 
 }
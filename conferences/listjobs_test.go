@@ -0,0 +1,65 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+)
+
+func TestListJobsPagination(t *testing.T) {
+	ctx := context.Background()
+	company := "Paginated Gophers"
+	for rank := 1; rank <= 3; rank++ {
+		_, err := CreateJob(ctx, &CreateJobParams{Job: &Job{
+			CompanyName: company,
+			Title:       "Gopher",
+			Description: "Turns pages",
+			Link:        "gophers.example/jobs",
+			Discord:     "https://discord.gg/gophers",
+			Rank:        rank,
+		}})
+		if err != nil {
+			t.Fatalf("failed to create job: %v", err)
+		}
+	}
+
+	filter := JobFilter{CompanyName: "paginated gophers"}
+	first, err := ListJobs(ctx, &ListJobsParams{Filter: filter, Page: Page{Limit: 2}})
+	if err != nil {
+		t.Fatalf("failed to list jobs: %v", err)
+	}
+	if len(first.Jobs) != 2 || first.Jobs[0].Rank != 1 || first.Jobs[1].Rank != 2 {
+		t.Fatalf("unexpected first page %+v", first.Jobs)
+	}
+	if first.Page.NextCursor == "" {
+		t.Fatalf("expected a cursor to the next page")
+	}
+
+	// Jobs added before the cursor do not shift the next page.
+	_, err = CreateJob(ctx, &CreateJobParams{Job: &Job{
+		CompanyName: company,
+		Title:       "Gopher",
+		Description: "Turns pages",
+		Link:        "gophers.example/jobs",
+		Discord:     "https://discord.gg/gophers",
+		Rank:        0,
+	}})
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+
+	next, err := ListJobs(ctx, &ListJobsParams{Filter: filter, Page: Page{Limit: 2, Cursor: first.Page.NextCursor}})
+	if err != nil {
+		t.Fatalf("failed to list jobs: %v", err)
+	}
+	if len(next.Jobs) != 1 || next.Jobs[0].Rank != 3 {
+		t.Errorf("unexpected next page %+v", next.Jobs)
+	}
+	if next.Page.NextCursor != "" {
+		t.Errorf("expected the last page to have no cursor")
+	}
+
+	_, err = ListJobs(ctx, &ListJobsParams{Filter: filter, Page: Page{Cursor: first.Page.NextCursor, SortBy: "title"}})
+	if err == nil {
+		t.Errorf("expected a cursor used with another sort to fail")
+	}
+}
@@ -0,0 +1,36 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListMyPapersResponse defines the output returned by the ListMyPapers API method
+type ListMyPapersResponse struct {
+	Papers []Paper
+}
+
+// ListMyPapers retrieves all the papers submitted by the authenticated user followed by those they
+// accepted to co-present
+// encore:api auth
+func ListMyPapers(ctx context.Context) (*ListMyPapersResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	papers, err := readPapersByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve papers: %w", err)
+	}
+	coPresented, err := readPapersByCoSpeaker(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve co-presented papers: %w", err)
+	}
+	papers = append(papers, coPresented...)
+	if err := attachCoSpeakers(ctx, papers); err != nil {
+		return nil, fmt.Errorf("failed to retrieve co-speakers: %w", err)
+	}
+
+	return &ListMyPapersResponse{Papers: papers}, nil
+}
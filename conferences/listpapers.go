@@ -3,61 +3,50 @@ package conferences
 import (
 	"context"
 	"fmt"
-
-	"encore.dev/storage/sqldb"
 )
 
 // ListPapersParams defines the inputs used by the ListPapers API method
 type ListPapersParams struct {
-	ConferenceID uint32
+	ConferenceID  uint32
+	Format        TalkFormat
+	AudienceLevel AudienceLevel
+	Track         string
+	Status        PaperStatus
+	// Page sorts by id by default, or by title.
+	Page Page
 }
 
 // ListPapersResponse defines the output returned by the ListPapers API method
 type ListPapersResponse struct {
 	Papers []Paper
+	Page   PageInfo
 }
 
-// ListPapers retrieves all the papers submitted for a specific conference
-// encore:api public
+// ListPapers retrieves the papers submitted for a specific conference optionally filtered by format,
+// audience level, track and status one page at a time, only organizers can list them
+// encore:api auth
 func ListPapers(ctx context.Context, params *ListPapersParams) (*ListPapersResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
 
-	rows, err := sqldb.Query(ctx,
-		` SELECT id,
-			user_id,
-			conference_id,
-			title,
-			elevator_pitch,
-			description,
-			notes
-			FROM paper_submission
-`)
+	papers, page, err := readPapersByFilter(ctx, PaperFilter{
+		ConferenceID:  params.ConferenceID,
+		Format:        params.Format,
+		AudienceLevel: params.AudienceLevel,
+		Track:         params.Track,
+		Status:        params.Status,
+	}, params.Page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve all papers: %w", err)
 	}
-
-	defer rows.Close()
-
-	var papers []Paper
-
-	for rows.Next() {
-
-		var paper Paper
-
-		err := rows.Scan(
-			&paper.ID,
-			&paper.UserID,
-			&paper.ConferenceID,
-			&paper.Title,
-			&paper.ElevatorPitch,
-			&paper.Description,
-			&paper.Notes,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan rows: %w", err)
-		}
-
-		papers = append(papers, paper)
+	if err := attachCoSpeakers(ctx, papers); err != nil {
+		return nil, fmt.Errorf("failed to retrieve co-speakers: %w", err)
 	}
 
-	return &ListPapersResponse{Papers: papers}, nil
+	return &ListPapersResponse{Papers: papers, Page: page}, nil
 }
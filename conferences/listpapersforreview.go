@@ -0,0 +1,33 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListPapersForReviewParams defines the inputs used by the ListPapersForReview API method
+type ListPapersForReviewParams struct {
+	ConferenceID uint32
+}
+
+// ListPapersForReviewResponse defines the output returned by the ListPapersForReview API method
+type ListPapersForReviewResponse struct {
+	Papers []BlindPaper
+}
+
+// ListPapersForReview retrieves the papers of a conference hiding who submitted them until the
+// organizers unblind the review
+// encore:api auth
+func ListPapersForReview(ctx context.Context, params *ListPapersForReviewParams) (*ListPapersForReviewResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	papers, err := papersForReview(ctx, userID, params.ConferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve papers for review: %w", err)
+	}
+
+	return &ListPapersForReviewResponse{Papers: papers}, nil
+}
@@ -0,0 +1,27 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListSpeakersParams defines the inputs used by the ListSpeakers API method
+type ListSpeakersParams struct {
+	ConferenceID uint32
+}
+
+// ListSpeakersResponse defines the output returned by the ListSpeakers API method
+type ListSpeakersResponse struct {
+	Speakers []Speaker
+}
+
+// ListSpeakers retrieves the speakers of the accepted talks of a conference
+// encore:api public
+func ListSpeakers(ctx context.Context, params *ListSpeakersParams) (*ListSpeakersResponse, error) {
+	speakers, err := conferenceSpeakers(ctx, params.ConferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve speakers: %w", err)
+	}
+
+	return &ListSpeakersResponse{Speakers: speakers}, nil
+}
@@ -0,0 +1,28 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListSponsorshipTiersParams defines the inputs used by the ListSponsorshipTiers API method
+type ListSponsorshipTiersParams struct {
+	ConferenceID uint32
+}
+
+// ListSponsorshipTiersResponse defines the output returned by the ListSponsorshipTiers API method
+type ListSponsorshipTiersResponse struct {
+	Tiers []SponsorshipTier
+}
+
+// ListSponsorshipTiers retrieves the tiers a conference offers to sponsors, from the highest
+// encore:api public
+func ListSponsorshipTiers(ctx context.Context, params *ListSponsorshipTiersParams) (*ListSponsorshipTiersResponse, error) {
+
+	tiers, err := readTiersByConference(ctx, params.ConferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve sponsorship tiers: %w", err)
+	}
+
+	return &ListSponsorshipTiersResponse{Tiers: tiers}, nil
+}
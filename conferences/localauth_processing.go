@@ -0,0 +1,341 @@
+package conferences
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"encore.dev/storage/sqldb"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var secrets struct {
+	// SessionSigningKey signs the session tokens issued to users logging in with a password.
+	SessionSigningKey string
+	// SMTPAddress is the host:port of the server emails are sent through, emails are only logged
+	// without it. SMTPUsername and SMTPPassword are only needed if the server requires them.
+	SMTPAddress  string
+	SMTPUsername string
+	SMTPPassword string
+	// MailFrom is the address emails are sent from.
+	MailFrom string
+	// FirstOrganizerEmail is the email of who can make themselves the first organizer of a new
+	// deployment, from then on organizers grant roles to others.
+	FirstOrganizerEmail string
+}
+
+const (
+	// sessionTokenPrefix tells our own session tokens apart from OIDC JWTs.
+	sessionTokenPrefix      = "srs1."
+	sessionTokenLifetime    = 7 * 24 * time.Hour
+	verifyEmailLifetime     = 48 * time.Hour
+	resetPasswordLifetime   = time.Hour
+	minPasswordLength       = 10
+	maxFailedLoginAttempts  = 5
+	failedLoginAttemptsSpan = 15 * time.Minute
+)
+
+// ErrTooManyLoginAttempts is returned when an email has failed to log in too many times recently.
+var ErrTooManyLoginAttempts = errors.New("too many failed login attempts, try again later")
+
+// errInvalidCredentials is deliberately vague so we do not leak which emails are registered.
+var errInvalidCredentials = errors.New("invalid email or password")
+
+// dummyPasswordHash is compared against when there is no user for an email, so logging in takes as
+// long whether the email is registered or not. It has the cost of hashPassword.
+const dummyPasswordHash = "$2a$10$7ewznrTVvvHF75i91kRPXuhCWDeUeH6c8btuvVosEtcN.9U3I1LuS"
+
+// hashPassword returns the bcrypt hash of a password after checking it is acceptable.
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", fmt.Errorf("password must be at least %d characters long", minPasswordLength)
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hashing password: %w", err)
+	}
+	return string(hashed), nil
+}
+
+// newOneTimeToken returns a random token to email to the user and the hash we store for it.
+func newOneTimeToken() (token string, tokenHash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("generating token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashOneTimeToken(token), nil
+}
+
+func hashOneTimeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// signSession returns the signature of a session payload.
+func signSession(payload string) []byte {
+	mac := hmac.New(sha256.New, []byte(secrets.SessionSigningKey))
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// issueSessionToken returns a signed session token for the user valid until expiresAt, or until
+// their session version moves past the passed one.
+func issueSessionToken(userID, version uint32, expiresAt time.Time) (string, error) {
+	if secrets.SessionSigningKey == "" {
+		return "", fmt.Errorf("session signing key is not configured")
+	}
+	payload := fmt.Sprintf("%d.%d.%d", userID, version, expiresAt.Unix())
+	signature := base64.RawURLEncoding.EncodeToString(signSession(payload))
+	return sessionTokenPrefix + payload + "." + signature, nil
+}
+
+// isSessionToken returns true if the token was issued by issueSessionToken.
+func isSessionToken(token string) bool {
+	return strings.HasPrefix(token, sessionTokenPrefix)
+}
+
+// verifySessionToken returns the user the token was issued to and the session version it was
+// issued at if it is valid at the passed time. The version is checked by authenticateSession.
+func verifySessionToken(token string, now time.Time) (uint32, uint32, error) {
+	if secrets.SessionSigningKey == "" {
+		return 0, 0, fmt.Errorf("session signing key is not configured")
+	}
+	parts := strings.Split(strings.TrimPrefix(token, sessionTokenPrefix), ".")
+	if len(parts) != 4 {
+		return 0, 0, fmt.Errorf("malformed session token")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed session token signature: %w", err)
+	}
+	if !hmac.Equal(signature, signSession(strings.Join(parts[:3], "."))) {
+		return 0, 0, fmt.Errorf("invalid session token signature")
+	}
+	userID, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed session token user: %w", err)
+	}
+	version, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed session token version: %w", err)
+	}
+	expiresAt, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed session token expiry: %w", err)
+	}
+	if now.Unix() >= expiresAt {
+		return 0, 0, fmt.Errorf("session token expired")
+	}
+	return uint32(userID), uint32(version), nil
+}
+
+// errSessionRevoked is returned for session tokens of users who reset their password, were
+// flagged or erased since the token was issued.
+var errSessionRevoked = errors.New("session was revoked")
+
+// authenticateSession returns the user of a session token that is valid and was not revoked.
+func authenticateSession(ctx context.Context, token string, now time.Time) (uint32, error) {
+	userID, version, err := verifySessionToken(token, now)
+	if err != nil {
+		return 0, err
+	}
+	current, err := readSessionVersion(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if current == nil || *current != version {
+		return 0, errSessionRevoked
+	}
+	return userID, nil
+}
+
+// registerLocalUser creates a user with a password and emails them a verification token, which
+// is also returned. An email that is already registered gets a notice instead and nil is returned
+// without an error, so registering cannot be used to probe which emails have accounts.
+func registerLocalUser(ctx context.Context, u *User, password string) (*User, string, error) {
+	u.Email = strings.TrimSpace(u.Email)
+	if !strings.Contains(u.Email, "@") {
+		return nil, "", fmt.Errorf("a valid email is required")
+	}
+	if !u.CoCAccepted {
+		return nil, "", fmt.Errorf("the code of conduct must be accepted")
+	}
+	existing, err := readLocalCredentials(ctx, u.Email)
+	if err != nil {
+		return nil, "", err
+	}
+	u.HashedPassword, err = hashPassword(password)
+	if err != nil {
+		return nil, "", err
+	}
+	if existing != nil {
+		err = sendEmail(ctx, Email{
+			To:      u.Email,
+			Subject: "You already have an account",
+			Body:    "Someone tried to register with this email, which already has an account. Log in or reset your password instead.",
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("sending registration notice: %w", err)
+		}
+		return nil, "", nil
+	}
+	token, tokenHash, err := newOneTimeToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("beginning transaction: %w", err)
+	}
+	saved, err := createLocalUser(ctx, tx, u)
+	if err == nil {
+		err = insertAuthToken(ctx, tx, tokenHash, saved.ID, authTokenVerifyEmail, time.Now().Add(verifyEmailLifetime))
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return nil, "", fmt.Errorf("registering user: %w", err)
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return nil, "", fmt.Errorf("committing transaction: %w", err)
+	}
+
+	err = sendEmail(ctx, Email{
+		To:      saved.Email,
+		Subject: "Verify your email",
+		Body:    "Use this code to verify your email: " + token,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("sending verification email: %w", err)
+	}
+	return saved, token, nil
+}
+
+// verifyEmail consumes a verification token and marks the user email as verified.
+func verifyEmail(ctx context.Context, token string) error {
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	userID, err := consumeAuthToken(ctx, tx, hashOneTimeToken(token), authTokenVerifyEmail, time.Now())
+	if err == nil {
+		err = markEmailVerified(ctx, tx, userID)
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return fmt.Errorf("verifying email: %w", err)
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// loginLocalUser checks the password for an email and returns a session token.
+func loginLocalUser(ctx context.Context, email, password string, now time.Time) (string, error) {
+	failed, err := countFailedLoginAttempts(ctx, email, now.Add(-failedLoginAttemptsSpan))
+	if err != nil {
+		return "", err
+	}
+	if failed >= maxFailedLoginAttempts {
+		return "", ErrTooManyLoginAttempts
+	}
+
+	creds, err := readLocalCredentials(ctx, email)
+	if err != nil {
+		return "", err
+	}
+	hashedPassword := dummyPasswordHash
+	if creds != nil {
+		hashedPassword = creds.HashedPassword
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password)) != nil || creds == nil {
+		if err := insertLoginAttempt(ctx, email, false, now); err != nil {
+			return "", err
+		}
+		return "", errInvalidCredentials
+	}
+	if err := insertLoginAttempt(ctx, email, true, now); err != nil {
+		return "", err
+	}
+	if !creds.EmailVerified {
+		return "", fmt.Errorf("email has not been verified yet")
+	}
+
+	return issueSessionToken(creds.UserID, creds.SessionVersion, now.Add(sessionTokenLifetime))
+}
+
+// requestPasswordReset emails a reset token to the user and returns it, unknown emails are
+// silently ignored so they cannot be probed.
+func requestPasswordReset(ctx context.Context, email string) (string, error) {
+	creds, err := readLocalCredentials(ctx, email)
+	if err != nil {
+		return "", err
+	}
+	if creds == nil {
+		return "", nil
+	}
+	token, tokenHash, err := newOneTimeToken()
+	if err != nil {
+		return "", err
+	}
+	if err := insertAuthToken(ctx, nil, tokenHash, creds.UserID, authTokenResetPassword, time.Now().Add(resetPasswordLifetime)); err != nil {
+		return "", err
+	}
+	err = sendEmail(ctx, Email{
+		To:      email,
+		Subject: "Reset your password",
+		Body:    "Use this code to reset your password: " + token,
+	})
+	if err != nil {
+		return "", fmt.Errorf("sending password reset email: %w", err)
+	}
+	return token, nil
+}
+
+// resetPassword consumes a reset token and sets a new password.
+func resetPassword(ctx context.Context, token, newPassword string) error {
+	hashed, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	userID, err := consumeAuthToken(ctx, tx, hashOneTimeToken(token), authTokenResetPassword, time.Now())
+	if err == nil {
+		err = updatePassword(ctx, tx, userID, hashed)
+	}
+	if err == nil {
+		// whoever knew the old password may still hold a session.
+		err = revokeSessions(ctx, tx, userID)
+	}
+	if err == nil {
+		// following the link in the email proves ownership too.
+		err = markEmailVerified(ctx, tx, userID)
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return fmt.Errorf("resetting password: %w", err)
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSessionToken(t *testing.T) {
+	if secrets.SessionSigningKey == "" {
+		secrets.SessionSigningKey = "test-signing-key"
+	}
+	now := time.Now()
+
+	token, err := issueSessionToken(42, 3, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("issuing session token: %v", err)
+	}
+	if !isSessionToken(token) {
+		t.Fatalf("issued token is not recognized as a session token: %v", token)
+	}
+
+	userID, version, err := verifySessionToken(token, now)
+	if err != nil {
+		t.Fatalf("verifying session token: %v", err)
+	}
+	if userID != 42 || version != 3 {
+		t.Errorf("incorrect session token got user %v version %v want %v %v", userID, version, 42, 3)
+	}
+
+	if _, _, err := verifySessionToken(token, now.Add(2*time.Hour)); err == nil {
+		t.Errorf("expired session token did not cause an error")
+	}
+	if _, _, err := verifySessionToken(token[:len(token)-2]+"xx", now); err == nil {
+		t.Errorf("tampered session token did not cause an error")
+	}
+}
+
+func TestLocalAuthentication(t *testing.T) {
+	if secrets.SessionSigningKey == "" {
+		secrets.SessionSigningKey = "test-signing-key"
+	}
+	ctx := context.Background()
+	email := "local@gophercon.com"
+	password := "correct horse battery"
+
+	user, verificationToken, err := registerLocalUser(ctx, &User{Email: email, CoCAccepted: true}, password)
+	assertDatabaseError(t, err)
+	var sessionToken string
+
+	t.Run("notifies a duplicated registration without telling", func(t *testing.T) {
+		sent := &recordingMailer{}
+		mail = sent
+		defer func() { mail = nil }()
+
+		duplicate, _, err := registerLocalUser(ctx, &User{Email: "LOCAL@gophercon.com", CoCAccepted: true}, password)
+		assertDatabaseError(t, err)
+		if duplicate != nil {
+			t.Errorf("duplicated email created a user %+v", duplicate)
+		}
+		if len(sent.emails) != 1 || sent.emails[0].Subject != "You already have an account" {
+			t.Errorf("incorrect emails sent got %+v", sent.emails)
+		}
+	})
+
+	t.Run("requires a verified email to log in", func(t *testing.T) {
+		if _, err := loginLocalUser(ctx, email, password, time.Now()); err == nil {
+			t.Fatalf("unverified email did not cause an error")
+		}
+		assertDatabaseError(t, verifyEmail(ctx, verificationToken))
+
+		token, err := loginLocalUser(ctx, email, password, time.Now())
+		assertDatabaseError(t, err)
+		sessionToken = token
+		userID, err := authenticateSession(ctx, token, time.Now())
+		assertDatabaseError(t, err)
+		if userID != user.ID {
+			t.Errorf("incorrect user logged in got %v want %v", userID, user.ID)
+		}
+	})
+
+	t.Run("resets a password with a one-time token", func(t *testing.T) {
+		newPassword := "staple battery horse"
+		resetToken, err := requestPasswordReset(ctx, email)
+		assertDatabaseError(t, err)
+		assertDatabaseError(t, resetPassword(ctx, resetToken, newPassword))
+
+		if err := resetPassword(ctx, resetToken, "another password here"); err == nil {
+			t.Errorf("reusing a reset token did not cause an error")
+		}
+		if _, err := authenticateSession(ctx, sessionToken, time.Now()); err != errSessionRevoked {
+			t.Errorf("incorrect error for a session from before the reset got %v want %v", err, errSessionRevoked)
+		}
+		if _, err := loginLocalUser(ctx, email, newPassword, time.Now()); err != nil {
+			t.Errorf("login with new password failed: %v", err)
+		}
+	})
+
+	t.Run("rate limits failed logins", func(t *testing.T) {
+		now := time.Now()
+		for i := 0; i < maxFailedLoginAttempts; i++ {
+			if _, err := loginLocalUser(ctx, email, "wrong password", now); err != errInvalidCredentials {
+				t.Fatalf("incorrect error for wrong password got %v want %v", err, errInvalidCredentials)
+			}
+		}
+		if _, err := loginLocalUser(ctx, email, "staple battery horse", now); err != ErrTooManyLoginAttempts {
+			t.Errorf("incorrect error after too many attempts got %v want %v", err, ErrTooManyLoginAttempts)
+		}
+	})
+}
+
+// recordingMailer keeps the emails sent instead of delivering them.
+type recordingMailer struct {
+	emails []Email
+}
+
+func (m *recordingMailer) send(ctx context.Context, email Email) error {
+	m.emails = append(m.emails, email)
+	return nil
+}
@@ -0,0 +1,193 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+// authTokenPurpose is what a one-time token emailed to a user can be used for.
+type authTokenPurpose string
+
+const (
+	authTokenVerifyEmail   authTokenPurpose = "verify_email"
+	authTokenResetPassword authTokenPurpose = "reset_password"
+)
+
+// createLocalUser saves a user that authenticates with a password.
+func createLocalUser(ctx context.Context, tx *sqldb.Tx, u *User) (*User, error) {
+	sqlStatement := `INSERT INTO users (email, coc_accepted, hashed_password, given_name, family_name, created_at)
+	VALUES ($1, $2, $3, $4, $5, NOW())
+	RETURNING id, email, coc_accepted, given_name, family_name, created_at`
+	sqlArgs := []interface{}{u.Email, u.CoCAccepted, u.HashedPassword, u.GivenName, u.FamilyName}
+	var row *sqldb.Row
+
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
+	}
+
+	result := User{}
+	err := row.Scan(&result.ID, &result.Email, &result.CoCAccepted, &result.GivenName, &result.FamilyName, &result.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("creating local user: %w", err)
+	}
+	return &result, nil
+}
+
+// localCredentials are what we need to authenticate a user with a password.
+type localCredentials struct {
+	UserID         uint32
+	HashedPassword string
+	EmailVerified  bool
+	SessionVersion uint32
+}
+
+// readLocalCredentials returns the credentials for the email, nil if there is no local user for it.
+func readLocalCredentials(ctx context.Context, email string) (*localCredentials, error) {
+	row := sqldb.QueryRow(ctx, `SELECT id, hashed_password, email_verified, session_version FROM users
+	WHERE LOWER(email) = LOWER($1) AND hashed_password IS NOT NULL`, email)
+
+	creds := localCredentials{}
+	err := row.Scan(&creds.UserID, &creds.HashedPassword, &creds.EmailVerified, &creds.SessionVersion)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading local credentials: %w", err)
+	}
+	return &creds, nil
+}
+
+// updatePassword replaces the hashed password of a user.
+func updatePassword(ctx context.Context, tx *sqldb.Tx, userID uint32, hashedPassword string) error {
+	sqlStatement := `UPDATE users SET hashed_password = $1 WHERE id = $2`
+	sqlArgs := []interface{}{hashedPassword, userID}
+	var err error
+
+	if tx != nil {
+		_, err = sqldb.ExecTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		_, err = sqldb.Exec(ctx, sqlStatement, sqlArgs...)
+	}
+	if err != nil {
+		return fmt.Errorf("updating password: %w", err)
+	}
+	return nil
+}
+
+// readSessionVersion returns the session version of a user, nil if there is no such user or they
+// were erased.
+func readSessionVersion(ctx context.Context, userID uint32) (*uint32, error) {
+	row := sqldb.QueryRow(ctx, `SELECT session_version FROM users WHERE id = $1 AND erased_at IS NULL`, userID)
+
+	var version uint32
+	err := row.Scan(&version)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading session version: %w", err)
+	}
+	return &version, nil
+}
+
+// revokeSessions invalidates every session token issued to the user so far.
+func revokeSessions(ctx context.Context, tx *sqldb.Tx, userID uint32) error {
+	sqlStatement := `UPDATE users SET session_version = session_version + 1 WHERE id = $1`
+	var err error
+
+	if tx != nil {
+		_, err = sqldb.ExecTx(tx, ctx, sqlStatement, userID)
+	} else {
+		_, err = sqldb.Exec(ctx, sqlStatement, userID)
+	}
+	if err != nil {
+		return fmt.Errorf("revoking sessions: %w", err)
+	}
+	return nil
+}
+
+// markEmailVerified records that the user proved they own their email.
+func markEmailVerified(ctx context.Context, tx *sqldb.Tx, userID uint32) error {
+	sqlStatement := `UPDATE users SET email_verified = TRUE WHERE id = $1`
+	var err error
+
+	if tx != nil {
+		_, err = sqldb.ExecTx(tx, ctx, sqlStatement, userID)
+	} else {
+		_, err = sqldb.Exec(ctx, sqlStatement, userID)
+	}
+	if err != nil {
+		return fmt.Errorf("marking email as verified: %w", err)
+	}
+	return nil
+}
+
+// insertAuthToken saves the hash of a one-time token.
+func insertAuthToken(ctx context.Context, tx *sqldb.Tx, tokenHash string, userID uint32, purpose authTokenPurpose, expiresAt time.Time) error {
+	sqlStatement := `INSERT INTO auth_token (token_hash, user_id, purpose, expires_at) VALUES ($1, $2, $3, $4)`
+	sqlArgs := []interface{}{tokenHash, userID, string(purpose), expiresAt}
+	var err error
+
+	if tx != nil {
+		_, err = sqldb.ExecTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		_, err = sqldb.Exec(ctx, sqlStatement, sqlArgs...)
+	}
+	if err != nil {
+		return fmt.Errorf("saving auth token: %w", err)
+	}
+	return nil
+}
+
+// consumeAuthToken marks a valid, unused token as used and returns the user it belongs to.
+func consumeAuthToken(ctx context.Context, tx *sqldb.Tx, tokenHash string, purpose authTokenPurpose, now time.Time) (uint32, error) {
+	sqlStatement := `UPDATE auth_token SET used_at = $1
+	WHERE token_hash = $2 AND purpose = $3 AND used_at IS NULL AND expires_at > $1
+	RETURNING user_id`
+	sqlArgs := []interface{}{now, tokenHash, string(purpose)}
+	var row *sqldb.Row
+
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
+	}
+
+	var userID uint32
+	err := row.Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("token is invalid or expired")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("consuming auth token: %w", err)
+	}
+	return userID, nil
+}
+
+// insertLoginAttempt records a login attempt for rate limiting.
+func insertLoginAttempt(ctx context.Context, email string, succeeded bool, at time.Time) error {
+	_, err := sqldb.Exec(ctx, `INSERT INTO login_attempt (email, succeeded, attempted_at) VALUES (LOWER($1), $2, $3)`,
+		email, succeeded, at)
+	if err != nil {
+		return fmt.Errorf("saving login attempt: %w", err)
+	}
+	return nil
+}
+
+// countFailedLoginAttempts returns how many failed logins happened for an email since the passed time.
+func countFailedLoginAttempts(ctx context.Context, email string, since time.Time) (int, error) {
+	row := sqldb.QueryRow(ctx, `SELECT COUNT(*) FROM login_attempt
+	WHERE email = LOWER($1) AND succeeded = FALSE AND attempted_at > $2`, email, since)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting failed login attempts: %w", err)
+	}
+	return count, nil
+}
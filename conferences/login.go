@@ -0,0 +1,30 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// LoginParams defines the inputs used by the Login API method
+type LoginParams struct {
+	Email    string
+	Password string
+}
+
+// LoginResponse defines the output returned by the Login API method
+type LoginResponse struct {
+	// Token is accepted by VerifyToken just like the OIDC ones.
+	Token string
+}
+
+// Login exchanges an email and password for a session token
+// encore:api public
+func Login(ctx context.Context, params *LoginParams) (*LoginResponse, error) {
+	token, err := loginLocalUser(ctx, params.Email, params.Password, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to log in: %w", err)
+	}
+
+	return &LoginResponse{Token: token}, nil
+}
@@ -0,0 +1,87 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// Email is a message sent to a user.
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// mailer delivers emails, it is picked from the configured secrets on the first email sent.
+type mailer interface {
+	send(ctx context.Context, email Email) error
+}
+
+// mail is the mailer sendEmail uses, nil until the first email is sent.
+var mail mailer
+
+// sendEmail delivers an email through the SMTP server in the secrets, or logs it in full when
+// there is none as happens in development.
+func sendEmail(ctx context.Context, email Email) error {
+	if strings.ContainsAny(email.To+email.Subject, "\r\n") {
+		return fmt.Errorf("email recipient and subject cannot span lines")
+	}
+	if mail == nil {
+		if secrets.SMTPAddress == "" {
+			mail = logMailer{}
+		} else {
+			mail = smtpMailer{
+				address:  secrets.SMTPAddress,
+				username: secrets.SMTPUsername,
+				password: secrets.SMTPPassword,
+				from:     secrets.MailFrom,
+			}
+		}
+	}
+	return mail.send(ctx, email)
+}
+
+// logMailer writes emails to the log so the codes they carry can be used in development.
+type logMailer struct{}
+
+func (logMailer) send(ctx context.Context, email Email) error {
+	log.Printf("sending email to %s: %s\n%s", email.To, email.Subject, email.Body)
+	return nil
+}
+
+// smtpMailer sends emails through an SMTP server, authenticating when a username is set.
+type smtpMailer struct {
+	address  string
+	username string
+	password string
+	from     string
+}
+
+func (m smtpMailer) send(ctx context.Context, email Email) error {
+	if m.from == "" {
+		return fmt.Errorf("the address emails are sent from is not configured")
+	}
+	var auth smtp.Auth
+	if m.username != "" {
+		host, _, err := net.SplitHostPort(m.address)
+		if err != nil {
+			return fmt.Errorf("invalid SMTP address %q: %w", m.address, err)
+		}
+		auth = smtp.PlainAuth("", m.username, m.password, host)
+	}
+	message := "From: " + m.from + "\r\n" +
+		"To: " + email.To + "\r\n" +
+		"Subject: " + email.Subject + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" +
+		strings.ReplaceAll(email.Body, "\n", "\r\n")
+	if err := smtp.SendMail(m.address, auth, m.from, []string{email.To}, []byte(message)); err != nil {
+		return fmt.Errorf("sending email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+BEGIN;
+
+CREATE TABLE user_role(
+  user_id INT NOT NULL REFERENCES users(id),
+  role TEXT NOT NULL,
+  PRIMARY KEY (user_id, role)
+);
+
+ALTER TABLE users ADD flagged BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE slot_claim ADD revoked BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TYPE incident_status AS ENUM ('new', 'investigating', 'resolved');
+
+CREATE TABLE coc_incident(
+  id SERIAL PRIMARY KEY,
+  conference_id INT NOT NULL REFERENCES conference(id),
+  reporter_id INT NULL REFERENCES users(id),
+  conference_slot_id INT NULL REFERENCES conference_slot(id),
+  location_id INT NULL REFERENCES location(id),
+  occurred_at TIMESTAMPTZ NULL,
+  description TEXT NOT NULL,
+  contact TEXT NOT NULL DEFAULT '',
+  status incident_status NOT NULL DEFAULT 'new',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE coc_incident_note(
+  id SERIAL PRIMARY KEY,
+  incident_id INT NOT NULL REFERENCES coc_incident(id),
+  author_id INT NOT NULL REFERENCES users(id),
+  body TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE coc_incident_audit(
+  id SERIAL PRIMARY KEY,
+  incident_id INT NOT NULL REFERENCES coc_incident(id),
+  actor_id INT NULL REFERENCES users(id),
+  action TEXT NOT NULL,
+  detail TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE user_flag(
+  id SERIAL PRIMARY KEY,
+  user_id INT NOT NULL REFERENCES users(id),
+  incident_id INT NULL REFERENCES coc_incident(id),
+  flagged_by INT NOT NULL REFERENCES users(id),
+  reason TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
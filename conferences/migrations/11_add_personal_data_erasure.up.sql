@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE users ADD erased_at TIMESTAMPTZ NULL;
+
+CREATE TABLE claim_payment_slot_claim(
+  claim_payment_id INT NOT NULL REFERENCES claim_payment(id),
+  slot_claim_id INT NOT NULL REFERENCES slot_claim(id),
+  PRIMARY KEY (claim_payment_id, slot_claim_id)
+);
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+
+ALTER TABLE users ADD email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX users_email_lower_idx ON users (LOWER(email)) WHERE hashed_password IS NOT NULL;
+
+CREATE TYPE auth_token_purpose AS ENUM ('verify_email', 'reset_password');
+
+CREATE TABLE auth_token(
+  token_hash TEXT PRIMARY KEY,
+  user_id INT NOT NULL REFERENCES users(id),
+  purpose auth_token_purpose NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  used_at TIMESTAMPTZ NULL
+);
+
+CREATE TABLE login_attempt(
+  id SERIAL PRIMARY KEY,
+  email TEXT NOT NULL,
+  succeeded BOOLEAN NOT NULL,
+  attempted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX login_attempt_email_idx ON login_attempt (LOWER(email), attempted_at);
+
+COMMIT;
@@ -0,0 +1,11 @@
+CREATE TABLE cfp_rules(
+  conference_id INT PRIMARY KEY REFERENCES conference(id),
+  opens_at TIMESTAMPTZ NOT NULL,
+  closes_at TIMESTAMPTZ NOT NULL,
+  max_submissions_per_speaker INT NOT NULL DEFAULT 0,
+  title_max_length INT NOT NULL DEFAULT 0,
+  elevator_pitch_max_length INT NOT NULL DEFAULT 0,
+  description_min_length INT NOT NULL DEFAULT 0,
+  description_max_length INT NOT NULL DEFAULT 0,
+  CHECK (closes_at > opens_at)
+);
@@ -0,0 +1 @@
+ALTER TABLE paper_submission ADD withdrawn BOOLEAN NOT NULL DEFAULT FALSE;
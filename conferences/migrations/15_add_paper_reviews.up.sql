@@ -0,0 +1,39 @@
+BEGIN;
+
+CREATE TYPE paper_status AS ENUM ('submitted', 'in_review', 'accepted', 'waitlisted', 'rejected', 'confirmed');
+
+ALTER TABLE paper_submission ADD status paper_status NOT NULL DEFAULT 'submitted';
+
+CREATE TABLE review_assignment(
+  conference_id INT NOT NULL REFERENCES conference(id),
+  user_id INT NOT NULL REFERENCES users(id),
+  PRIMARY KEY (conference_id, user_id)
+);
+
+CREATE TABLE scoring_criterion(
+  id SERIAL PRIMARY KEY,
+  conference_id INT NOT NULL REFERENCES conference(id),
+  name TEXT NOT NULL,
+  description TEXT NOT NULL,
+  weight INT NOT NULL CHECK (weight > 0),
+  max_score INT NOT NULL CHECK (max_score > 0),
+  retired BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE paper_review(
+  id SERIAL PRIMARY KEY,
+  paper_id INT NOT NULL REFERENCES paper_submission(id) ON DELETE CASCADE,
+  reviewer_id INT NOT NULL REFERENCES users(id),
+  comment TEXT NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (paper_id, reviewer_id)
+);
+
+CREATE TABLE paper_review_score(
+  review_id INT NOT NULL REFERENCES paper_review(id) ON DELETE CASCADE,
+  criterion_id INT NOT NULL REFERENCES scoring_criterion(id),
+  score INT NOT NULL,
+  PRIMARY KEY (review_id, criterion_id)
+);
+
+COMMIT;
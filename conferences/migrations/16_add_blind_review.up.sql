@@ -0,0 +1,6 @@
+CREATE TABLE review_settings(
+  conference_id INT PRIMARY KEY REFERENCES conference(id),
+  unblinded BOOLEAN NOT NULL DEFAULT FALSE,
+  redact_links BOOLEAN NOT NULL DEFAULT TRUE,
+  redaction_terms TEXT[] NOT NULL DEFAULT '{}'
+);
@@ -0,0 +1,27 @@
+BEGIN;
+
+CREATE TYPE talk_format AS ENUM ('lightning', 'short', 'long', 'workshop');
+
+CREATE TYPE audience_level AS ENUM ('beginner', 'intermediate', 'advanced');
+
+CREATE TYPE cospeaker_status AS ENUM ('invited', 'accepted', 'declined');
+
+ALTER TABLE paper_submission
+  ADD format talk_format NOT NULL DEFAULT 'long',
+  ADD duration_minutes INT NOT NULL DEFAULT 45,
+  ADD audience_level audience_level NOT NULL DEFAULT 'intermediate',
+  ADD tracks TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX paper_submission_tracks ON paper_submission USING GIN (tracks);
+
+CREATE TABLE paper_cospeaker(
+  paper_id INT NOT NULL REFERENCES paper_submission(id) ON DELETE CASCADE,
+  email TEXT NOT NULL,
+  user_id INT REFERENCES users(id),
+  status cospeaker_status NOT NULL DEFAULT 'invited',
+  invited_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX paper_cospeaker_email ON paper_cospeaker (paper_id, LOWER(email));
+
+COMMIT;
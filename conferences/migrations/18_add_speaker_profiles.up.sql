@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE speaker_profile(
+  user_id INT PRIMARY KEY REFERENCES users(id),
+  bio TEXT NOT NULL DEFAULT '',
+  company TEXT NOT NULL DEFAULT '',
+  pronouns TEXT NOT NULL DEFAULT '',
+  twitter TEXT NOT NULL DEFAULT '',
+  github TEXT NOT NULL DEFAULT '',
+  mastodon TEXT NOT NULL DEFAULT '',
+  linkedin TEXT NOT NULL DEFAULT '',
+  website TEXT NOT NULL DEFAULT '',
+  headshot_key TEXT NOT NULL DEFAULT '',
+  headshot_content_type TEXT NOT NULL DEFAULT '',
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
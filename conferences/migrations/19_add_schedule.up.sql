@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE schedule_entry(
+  id SERIAL PRIMARY KEY,
+  conference_id INT NOT NULL REFERENCES conference(id),
+  paper_id INT NOT NULL UNIQUE REFERENCES paper_submission(id) ON DELETE CASCADE,
+  conference_slot_id INT NOT NULL REFERENCES conference_slot(id),
+  location_id INT NOT NULL REFERENCES location(id),
+  starts_at TIMESTAMPTZ NOT NULL,
+  ends_at TIMESTAMPTZ NOT NULL CHECK (ends_at > starts_at),
+  track TEXT NOT NULL DEFAULT '',
+  expected_attendance INT NOT NULL DEFAULT 0 CHECK (expected_attendance >= 0)
+);
+
+CREATE INDEX schedule_entry_conference ON schedule_entry (conference_id, starts_at);
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+CREATE TABLE calendar_feed_token(
+  user_id INT PRIMARY KEY REFERENCES users(id),
+  token_hash TEXT NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE agenda_bookmark(
+  user_id INT NOT NULL REFERENCES users(id),
+  conference_slot_id INT NOT NULL REFERENCES conference_slot(id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (user_id, conference_slot_id)
+);
+
+CREATE INDEX agenda_bookmark_slot ON agenda_bookmark (conference_slot_id);
+
+COMMIT;
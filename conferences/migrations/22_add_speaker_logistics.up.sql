@@ -0,0 +1,36 @@
+BEGIN;
+
+CREATE TABLE speaker_program(
+  conference_id INT PRIMARY KEY REFERENCES conference(id),
+  admission_slot_id INT REFERENCES conference_slot(id),
+  confirm_by TIMESTAMPTZ
+);
+
+CREATE TABLE speaker_travel(
+  conference_id INT NOT NULL REFERENCES conference(id),
+  user_id INT NOT NULL REFERENCES users(id),
+  needs_travel BOOLEAN NOT NULL DEFAULT FALSE,
+  departure_city TEXT NOT NULL DEFAULT '',
+  arrive_on TIMESTAMPTZ,
+  leave_on TIMESTAMPTZ,
+  needs_hotel BOOLEAN NOT NULL DEFAULT FALSE,
+  notes TEXT NOT NULL DEFAULT '',
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (conference_id, user_id)
+);
+
+CREATE TYPE speaker_payable_kind AS ENUM ('honorarium', 'travel_grant');
+
+CREATE TABLE speaker_payable(
+  id SERIAL PRIMARY KEY,
+  conference_id INT NOT NULL REFERENCES conference(id),
+  user_id INT NOT NULL REFERENCES users(id),
+  kind speaker_payable_kind NOT NULL,
+  amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+  detail TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  paid_at TIMESTAMPTZ,
+  payment_ref TEXT NOT NULL DEFAULT ''
+);
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+-- user_id is cleared when the attendee is erased, their rating still counts.
+CREATE TABLE session_feedback(
+  id SERIAL PRIMARY KEY,
+  conference_slot_id INT NOT NULL REFERENCES conference_slot(id),
+  paper_id INT REFERENCES paper_submission(id) ON DELETE CASCADE,
+  user_id INT REFERENCES users(id),
+  rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+  comment TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX session_feedback_once ON session_feedback (user_id, conference_slot_id, COALESCE(paper_id, 0));
+CREATE INDEX session_feedback_paper ON session_feedback (paper_id);
+
+COMMIT;
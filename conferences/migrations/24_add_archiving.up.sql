@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE event ADD archived_at TIMESTAMPTZ;
+ALTER TABLE event ADD CONSTRAINT event_slug_unique UNIQUE (slug);
+
+ALTER TABLE conference ADD archived_at TIMESTAMPTZ;
+
+ALTER TABLE venue ADD archived_at TIMESTAMPTZ;
+
+ALTER TABLE location ADD archived_at TIMESTAMPTZ;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE conference_slot ADD retired_at TIMESTAMPTZ;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- current is now an override, NULL lets the dates of the conferences of an event decide which one
+-- is current, TRUE always makes it current and FALSE never does.
+UPDATE conference SET current = NULL;
+
+COMMIT;
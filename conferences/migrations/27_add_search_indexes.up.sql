@@ -0,0 +1,16 @@
+BEGIN;
+
+-- The expressions must match the ones the Search queries in search_storage.go use, otherwise
+-- these indexes are not picked.
+CREATE INDEX paper_submission_search ON paper_submission
+  USING GIN (to_tsvector('english', title || ' ' || elevator_pitch || ' ' || description));
+
+CREATE INDEX job_board_search ON job_board
+  USING GIN (to_tsvector('english', title || ' ' || company_name || ' ' || description));
+
+CREATE INDEX sponsor_search ON sponsor USING GIN (to_tsvector('english', name));
+
+CREATE INDEX conference_slot_search ON conference_slot
+  USING GIN (to_tsvector('english', name || ' ' || description));
+
+COMMIT;
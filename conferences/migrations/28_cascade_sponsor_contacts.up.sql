@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Contacts go away with the sponsor they work for.
+ALTER TABLE sponsor_contact_information
+  DROP CONSTRAINT sponsor_contact_information_sponsor_id_fkey,
+  ADD CONSTRAINT sponsor_contact_information_sponsor_id_fkey
+    FOREIGN KEY (sponsor_id) REFERENCES sponsor(id) ON DELETE CASCADE;
+
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN;
+
+-- Tiers change every year, so they are data of each conference rather than a database type.
+-- rank 1 is the highest tier, max_sponsors 0 means there is no limit.
+CREATE TABLE sponsorship_tier(
+  id SERIAL PRIMARY KEY,
+  conference_id INT NOT NULL REFERENCES conference(id),
+  name TEXT NOT NULL,
+  rank INT NOT NULL CHECK (rank > 0),
+  price_cents BIGINT NOT NULL DEFAULT 0 CHECK (price_cents >= 0),
+  benefits TEXT[] NOT NULL DEFAULT '{}',
+  max_sponsors INT NOT NULL DEFAULT 0 CHECK (max_sponsors >= 0),
+  UNIQUE (conference_id, name),
+  UNIQUE (conference_id, rank)
+);
+
+-- Every level a conference had sponsors at becomes one of its tiers, ranked as the levels were.
+INSERT INTO sponsorship_tier (conference_id, name, rank)
+SELECT DISTINCT conference_id, INITCAP(sponsorship_level::TEXT),
+  ARRAY_POSITION(ENUM_RANGE(NULL::sponsorship_level), sponsorship_level)
+FROM sponsor;
+
+ALTER TABLE sponsor ADD tier_id INT REFERENCES sponsorship_tier(id);
+
+UPDATE sponsor SET tier_id = sponsorship_tier.id
+FROM sponsorship_tier
+WHERE sponsorship_tier.conference_id = sponsor.conference_id
+AND sponsorship_tier.name = INITCAP(sponsor.sponsorship_level::TEXT);
+
+ALTER TABLE sponsor ALTER tier_id SET NOT NULL, DROP sponsorship_level;
+
+DROP TYPE sponsorship_level;
+
+COMMIT;
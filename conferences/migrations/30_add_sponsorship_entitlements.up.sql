@@ -0,0 +1,31 @@
+BEGIN;
+
+CREATE TYPE entitlement_kind AS ENUM ('ticket', 'booth', 'job_post', 'logo', 'other');
+
+-- The package of a tier, ticket entitlements are claims of a slot not available to the public.
+CREATE TABLE sponsorship_entitlement(
+  id SERIAL PRIMARY KEY,
+  tier_id INT NOT NULL REFERENCES sponsorship_tier(id) ON DELETE CASCADE,
+  kind entitlement_kind NOT NULL,
+  quantity INT NOT NULL CHECK (quantity > 0),
+  conference_slot_id INT REFERENCES conference_slot(id),
+  description TEXT NOT NULL DEFAULT '',
+  CHECK ((kind = 'ticket') = (conference_slot_id IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX sponsorship_entitlement_unique
+  ON sponsorship_entitlement (tier_id, kind, COALESCE(conference_slot_id, 0));
+
+-- Each use of an entitlement by a sponsor, tickets keep the claim they were issued as.
+CREATE TABLE sponsor_entitlement_use(
+  id SERIAL PRIMARY KEY,
+  sponsor_id INT NOT NULL REFERENCES sponsor(id) ON DELETE CASCADE,
+  entitlement_id INT NOT NULL REFERENCES sponsorship_entitlement(id),
+  slot_claim_id INT REFERENCES slot_claim(id),
+  note TEXT NOT NULL DEFAULT '',
+  used_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX sponsor_entitlement_use_sponsor ON sponsor_entitlement_use (sponsor_id);
+
+COMMIT;
@@ -0,0 +1,43 @@
+BEGIN;
+
+CREATE TYPE sponsor_deal_stage AS ENUM ('prospect', 'contacted', 'contract_sent', 'signed', 'invoiced', 'paid');
+
+ALTER TABLE sponsor
+  ADD stage sponsor_deal_stage NOT NULL DEFAULT 'prospect',
+  ADD contract_amount_cents BIGINT NOT NULL DEFAULT 0 CHECK (contract_amount_cents >= 0),
+  ADD billing_contact_id INT REFERENCES sponsor_contact_information(id) ON DELETE SET NULL;
+
+-- Sponsors that predate the pipeline are already shown on the site.
+UPDATE sponsor SET stage = 'signed';
+
+-- The payment schedule of the contract of a sponsor.
+CREATE TABLE sponsor_installment(
+  id SERIAL PRIMARY KEY,
+  sponsor_id INT NOT NULL REFERENCES sponsor(id) ON DELETE CASCADE,
+  due_on TIMESTAMPTZ NOT NULL,
+  amount_cents BIGINT NOT NULL CHECK (amount_cents > 0)
+);
+
+-- Invoices keep who they were billed to as it was when issued.
+CREATE TABLE sponsor_invoice(
+  id SERIAL PRIMARY KEY,
+  sponsor_id INT NOT NULL REFERENCES sponsor(id),
+  installment_id INT NOT NULL UNIQUE REFERENCES sponsor_installment(id),
+  billed_to TEXT NOT NULL,
+  billed_to_email TEXT NOT NULL,
+  amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+  issued_at TIMESTAMPTZ NOT NULL,
+  due_on TIMESTAMPTZ NOT NULL
+);
+
+-- Payments are a ledger, entries are only ever added.
+CREATE TABLE sponsor_payment(
+  id SERIAL PRIMARY KEY,
+  invoice_id INT NOT NULL REFERENCES sponsor_invoice(id),
+  asset_type TEXT NOT NULL CHECK (asset_type IN ('cash', 'receivable', 'discount')),
+  amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+  ref TEXT NOT NULL DEFAULT '',
+  received_at TIMESTAMPTZ NOT NULL
+);
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Contacts manage their sponsor once they join its portal logged in with their email, a user
+-- joins the portal of a sponsor once.
+ALTER TABLE sponsor_contact_information
+  ADD COLUMN user_id INT REFERENCES users(id),
+  ADD CONSTRAINT sponsor_contact_information_user_unique UNIQUE (sponsor_id, user_id);
+
+-- The public profile sponsors edit themselves.
+ALTER TABLE sponsor
+  ADD COLUMN description TEXT NOT NULL DEFAULT '',
+  ADD COLUMN logo_key TEXT,
+  ADD COLUMN logo_content_type TEXT;
+
+-- Jobs posted by sponsors, they stay on the board if the sponsor goes.
+ALTER TABLE job_board
+  ADD COLUMN sponsor_id INT REFERENCES sponsor(id) ON DELETE SET NULL;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Session tokens carry the version they were issued at, bumping it revokes them.
+ALTER TABLE users ADD session_version INT NOT NULL DEFAULT 0;
+
+COMMIT;
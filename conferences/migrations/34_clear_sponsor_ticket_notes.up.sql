@@ -0,0 +1,6 @@
+BEGIN;
+
+-- Tickets issued by sponsors recorded the email of their holder, the claim already tells who it is.
+UPDATE sponsor_entitlement_use SET note = '' WHERE slot_claim_id IS NOT NULL;
+
+COMMIT;
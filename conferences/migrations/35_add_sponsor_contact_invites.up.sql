@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Contacts join the portal of their sponsor with a token the organizers email them rather than by
+-- logging in with their email, only the hash of the token is kept until it is used.
+ALTER TABLE sponsor_contact_information
+  ADD COLUMN invite_token_hash TEXT UNIQUE,
+  ADD COLUMN invite_expires_at TIMESTAMPTZ;
+
+COMMIT;
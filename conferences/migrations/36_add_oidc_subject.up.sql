@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Users logging in through OIDC are known by the subject of their tokens, they are linked to the
+-- user with their verified email on their first login.
+ALTER TABLE users ADD oidc_subject TEXT UNIQUE;
+
+COMMIT;
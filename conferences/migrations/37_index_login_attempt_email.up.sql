@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Login attempts are stored with their email lowercased and looked up by it as is, an index on
+-- LOWER(email) is never used.
+DROP INDEX login_attempt_email_idx;
+CREATE INDEX login_attempt_email_idx ON login_attempt (email, attempted_at);
+
+COMMIT;
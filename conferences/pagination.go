@@ -0,0 +1,155 @@
+package conferences
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Page defines which part of a list to return, every list API method takes one. Pages are cut by
+// cursor rather than offset, so items added or removed while paging do not shift the next pages.
+type Page struct {
+	// Cursor is the NextCursor of the previous page, empty for the first page.
+	Cursor string
+	// Limit is how many items to return, defaultPageLimit if zero and at most maxPageLimit.
+	Limit int
+	// SortBy is one of the fields the list can be sorted by, its default field if empty. Ties are
+	// broken by ID so the order is always the same.
+	SortBy     string
+	Descending bool
+}
+
+// PageInfo tells how to get the page after the one returned
+type PageInfo struct {
+	// NextCursor is the Cursor of the next page, empty if this was the last one.
+	NextCursor string
+}
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// sortColumn is a field a list can be sorted by, expr must not be NULL and its text
+// representation must cast back to sqlType.
+type sortColumn struct {
+	expr    string
+	sqlType string
+}
+
+// pageCursor is what a cursor holds, the sort key and ID of the last item of the previous page.
+type pageCursor struct {
+	SortBy     string
+	Descending bool
+	Key        string
+	ID         uint32
+}
+
+// encode returns the cursor as an opaque string.
+func (c *pageCursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor parses a cursor returned by encode.
+func decodeCursor(cursor string) (*pageCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	c := pageCursor{}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	return &c, nil
+}
+
+// pageQuery builds the SQL selecting a page of a list.
+type pageQuery struct {
+	idExpr     string
+	sortBy     string
+	column     sortColumn
+	descending bool
+	limit      int
+	after      *pageCursor
+}
+
+// newPageQuery checks the page against the fields the list can be sorted by. idExpr is the
+// unique ID breaking ties.
+func newPageQuery(page Page, idExpr string, columns map[string]sortColumn, defaultSort string) (*pageQuery, error) {
+	q := pageQuery{idExpr: idExpr, sortBy: page.SortBy, descending: page.Descending, limit: page.Limit}
+	if q.sortBy == "" {
+		q.sortBy = defaultSort
+	}
+	column, ok := columns[q.sortBy]
+	if !ok {
+		fields := make([]string, 0, len(columns))
+		for field := range columns {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		return nil, fmt.Errorf("cannot sort by %q, use one of %s", q.sortBy, strings.Join(fields, ", "))
+	}
+	q.column = column
+
+	switch {
+	case q.limit < 0:
+		return nil, fmt.Errorf("limit cannot be negative")
+	case q.limit == 0:
+		q.limit = defaultPageLimit
+	case q.limit > maxPageLimit:
+		q.limit = maxPageLimit
+	}
+
+	if page.Cursor != "" {
+		after, err := decodeCursor(page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if after.SortBy != q.sortBy || after.Descending != q.descending {
+			return nil, fmt.Errorf("cursor was issued for another sort order")
+		}
+		q.after = after
+	}
+	return &q, nil
+}
+
+// keyColumn is the expression to select along with every item, its sort key as text.
+func (q *pageQuery) keyColumn() string {
+	return "(" + q.column.expr + ")::TEXT"
+}
+
+// condition returns the SQL condition skipping the previous pages, its arguments start at $firstArg.
+func (q *pageQuery) condition(firstArg int) (string, []interface{}) {
+	if q.after == nil {
+		return "TRUE", nil
+	}
+	op := ">"
+	if q.descending {
+		op = "<"
+	}
+	return fmt.Sprintf("(%s, %s) %s ($%d::%s, $%d)", q.column.expr, q.idExpr, op, firstArg, q.column.sqlType, firstArg+1),
+		[]interface{}{q.after.Key, q.after.ID}
+}
+
+// orderBy returns the ORDER BY and LIMIT clauses, one more item than the page holds is selected
+// to know whether there is a next page.
+func (q *pageQuery) orderBy() string {
+	direction := "ASC"
+	if q.descending {
+		direction = "DESC"
+	}
+	return fmt.Sprintf("ORDER BY %s %s, %s %s LIMIT %d", q.column.expr, direction, q.idExpr, direction, q.limit+1)
+}
+
+// pageInfo returns how many of the selected items belong to the page and the cursor of the next
+// one, keys and ids are those of the selected items in order.
+func (q *pageQuery) pageInfo(keys []string, ids []uint32) (int, PageInfo) {
+	if len(ids) <= q.limit {
+		return len(ids), PageInfo{}
+	}
+	last := pageCursor{SortBy: q.sortBy, Descending: q.descending, Key: keys[q.limit-1], ID: ids[q.limit-1]}
+	return q.limit, PageInfo{NextCursor: last.encode()}
+}
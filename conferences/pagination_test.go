@@ -0,0 +1,95 @@
+package conferences
+
+import (
+	"testing"
+)
+
+var testSortColumns = map[string]sortColumn{
+	"name": {expr: "name", sqlType: "TEXT"},
+	"id":   {expr: "id", sqlType: "INT"},
+}
+
+func TestNewPageQuery(t *testing.T) {
+	cursor := (&pageCursor{SortBy: "name", Key: "Gopher", ID: 7}).encode()
+
+	tests := []struct {
+		name      string
+		page      Page
+		wantErr   bool
+		wantLimit int
+		wantSort  string
+	}{
+		{name: "defaults", page: Page{}, wantLimit: defaultPageLimit, wantSort: "name"},
+		{name: "limit is clamped", page: Page{Limit: 1000, SortBy: "id"}, wantLimit: maxPageLimit, wantSort: "id"},
+		{name: "negative limit", page: Page{Limit: -1}, wantErr: true},
+		{name: "unknown sort", page: Page{SortBy: "rank"}, wantErr: true},
+		{name: "malformed cursor", page: Page{Cursor: "not a cursor"}, wantErr: true},
+		{name: "cursor of another sort", page: Page{Cursor: cursor, SortBy: "id"}, wantErr: true},
+		{name: "cursor of another direction", page: Page{Cursor: cursor, Descending: true}, wantErr: true},
+		{name: "cursor", page: Page{Cursor: cursor, Limit: 10}, wantLimit: 10, wantSort: "name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := newPageQuery(tt.page, "id", testSortColumns, "name")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newPageQuery() got error %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if q.limit != tt.wantLimit || q.sortBy != tt.wantSort {
+				t.Errorf("newPageQuery() got limit %d sorted by %q, want %d sorted by %q", q.limit, q.sortBy, tt.wantLimit, tt.wantSort)
+			}
+		})
+	}
+}
+
+func TestPageQueryCondition(t *testing.T) {
+	q, err := newPageQuery(Page{}, "id", testSortColumns, "name")
+	if err != nil {
+		t.Fatalf("newPageQuery() got error %v", err)
+	}
+	if condition, args := q.condition(3); condition != "TRUE" || len(args) != 0 {
+		t.Errorf("condition() on the first page got %q with %v", condition, args)
+	}
+
+	cursor := (&pageCursor{SortBy: "id", Descending: true, Key: "12", ID: 12}).encode()
+	q, err = newPageQuery(Page{Cursor: cursor, SortBy: "id", Descending: true}, "id", testSortColumns, "name")
+	if err != nil {
+		t.Fatalf("newPageQuery() got error %v", err)
+	}
+	condition, args := q.condition(3)
+	if want := "(id, id) < ($3::INT, $4)"; condition != want {
+		t.Errorf("condition() got %q, want %q", condition, want)
+	}
+	if len(args) != 2 || args[0] != "12" || args[1] != uint32(12) {
+		t.Errorf("condition() got arguments %v", args)
+	}
+	if want := "ORDER BY id DESC, id DESC LIMIT 51"; q.orderBy() != want {
+		t.Errorf("orderBy() got %q, want %q", q.orderBy(), want)
+	}
+}
+
+func TestPageQueryPageInfo(t *testing.T) {
+	q, err := newPageQuery(Page{Limit: 2}, "id", testSortColumns, "name")
+	if err != nil {
+		t.Fatalf("newPageQuery() got error %v", err)
+	}
+
+	n, info := q.pageInfo([]string{"a", "b"}, []uint32{1, 2})
+	if n != 2 || info.NextCursor != "" {
+		t.Errorf("pageInfo() on the last page got %d items and cursor %q", n, info.NextCursor)
+	}
+
+	n, info = q.pageInfo([]string{"a", "b", "c"}, []uint32{1, 2, 3})
+	if n != 2 || info.NextCursor == "" {
+		t.Fatalf("pageInfo() got %d items and cursor %q, want 2 items and a cursor", n, info.NextCursor)
+	}
+	next, err := decodeCursor(info.NextCursor)
+	if err != nil {
+		t.Fatalf("decodeCursor() got error %v", err)
+	}
+	if want := (pageCursor{SortBy: "name", Key: "b", ID: 2}); *next != want {
+		t.Errorf("decodeCursor() got %+v, want %+v", *next, want)
+	}
+}
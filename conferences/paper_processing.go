@@ -0,0 +1,207 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+// canManagePaper returns true if the user is one of the speakers of the paper or an organizer.
+func canManagePaper(ctx context.Context, userID uint32, paper *Paper) (bool, error) {
+	if paper.isSpeaker(userID) {
+		return true, nil
+	}
+	return hasRole(ctx, nil, userID, RoleOrganizer)
+}
+
+// readManagedPaper returns the paper if userID is allowed to manage it.
+func readManagedPaper(ctx context.Context, userID, paperID uint32) (*Paper, error) {
+	paper, err := readPaperByID(ctx, nil, paperID)
+	if err != nil {
+		return nil, err
+	}
+	if paper == nil {
+		return nil, fmt.Errorf("no such paper")
+	}
+	coSpeakers, err := readCoSpeakers(ctx, []uint32{paper.ID})
+	if err != nil {
+		return nil, err
+	}
+	paper.CoSpeakers = coSpeakers[paper.ID]
+	allowed, err := canManagePaper(ctx, userID, paper)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		// we do not tell apart missing and not owned papers so ids cannot be probed.
+		return nil, fmt.Errorf("no such paper")
+	}
+	return paper, nil
+}
+
+// submitPaper saves a paper on behalf of the passed speaker, one submission of a speaker at a time
+// so none of them gets past the limit of papers per speaker.
+func submitPaper(ctx context.Context, speakerID uint32, paper *Paper) (uint32, error) {
+	submission := *paper
+	submission.UserID = speakerID
+	if err := submission.normalizeDetails(); err != nil {
+		return 0, err
+	}
+
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	var id uint32
+	err = lockSpeakerSubmissions(ctx, tx, speakerID)
+	if err == nil {
+		err = checkCFPSubmission(ctx, tx, &submission, true, time.Now())
+	}
+	if err == nil {
+		id, err = createPaper(ctx, tx, &submission)
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return 0, err
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+	return id, nil
+}
+
+// editPaper saves the editable fields of a paper if userID is allowed to manage it.
+func editPaper(ctx context.Context, userID uint32, paper *Paper) (*Paper, error) {
+	existing, err := readManagedPaper(ctx, userID, paper.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Withdrawn {
+		return nil, fmt.Errorf("withdrawn papers cannot be edited")
+	}
+
+	// conference and speaker cannot be changed, rules are checked against the stored ones.
+	edited := *paper
+	edited.ConferenceID = existing.ConferenceID
+	edited.UserID = existing.UserID
+	if err := edited.normalizeDetails(); err != nil {
+		return nil, err
+	}
+	if err := checkCFPSubmission(ctx, nil, &edited, false, time.Now()); err != nil {
+		return nil, err
+	}
+	updated, err := updatePaperContent(ctx, nil, &edited)
+	if err != nil {
+		return nil, err
+	}
+	updated.CoSpeakers = existing.CoSpeakers
+	return updated, nil
+}
+
+// withdrawPaper pulls a paper out of consideration if userID is allowed to manage it, co-speakers
+// cannot withdraw a paper they were invited to.
+func withdrawPaper(ctx context.Context, userID, paperID uint32) error {
+	paper, err := readManagedPaper(ctx, userID, paperID)
+	if err != nil {
+		return err
+	}
+	if err := requireSubmitterOrOrganizer(ctx, userID, paper); err != nil {
+		return err
+	}
+	if paper.Withdrawn {
+		return nil
+	}
+	return markPaperWithdrawn(ctx, nil, paperID)
+}
+
+// requireSubmitterOrOrganizer returns an error unless the user submitted the paper or is an organizer.
+func requireSubmitterOrOrganizer(ctx context.Context, userID uint32, paper *Paper) error {
+	if paper.UserID == userID {
+		return nil
+	}
+	return requireRole(ctx, userID, RoleOrganizer)
+}
+
+// inviteCoSpeaker invites an email to co-present a paper and emails them about it.
+func inviteCoSpeaker(ctx context.Context, userID, paperID uint32, email string) error {
+	email = strings.TrimSpace(email)
+	if !strings.Contains(email, "@") {
+		return fmt.Errorf("a valid email is required")
+	}
+	paper, err := readManagedPaper(ctx, userID, paperID)
+	if err != nil {
+		return err
+	}
+	if err := requireSubmitterOrOrganizer(ctx, userID, paper); err != nil {
+		return err
+	}
+	if paper.Withdrawn {
+		return fmt.Errorf("withdrawn papers cannot have co-speakers")
+	}
+
+	speaker, err := readAttendeeByID(ctx, nil, paper.UserID)
+	if err != nil {
+		return err
+	}
+	if speaker != nil && strings.EqualFold(speaker.Email, email) {
+		return fmt.Errorf("the speaker cannot be their own co-speaker")
+	}
+	pending := 0
+	for _, c := range paper.CoSpeakers {
+		if strings.EqualFold(c.Email, email) && c.Status != CoSpeakerDeclined {
+			return fmt.Errorf("%s was already invited", email)
+		}
+		if c.Status != CoSpeakerDeclined {
+			pending++
+		}
+	}
+	if pending >= maxCoSpeakers {
+		return fmt.Errorf("a paper can have at most %d co-speakers", maxCoSpeakers)
+	}
+
+	if err := insertCoSpeakerInvitation(ctx, paperID, email); err != nil {
+		return err
+	}
+	err = sendEmail(ctx, Email{
+		To:      email,
+		Subject: "You were invited to co-present " + paper.Title,
+		Body:    fmt.Sprintf("Log in with this email and answer the invitation for paper %d to co-present %q.", paper.ID, paper.Title),
+	})
+	if err != nil {
+		return fmt.Errorf("sending co-speaker invitation: %w", err)
+	}
+	return nil
+}
+
+// removeCoSpeaker removes a co-speaker, or their pending invitation, from a paper.
+func removeCoSpeaker(ctx context.Context, userID, paperID uint32, email string) error {
+	paper, err := readManagedPaper(ctx, userID, paperID)
+	if err != nil {
+		return err
+	}
+	if err := requireSubmitterOrOrganizer(ctx, userID, paper); err != nil {
+		return err
+	}
+	return deleteCoSpeaker(ctx, paperID, email)
+}
+
+// answerCoSpeakerInvitation accepts or declines the invitation sent to the email of the user.
+func answerCoSpeakerInvitation(ctx context.Context, userID, paperID uint32, accept bool) error {
+	user, err := readAttendeeByID(ctx, nil, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return fmt.Errorf("no such invitation")
+	}
+	status := CoSpeakerDeclined
+	if accept {
+		status = CoSpeakerAccepted
+	}
+	return updateCoSpeakerStatus(ctx, paperID, userID, user.Email, status)
+}
@@ -0,0 +1,418 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"encore.dev/storage/sqldb"
+	"github.com/lib/pq"
+)
+
+const paperColumns = `id, user_id, conference_id, title, elevator_pitch, description, notes, withdrawn, status,
+	format, duration_minutes, audience_level, tracks`
+
+// prefixedPaperColumns are paperColumns for queries joining paper_submission with other tables.
+const prefixedPaperColumns = `paper_submission.id, paper_submission.user_id, paper_submission.conference_id,
+	paper_submission.title, paper_submission.elevator_pitch, paper_submission.description, paper_submission.notes,
+	paper_submission.withdrawn, paper_submission.status, paper_submission.format, paper_submission.duration_minutes,
+	paper_submission.audience_level, paper_submission.tracks`
+
+// scanPaper scans a row selected with paperColumns.
+func scanPaper(scan func(dest ...interface{}) error) (*Paper, error) {
+	var paper Paper
+	var tracks pq.StringArray
+	err := scan(&paper.ID,
+		&paper.UserID,
+		&paper.ConferenceID,
+		&paper.Title,
+		&paper.ElevatorPitch,
+		&paper.Description,
+		&paper.Notes,
+		&paper.Withdrawn,
+		&paper.Status,
+		&paper.Format,
+		&paper.DurationMinutes,
+		&paper.AudienceLevel,
+		&tracks)
+	if err != nil {
+		return nil, err
+	}
+	paper.Tracks = tracks
+	return &paper, nil
+}
+
+// readPaperByID returns a paper, nil if it does not exist.
+func readPaperByID(ctx context.Context, tx *sqldb.Tx, id uint32) (*Paper, error) {
+	sqlStatement := `SELECT ` + paperColumns + ` FROM paper_submission WHERE id = $1`
+	var row *sqldb.Row
+
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, id)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, id)
+	}
+
+	paper, err := scanPaper(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading paper by id: %w", err)
+	}
+	return paper, nil
+}
+
+// readPapersByUser returns all the papers submitted by a user, withdrawn ones included.
+func readPapersByUser(ctx context.Context, userID uint32) ([]Paper, error) {
+	rows, err := sqldb.Query(ctx, `SELECT `+paperColumns+` FROM paper_submission
+	WHERE user_id = $1 ORDER BY id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying papers by user: %w", err)
+	}
+	defer rows.Close()
+
+	papers := []Paper{}
+	for rows.Next() {
+		paper, err := scanPaper(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning paper: %w", err)
+		}
+		papers = append(papers, *paper)
+	}
+	return papers, nil
+}
+
+// createPaper saves a new paper and returns its ID.
+func createPaper(ctx context.Context, tx *sqldb.Tx, paper *Paper) (uint32, error) {
+	sqlStatement := `INSERT INTO paper_submission (
+			user_id,
+			conference_id,
+			title,
+			elevator_pitch,
+			description,
+			notes,
+			format,
+			duration_minutes,
+			audience_level,
+			tracks
+		) VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5,
+			$6,
+			$7,
+			$8,
+			$9,
+			$10
+		) RETURNING id`
+	sqlArgs := []interface{}{
+		paper.UserID,
+		paper.ConferenceID,
+		paper.Title,
+		paper.ElevatorPitch,
+		paper.Description,
+		paper.Notes,
+		string(paper.Format),
+		paper.DurationMinutes,
+		string(paper.AudienceLevel),
+		pq.StringArray(paper.Tracks),
+	}
+	var row *sqldb.Row
+
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
+	}
+
+	var paperID uint32
+	if err := row.Scan(&paperID); err != nil {
+		return 0, fmt.Errorf("saving paper: %w", err)
+	}
+	return paperID, nil
+}
+
+// updatePaperContent saves the editable fields of a paper and returns it as stored.
+func updatePaperContent(ctx context.Context, tx *sqldb.Tx, paper *Paper) (*Paper, error) {
+	sqlStatement := `UPDATE paper_submission
+		SET title = $1,
+			elevator_pitch = $2,
+			description = $3,
+			notes = $4,
+			format = $5,
+			duration_minutes = $6,
+			audience_level = $7,
+			tracks = $8
+		WHERE id = $9
+		RETURNING ` + paperColumns
+	sqlArgs := []interface{}{
+		paper.Title,
+		paper.ElevatorPitch,
+		paper.Description,
+		paper.Notes,
+		string(paper.Format),
+		paper.DurationMinutes,
+		string(paper.AudienceLevel),
+		pq.StringArray(paper.Tracks),
+		paper.ID,
+	}
+	var row *sqldb.Row
+
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
+	}
+
+	updated, err := scanPaper(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no such paper")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("updating paper: %w", err)
+	}
+	return updated, nil
+}
+
+// markPaperWithdrawn flags a paper as withdrawn by its speaker.
+func markPaperWithdrawn(ctx context.Context, tx *sqldb.Tx, id uint32) error {
+	sqlStatement := `UPDATE paper_submission SET withdrawn = TRUE WHERE id = $1`
+	var res sql.Result
+	var err error
+
+	if tx != nil {
+		res, err = sqldb.ExecTx(tx, ctx, sqlStatement, id)
+	} else {
+		res, err = sqldb.Exec(ctx, sqlStatement, id)
+	}
+	if err != nil {
+		return fmt.Errorf("withdrawing paper: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	if ra == 0 {
+		return fmt.Errorf("no such paper")
+	}
+	return nil
+}
+
+// updatePaperStatus moves a paper to a new status.
+func updatePaperStatus(ctx context.Context, tx *sqldb.Tx, id uint32, status PaperStatus) error {
+	sqlStatement := `UPDATE paper_submission SET status = $1 WHERE id = $2`
+	sqlArgs := []interface{}{string(status), id}
+	var res sql.Result
+	var err error
+
+	if tx != nil {
+		res, err = sqldb.ExecTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		res, err = sqldb.Exec(ctx, sqlStatement, sqlArgs...)
+	}
+	if err != nil {
+		return fmt.Errorf("updating paper status: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	if ra == 0 {
+		return fmt.Errorf("no such paper")
+	}
+	return nil
+}
+
+// readPapersByConference returns the papers of a conference that have not been withdrawn.
+func readPapersByConference(ctx context.Context, conferenceID uint32) ([]Paper, error) {
+	rows, err := sqldb.Query(ctx, `SELECT `+paperColumns+` FROM paper_submission
+	WHERE conference_id = $1 AND withdrawn = FALSE ORDER BY id`, conferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying papers by conference: %w", err)
+	}
+	defer rows.Close()
+
+	papers := []Paper{}
+	for rows.Next() {
+		paper, err := scanPaper(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning paper: %w", err)
+		}
+		papers = append(papers, *paper)
+	}
+	return papers, nil
+}
+
+// PaperFilter narrows down the papers returned by ListPapers, zero fields do not filter.
+type PaperFilter struct {
+	ConferenceID  uint32
+	Format        TalkFormat
+	AudienceLevel AudienceLevel
+	Track         string
+	Status        PaperStatus
+}
+
+// paperSortColumns are the fields papers can be sorted by.
+var paperSortColumns = map[string]sortColumn{
+	"id":    {expr: "id", sqlType: "INT"},
+	"title": {expr: "title", sqlType: "TEXT"},
+}
+
+// readPapersByFilter returns a page of the papers matching the filter that have not been withdrawn.
+func readPapersByFilter(ctx context.Context, filter PaperFilter, page Page) ([]Paper, PageInfo, error) {
+	q, err := newPageQuery(page, "id", paperSortColumns, "id")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	condition, pageArgs := q.condition(6)
+	rows, err := sqldb.Query(ctx, `SELECT `+paperColumns+`, `+q.keyColumn()+` FROM paper_submission
+	WHERE withdrawn = FALSE
+	AND ($1 = 0 OR conference_id = $1)
+	AND ($2 = '' OR format::TEXT = $2)
+	AND ($3 = '' OR audience_level::TEXT = $3)
+	AND ($4 = '' OR $4 = ANY(tracks))
+	AND ($5 = '' OR status::TEXT = $5)
+	AND `+condition+` `+q.orderBy(),
+		append([]interface{}{
+			filter.ConferenceID,
+			string(filter.Format),
+			string(filter.AudienceLevel),
+			strings.ToLower(strings.TrimSpace(filter.Track)),
+			string(filter.Status),
+		}, pageArgs...)...)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("querying papers: %w", err)
+	}
+	defer rows.Close()
+
+	papers := []Paper{}
+	keys := []string{}
+	ids := []uint32{}
+	for rows.Next() {
+		var key string
+		paper, err := scanPaper(func(dest ...interface{}) error {
+			return rows.Scan(append(dest, &key)...)
+		})
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("scanning paper: %w", err)
+		}
+		papers = append(papers, *paper)
+		keys = append(keys, key)
+		ids = append(ids, paper.ID)
+	}
+	n, info := q.pageInfo(keys, ids)
+	return papers[:n], info, nil
+}
+
+// readPapersByCoSpeaker returns the papers a user accepted to co-present.
+func readPapersByCoSpeaker(ctx context.Context, userID uint32) ([]Paper, error) {
+	rows, err := sqldb.Query(ctx, `SELECT `+paperColumns+` FROM paper_submission
+	WHERE id IN (SELECT paper_id FROM paper_cospeaker WHERE user_id = $1 AND status = 'accepted')
+	ORDER BY id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying papers by co-speaker: %w", err)
+	}
+	defer rows.Close()
+
+	papers := []Paper{}
+	for rows.Next() {
+		paper, err := scanPaper(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning paper: %w", err)
+		}
+		papers = append(papers, *paper)
+	}
+	return papers, nil
+}
+
+// readCoSpeakers returns the co-speakers of the passed papers by paper ID.
+func readCoSpeakers(ctx context.Context, paperIDs []uint32) (map[uint32][]CoSpeaker, error) {
+	ids := make(pq.Int64Array, 0, len(paperIDs))
+	for _, id := range paperIDs {
+		ids = append(ids, int64(id))
+	}
+	rows, err := sqldb.Query(ctx, `SELECT paper_id, email, COALESCE(user_id, 0), status FROM paper_cospeaker
+	WHERE paper_id = ANY($1) ORDER BY invited_at`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("querying co-speakers: %w", err)
+	}
+	defer rows.Close()
+
+	coSpeakers := map[uint32][]CoSpeaker{}
+	for rows.Next() {
+		var paperID uint32
+		c := CoSpeaker{}
+		if err := rows.Scan(&paperID, &c.Email, &c.UserID, &c.Status); err != nil {
+			return nil, fmt.Errorf("scanning co-speaker: %w", err)
+		}
+		coSpeakers[paperID] = append(coSpeakers[paperID], c)
+	}
+	return coSpeakers, nil
+}
+
+// attachCoSpeakers fills in the co-speakers of the passed papers.
+func attachCoSpeakers(ctx context.Context, papers []Paper) error {
+	ids := make([]uint32, 0, len(papers))
+	for _, p := range papers {
+		ids = append(ids, p.ID)
+	}
+	coSpeakers, err := readCoSpeakers(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range papers {
+		papers[i].CoSpeakers = coSpeakers[papers[i].ID]
+	}
+	return nil
+}
+
+// insertCoSpeakerInvitation invites an email to co-present a paper, inviting it again resets a
+// declined invitation.
+func insertCoSpeakerInvitation(ctx context.Context, paperID uint32, email string) error {
+	_, err := sqldb.Exec(ctx, `INSERT INTO paper_cospeaker (paper_id, email) VALUES ($1, $2)
+	ON CONFLICT (paper_id, LOWER(email)) DO UPDATE SET status = 'invited', user_id = NULL, invited_at = NOW()`,
+		paperID, email)
+	if err != nil {
+		return fmt.Errorf("inviting co-speaker: %w", err)
+	}
+	return nil
+}
+
+// deleteCoSpeaker removes a co-speaker, or their invitation, from a paper.
+func deleteCoSpeaker(ctx context.Context, paperID uint32, email string) error {
+	res, err := sqldb.Exec(ctx, `DELETE FROM paper_cospeaker WHERE paper_id = $1 AND LOWER(email) = LOWER($2)`,
+		paperID, email)
+	if err != nil {
+		return fmt.Errorf("removing co-speaker: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	if ra == 0 {
+		return fmt.Errorf("no such co-speaker")
+	}
+	return nil
+}
+
+// updateCoSpeakerStatus records the answer of a user to the invitation sent to their email.
+func updateCoSpeakerStatus(ctx context.Context, paperID, userID uint32, email string, status CoSpeakerStatus) error {
+	res, err := sqldb.Exec(ctx, `UPDATE paper_cospeaker SET user_id = $1, status = $2
+	WHERE paper_id = $3 AND LOWER(email) = LOWER($4)`,
+		userID, string(status), paperID, email)
+	if err != nil {
+		return fmt.Errorf("answering co-speaker invitation: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	if ra == 0 {
+		return fmt.Errorf("no such invitation")
+	}
+	return nil
+}
@@ -33,38 +33,35 @@ func TestAddPaperRoundTrip(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		response, err := AddPaper(ctx, &AddPaperParams{
-			Paper: paper,
-		},
-		)
+		paperID, err := submitPaper(ctx, savedAttendee01.ID, paper)
 		if err != nil {
 			t.Fatalf("unexpected database error: %v", err)
 		}
 
-		result, err := GetPaper(ctx, &GetPaperParams{PaperID: response.PaperID})
+		result, err := readManagedPaper(ctx, savedAttendee01.ID, paperID)
 
 		if err != nil {
 			t.Fatalf("unexpected database error: %v", err)
 		}
 
-		if result.Paper.UserID != paper.UserID {
-			t.Errorf("incorrect UserID returned got %v want %v", result.Paper.UserID, paper.UserID)
+		if result.UserID != paper.UserID {
+			t.Errorf("incorrect UserID returned got %v want %v", result.UserID, paper.UserID)
 		}
 
-		if result.Paper.Title != paper.Title {
-			t.Errorf("incorrect title returned got %v want %v", result.Paper.UserID, paper.UserID)
+		if result.Title != paper.Title {
+			t.Errorf("incorrect title returned got %v want %v", result.UserID, paper.UserID)
 		}
 
-		if result.Paper.ElevatorPitch != paper.ElevatorPitch {
-			t.Errorf("incorrect elevator pitch returned got %v want %v", result.Paper.ElevatorPitch, paper.ElevatorPitch)
+		if result.ElevatorPitch != paper.ElevatorPitch {
+			t.Errorf("incorrect elevator pitch returned got %v want %v", result.ElevatorPitch, paper.ElevatorPitch)
 		}
 
-		if result.Paper.Description != paper.Description {
-			t.Errorf("incorrect description returned got %v want %v", result.Paper.Description, paper.Description)
+		if result.Description != paper.Description {
+			t.Errorf("incorrect description returned got %v want %v", result.Description, paper.Description)
 		}
 
-		if result.Paper.Notes != paper.Notes {
-			t.Errorf("incorrect notes returned got %v want %v", result.Paper.Notes, paper.Notes)
+		if result.Notes != paper.Notes {
+			t.Errorf("incorrect notes returned got %v want %v", result.Notes, paper.Notes)
 		}
 
 	})
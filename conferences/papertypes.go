@@ -1,5 +1,12 @@
 package conferences
 
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
 // Paper holds information about a paper submitted to a conference
 type Paper struct {
 	ID            uint32
@@ -9,4 +16,179 @@ type Paper struct {
 	ElevatorPitch string
 	Description   string
 	Notes         string
+	// Withdrawn papers were pulled out by their speaker and are no longer considered.
+	Withdrawn bool
+	Status    PaperStatus
+	Format    TalkFormat
+	// DurationMinutes is fixed by the format except for workshops.
+	DurationMinutes int
+	AudienceLevel   AudienceLevel
+	// Tracks are the topics of the paper, stored lowercase.
+	Tracks     []string
+	CoSpeakers []CoSpeaker
+}
+
+// TalkFormat is the kind of session a paper is submitted for
+type TalkFormat string
+
+// Talk formats accepted by our conferences
+const (
+	TalkFormatLightning TalkFormat = "lightning"
+	TalkFormatShort     TalkFormat = "short"
+	TalkFormatLong      TalkFormat = "long"
+	TalkFormatWorkshop  TalkFormat = "workshop"
+)
+
+// talkFormatDurations are the default durations in minutes of each format.
+var talkFormatDurations = map[TalkFormat]int{
+	TalkFormatLightning: 5,
+	TalkFormatShort:     25,
+	TalkFormatLong:      45,
+	TalkFormatWorkshop:  180,
+}
+
+const (
+	minWorkshopMinutes = 60
+	maxWorkshopMinutes = 480
+	maxTracks          = 5
+	maxCoSpeakers      = 3
+)
+
+// AudienceLevel is the experience a paper expects from its audience
+type AudienceLevel string
+
+// Audience levels a paper can target
+const (
+	AudienceBeginner     AudienceLevel = "beginner"
+	AudienceIntermediate AudienceLevel = "intermediate"
+	AudienceAdvanced     AudienceLevel = "advanced"
+)
+
+func (l AudienceLevel) valid() bool {
+	switch l {
+	case AudienceBeginner, AudienceIntermediate, AudienceAdvanced:
+		return true
+	}
+	return false
+}
+
+// CoSpeakerStatus tracks an invitation to present a paper with its speaker
+type CoSpeakerStatus string
+
+// Co-speaker invitation statuses
+const (
+	CoSpeakerInvited  CoSpeakerStatus = "invited"
+	CoSpeakerAccepted CoSpeakerStatus = "accepted"
+	CoSpeakerDeclined CoSpeakerStatus = "declined"
+)
+
+// CoSpeaker is someone invited to present a paper along its speaker, UserID is set once they
+// respond to the invitation
+type CoSpeaker struct {
+	Email  string
+	UserID uint32
+	Status CoSpeakerStatus
+}
+
+// isSpeaker returns true if the user submitted the paper or accepted to co-present it.
+func (p *Paper) isSpeaker(userID uint32) bool {
+	if p.UserID == userID {
+		return true
+	}
+	for _, c := range p.CoSpeakers {
+		if c.UserID == userID && c.Status == CoSpeakerAccepted {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeDetails fills in the default format, duration and audience level, cleans up tracks
+// and returns an error if the result is not valid.
+func (p *Paper) normalizeDetails() error {
+	if p.Format == "" {
+		p.Format = TalkFormatLong
+	}
+	duration, ok := talkFormatDurations[p.Format]
+	if !ok {
+		return fmt.Errorf("unknown talk format %q", p.Format)
+	}
+	switch {
+	case p.DurationMinutes == 0:
+		p.DurationMinutes = duration
+	case p.Format == TalkFormatWorkshop:
+		if p.DurationMinutes < minWorkshopMinutes || p.DurationMinutes > maxWorkshopMinutes {
+			return fmt.Errorf("workshops must last between %d and %d minutes", minWorkshopMinutes, maxWorkshopMinutes)
+		}
+	case p.DurationMinutes != duration:
+		return fmt.Errorf("%s talks last %d minutes", p.Format, duration)
+	}
+
+	if p.AudienceLevel == "" {
+		p.AudienceLevel = AudienceIntermediate
+	}
+	if !p.AudienceLevel.valid() {
+		return fmt.Errorf("unknown audience level %q", p.AudienceLevel)
+	}
+
+	tracks := []string{}
+	seen := map[string]bool{}
+	for _, t := range p.Tracks {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		tracks = append(tracks, t)
+	}
+	if len(tracks) > maxTracks {
+		return fmt.Errorf("a paper can have at most %d tracks", maxTracks)
+	}
+	p.Tracks = tracks
+	return nil
+}
+
+// CFPRules defines when a conference call for papers is open and what a submission must look like.
+// A zero limit means there is no such limit.
+type CFPRules struct {
+	ConferenceID             uint32
+	OpensAt                  time.Time
+	ClosesAt                 time.Time
+	MaxSubmissionsPerSpeaker int
+	TitleMaxLength           int
+	ElevatorPitchMaxLength   int
+	DescriptionMinLength     int
+	DescriptionMaxLength     int
+}
+
+// Open returns true if submissions are accepted at the passed time.
+func (r *CFPRules) Open(now time.Time) bool {
+	return !now.Before(r.OpensAt) && now.Before(r.ClosesAt)
+}
+
+// validatePaper returns an error describing the first rule the paper breaks.
+func (r *CFPRules) validatePaper(p *Paper) error {
+	fields := []struct {
+		name      string
+		value     string
+		minLength int
+		maxLength int
+	}{
+		{"title", p.Title, 1, r.TitleMaxLength},
+		{"elevator pitch", p.ElevatorPitch, 1, r.ElevatorPitchMaxLength},
+		{"description", p.Description, r.DescriptionMinLength, r.DescriptionMaxLength},
+	}
+	for _, f := range fields {
+		length := utf8.RuneCountInString(strings.TrimSpace(f.value))
+		if length == 0 {
+			return fmt.Errorf("%s is required", f.name)
+		}
+		if length < f.minLength {
+			return fmt.Errorf("%s must be at least %d characters long", f.name, f.minLength)
+		}
+		if f.maxLength > 0 && length > f.maxLength {
+			return fmt.Errorf("%s must be at most %d characters long", f.name, f.maxLength)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,52 @@
+package conferences
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPaperNormalizeDetails(t *testing.T) {
+	tests := []struct {
+		name         string
+		paper        Paper
+		wantDuration int
+		wantTracks   []string
+		wantErr      bool
+	}{
+		{name: "defaults to a long talk", paper: Paper{}, wantDuration: 45, wantTracks: []string{}},
+		{name: "lightning talk", paper: Paper{Format: TalkFormatLightning}, wantDuration: 5, wantTracks: []string{}},
+		{name: "talk duration is fixed", paper: Paper{Format: TalkFormatShort, DurationMinutes: 40}, wantErr: true},
+		{name: "workshop duration", paper: Paper{Format: TalkFormatWorkshop, DurationMinutes: 120}, wantDuration: 120, wantTracks: []string{}},
+		{name: "workshop too long", paper: Paper{Format: TalkFormatWorkshop, DurationMinutes: 600}, wantErr: true},
+		{name: "unknown format", paper: Paper{Format: "keynote"}, wantErr: true},
+		{name: "unknown audience level", paper: Paper{AudienceLevel: "expert"}, wantErr: true},
+		{
+			name:         "tracks are cleaned up",
+			paper:        Paper{Tracks: []string{" Web ", "web", "", "Tooling"}},
+			wantDuration: 45,
+			wantTracks:   []string{"web", "tooling"},
+		},
+		{name: "too many tracks", paper: Paper{Tracks: []string{"a", "b", "c", "d", "e", "f"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paper := tt.paper
+			err := paper.normalizeDetails()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeDetails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if paper.DurationMinutes != tt.wantDuration {
+				t.Errorf("incorrect duration got %v want %v", paper.DurationMinutes, tt.wantDuration)
+			}
+			if paper.AudienceLevel != AudienceIntermediate {
+				t.Errorf("incorrect audience level got %v want %v", paper.AudienceLevel, AudienceIntermediate)
+			}
+			if !reflect.DeepEqual(paper.Tracks, tt.wantTracks) {
+				t.Errorf("incorrect tracks got %v want %v", paper.Tracks, tt.wantTracks)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"encore.dev/storage/sqldb"
+	"github.com/lib/pq"
+)
+
+// PersonalData bundles everything we hold about a user.
+type PersonalData struct {
+	User     User
+	Claims   []SlotClaim
+	Papers   []Paper
+	Payments []PersonalDataPayment
+	// ReportedIncidents holds the CoC reports filed by the user, without the CoC team notes.
+	ReportedIncidents []Incident
+	SpeakerProfile    SpeakerProfile
+	Travel            []TravelNeeds
+	Payables          []SpeakerPayable
+	Bookmarks         []ConferenceSlot
+	// Feedback holds the ratings the user gave to sessions.
+	Feedback []SessionFeedback
+}
+
+// PersonalDataPayment summarizes a payment covering claims held by a user.
+type PersonalDataPayment struct {
+	ID            uint64
+	Invoice       string
+	SlotClaimIDs  []int64
+	MoneyCents    int64
+	DiscountCents int64
+	CreditCents   int64
+}
+
+// readPersonalData collects everything stored about the passed user, nil if there is no such user.
+func readPersonalData(ctx context.Context, userID uint32) (*PersonalData, error) {
+	data := PersonalData{}
+	var givenName, familyName sql.NullString
+	var createdAt sql.NullTime
+
+	row := sqldb.QueryRow(ctx, `SELECT id, email, coc_accepted, given_name, family_name, created_at, flagged
+	FROM users WHERE id = $1`, userID)
+	err := row.Scan(&data.User.ID,
+		&data.User.Email,
+		&data.User.CoCAccepted,
+		&givenName,
+		&familyName,
+		&createdAt,
+		&data.User.Flagged)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading user: %w", err)
+	}
+	data.User.GivenName = givenName.String
+	data.User.FamilyName = familyName.String
+	data.User.CreatedAt = createdAt.Time
+
+	rows, err := sqldb.Query(ctx, `SELECT slot_claim.id, slot_claim.ticket_id, slot_claim.redeemed, slot_claim.revoked,
+	conference_slot.id, conference_slot.name, conference_slot.start_date, conference_slot.end_date, conference_slot.conference_id
+	FROM slot_claim
+	JOIN conference_slot ON slot_claim.conference_slot_id = conference_slot.id
+	WHERE slot_claim.user_id = $1
+	ORDER BY slot_claim.id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying claims: %w", err)
+	}
+	defer rows.Close()
+
+	data.Claims = []SlotClaim{}
+	for rows.Next() {
+		claim := SlotClaim{ConferenceSlot: &ConferenceSlot{}}
+		err := rows.Scan(&claim.ID,
+			&claim.TicketID,
+			&claim.Redeemed,
+			&claim.Revoked,
+			&claim.ConferenceSlot.ID,
+			&claim.ConferenceSlot.Name,
+			&claim.ConferenceSlot.StartDate,
+			&claim.ConferenceSlot.EndDate,
+			&claim.ConferenceSlot.ConferenceID)
+		if err != nil {
+			return nil, fmt.Errorf("scanning claim: %w", err)
+		}
+		data.Claims = append(data.Claims, claim)
+	}
+
+	data.Papers, err = readPapersByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err = sqldb.Query(ctx, `SELECT claim_payment.id, claim_payment.invoice,
+	ARRAY_AGG(claim_payment_slot_claim.slot_claim_id ORDER BY claim_payment_slot_claim.slot_claim_id),
+	(SELECT COALESCE(SUM(amount_cents), 0) FROM payment_method_money WHERE claim_payment_id = claim_payment.id),
+	(SELECT COALESCE(SUM(amount_cents), 0) FROM payment_method_conference_discount WHERE claim_payment_id = claim_payment.id),
+	(SELECT COALESCE(SUM(amount_cents), 0) FROM payment_method_credit_note WHERE claim_payment_id = claim_payment.id)
+	FROM claim_payment
+	JOIN claim_payment_slot_claim ON claim_payment_slot_claim.claim_payment_id = claim_payment.id
+	JOIN slot_claim ON claim_payment_slot_claim.slot_claim_id = slot_claim.id
+	WHERE slot_claim.user_id = $1
+	GROUP BY claim_payment.id
+	ORDER BY claim_payment.id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying payments: %w", err)
+	}
+	defer rows.Close()
+
+	data.Payments = []PersonalDataPayment{}
+	for rows.Next() {
+		var payment PersonalDataPayment
+		var claimIDs pq.Int64Array
+		err := rows.Scan(&payment.ID,
+			&payment.Invoice,
+			&claimIDs,
+			&payment.MoneyCents,
+			&payment.DiscountCents,
+			&payment.CreditCents)
+		if err != nil {
+			return nil, fmt.Errorf("scanning payment: %w", err)
+		}
+		payment.SlotClaimIDs = claimIDs
+		data.Payments = append(data.Payments, payment)
+	}
+
+	rows, err = sqldb.Query(ctx, `SELECT `+incidentColumns+` FROM coc_incident
+	WHERE reporter_id = $1 ORDER BY id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying reported incidents: %w", err)
+	}
+	defer rows.Close()
+
+	data.ReportedIncidents = []Incident{}
+	for rows.Next() {
+		incident, err := scanIncident(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning reported incident: %w", err)
+		}
+		data.ReportedIncidents = append(data.ReportedIncidents, *incident)
+	}
+
+	profile, err := readSpeakerProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	data.SpeakerProfile = *profile
+
+	rows, err = sqldb.Query(ctx, `SELECT conference_id, user_id, needs_travel, departure_city, arrive_on, leave_on,
+	needs_hotel, notes FROM speaker_travel WHERE user_id = $1 ORDER BY conference_id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying travel needs: %w", err)
+	}
+	defer rows.Close()
+
+	data.Travel = []TravelNeeds{}
+	for rows.Next() {
+		t := TravelNeeds{}
+		var arriveOn, leaveOn sql.NullTime
+		err := rows.Scan(&t.ConferenceID,
+			&t.UserID,
+			&t.NeedsTravel,
+			&t.DepartureCity,
+			&arriveOn,
+			&leaveOn,
+			&t.NeedsHotel,
+			&t.Notes)
+		if err != nil {
+			return nil, fmt.Errorf("scanning travel needs: %w", err)
+		}
+		t.ArriveOn = arriveOn.Time
+		t.LeaveOn = leaveOn.Time
+		data.Travel = append(data.Travel, t)
+	}
+
+	rows, err = sqldb.Query(ctx, `SELECT `+speakerPayableColumns+` FROM speaker_payable
+	WHERE user_id = $1 ORDER BY id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying speaker payables: %w", err)
+	}
+	defer rows.Close()
+
+	data.Payables = []SpeakerPayable{}
+	for rows.Next() {
+		p, err := scanSpeakerPayable(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning speaker payable: %w", err)
+		}
+		data.Payables = append(data.Payables, *p)
+	}
+
+	data.Bookmarks, err = readBookmarkedSlots(ctx, userID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err = sqldb.Query(ctx, `SELECT id, conference_slot_id, COALESCE(paper_id, 0), user_id, rating, comment, created_at
+	FROM session_feedback WHERE user_id = $1 ORDER BY created_at, id`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying session feedback: %w", err)
+	}
+	defer rows.Close()
+
+	data.Feedback = []SessionFeedback{}
+	for rows.Next() {
+		f := SessionFeedback{}
+		err := rows.Scan(&f.ID, &f.ConferenceSlotID, &f.PaperID, &f.UserID, &f.Rating, &f.Comment, &f.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("scanning session feedback: %w", err)
+		}
+		data.Feedback = append(data.Feedback, f)
+	}
+
+	return &data, nil
+}
+
+// erasedEmail is the placeholder email of an erased user, it is unique and not deliverable.
+func erasedEmail(userID uint32) string {
+	return fmt.Sprintf("erased-%d@erased.invalid", userID)
+}
+
+// erasePersonalData anonymizes a user. Claims and payments are kept, as accounting needs them,
+// but they are left pointing to an anonymous user.
+func erasePersonalData(ctx context.Context, tx *sqldb.Tx, userID uint32, erasedAt time.Time) error {
+	statements := []struct {
+		sql  string
+		args []interface{}
+		// mustMatch statements fail the erasure when they affect no rows.
+		mustMatch bool
+	}{
+		// Invitations to co-present are matched by email, so they go before the email does.
+		{`DELETE FROM paper_cospeaker WHERE user_id = $1 OR LOWER(email) = (SELECT LOWER(email) FROM users WHERE id = $1)`,
+			[]interface{}{userID}, false},
+		// Login attempts are recorded by email, not by user.
+		{`DELETE FROM login_attempt WHERE email = (SELECT LOWER(email) FROM users WHERE id = $1)`,
+			[]interface{}{userID}, false},
+		{`UPDATE users SET email = $1, given_name = NULL, family_name = NULL, hashed_password = NULL,
+		created_at = NULL, oidc_subject = NULL, erased_at = $2, session_version = session_version + 1 WHERE id = $3`, []interface{}{erasedEmail(userID), erasedAt, userID}, true},
+		// Accepted and scheduled talks stay in the program under the anonymous user, deleting them
+		// would drop them from the schedule along with their reviews and feedback.
+		{`DELETE FROM paper_submission WHERE user_id = $1 AND status NOT IN ('accepted', 'confirmed')
+		AND id NOT IN (SELECT paper_id FROM schedule_entry)`, []interface{}{userID}, false},
+		{`UPDATE paper_submission SET notes = '' WHERE user_id = $1`, []interface{}{userID}, false},
+		{`DELETE FROM user_role WHERE user_id = $1`, []interface{}{userID}, false},
+		{`DELETE FROM auth_token WHERE user_id = $1`, []interface{}{userID}, false},
+		{`DELETE FROM speaker_profile WHERE user_id = $1`, []interface{}{userID}, false},
+		{`DELETE FROM calendar_feed_token WHERE user_id = $1`, []interface{}{userID}, false},
+		{`DELETE FROM agenda_bookmark WHERE user_id = $1`, []interface{}{userID}, false},
+		// Payables to speakers are accounting records and stay, their travel plans do not.
+		{`DELETE FROM speaker_travel WHERE user_id = $1`, []interface{}{userID}, false},
+		// Ratings keep counting towards the speakers, the attendee who gave them becomes anonymous.
+		{`UPDATE session_feedback SET user_id = NULL WHERE user_id = $1`, []interface{}{userID}, false},
+		// Uses of the tickets of sponsors must not tell who holds them.
+		{`UPDATE sponsor_entitlement_use SET note = '' WHERE slot_claim_id IN (SELECT id FROM slot_claim WHERE user_id = $1)`,
+			[]interface{}{userID}, false},
+		// Sponsor contacts are the sponsor's, the erased user no longer manages it.
+		{`UPDATE sponsor_contact_information SET user_id = NULL WHERE user_id = $1`, []interface{}{userID}, false},
+		// Reports stay with the CoC team, the reporter becomes anonymous.
+		{`UPDATE coc_incident SET reporter_id = NULL, contact = '' WHERE reporter_id = $1`, []interface{}{userID}, false},
+	}
+
+	for _, statement := range statements {
+		var res sql.Result
+		var err error
+		if tx != nil {
+			res, err = sqldb.ExecTx(tx, ctx, statement.sql, statement.args...)
+		} else {
+			res, err = sqldb.Exec(ctx, statement.sql, statement.args...)
+		}
+		if err != nil {
+			return fmt.Errorf("erasing personal data: %w", err)
+		}
+		if !statement.mustMatch {
+			continue
+		}
+		ra, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get number of rows affected by query: %w", err)
+		}
+		if ra == 0 {
+			return fmt.Errorf("no such user")
+		}
+	}
+	return nil
+}
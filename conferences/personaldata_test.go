@@ -0,0 +1,141 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+func TestExportAndErasePersonalData(t *testing.T) {
+	ctx := context.Background()
+
+	user, err := createAttendee(ctx, nil, &User{Email: "erasable@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	_, err = sqldb.Exec(ctx, `UPDATE users SET given_name = 'Erin', family_name = 'Erasable', hashed_password = 'hash', created_at = NOW()
+	WHERE id = $1`, user.ID)
+	assertDatabaseError(t, err)
+
+	// There is an entry for general admision to gophercon 2021 preloaded in the first migration
+	cslot, err := readConferenceSlotByID(ctx, nil, 1, false)
+	assertDatabaseError(t, err)
+	claims, err := claimSlots(ctx, user, []ConferenceSlot{*cslot})
+	assertDatabaseError(t, err)
+	payment, err := payClaims(ctx, user, claims, []FinancialInstrument{
+		&PaymentMethodMoney{PaymentRef: "somethingbystripe", AmountCents: 400},
+	})
+	assertDatabaseError(t, err)
+
+	_, err = submitPaper(ctx, user.ID, &Paper{
+		ConferenceID:  1,
+		Title:         "Erin talks about Erin",
+		ElevatorPitch: "A talk by Erin Erasable",
+		Description:   "Contact me at erasable@gophercon.com",
+	})
+	assertDatabaseError(t, err)
+	_, err = bookmarkSlot(ctx, user.ID, cslot.ID)
+	assertDatabaseError(t, err)
+
+	organizer := createOrganizer(t, ctx, "erasure-organizer@gophercon.com")
+	talkID, err := submitPaper(ctx, user.ID, &Paper{
+		ConferenceID:  1,
+		Title:         "Forgetting things",
+		ElevatorPitch: "On erasure",
+		Description:   "How to forget",
+		Notes:         "Call me on my personal phone",
+		Format:        TalkFormatShort,
+	})
+	assertDatabaseError(t, err)
+	assertDatabaseError(t, updatePaperStatus(ctx, nil, talkID, PaperStatusAccepted))
+	start := time.Date(2020, 11, 12, 9, 0, 0, 0, time.UTC)
+	row := sqldb.QueryRow(ctx, `INSERT INTO conference_slot (name, description, cost, capacity, start_date, end_date,
+	purchaseable_from, purchaseable_until, available_to_public, conference_id, location_id)
+	VALUES ('Erasure talks', 'Talks', 0, 100, $1, $2, $1, $1, FALSE, 1, 1) RETURNING id`, start, start.Add(time.Hour))
+	var talkSlotID uint32
+	assertDatabaseError(t, row.Scan(&talkSlotID))
+	entry, _, err := scheduleTalk(ctx, organizer.ID, ScheduleEntry{PaperID: talkID, ConferenceSlotID: talkSlotID}, true)
+	assertDatabaseError(t, err)
+
+	t.Run("exports everything held about the user", func(t *testing.T) {
+		data, err := readPersonalData(ctx, user.ID)
+		assertDatabaseError(t, err)
+
+		if data.User.GivenName != "Erin" || data.User.FamilyName != "Erasable" {
+			t.Errorf("incorrect names exported got %v %v", data.User.GivenName, data.User.FamilyName)
+		}
+		if len(data.Claims) != 1 {
+			t.Errorf("incorrect number of claims exported got %v want %v", len(data.Claims), 1)
+		}
+		if len(data.Papers) != 2 {
+			t.Errorf("incorrect number of papers exported got %v want %v", len(data.Papers), 2)
+		}
+		if len(data.Payments) != 1 || data.Payments[0].MoneyCents != 400 {
+			t.Errorf("incorrect payments exported got %+v", data.Payments)
+		}
+		if len(data.Bookmarks) != 1 || data.Bookmarks[0].ID != cslot.ID {
+			t.Errorf("incorrect bookmarks exported got %+v", data.Bookmarks)
+		}
+	})
+
+	t.Run("refuses to erase without the right confirmation", func(t *testing.T) {
+		if err := eraseAccount(ctx, user.ID, "someoneelse@gophercon.com"); err == nil {
+			t.Fatalf("wrong confirmation email did not cause an error")
+		}
+	})
+
+	t.Run("erasure leaves no PII but keeps financial records", func(t *testing.T) {
+		assertDatabaseError(t, insertLoginAttempt(ctx, user.Email, false, time.Now()))
+		assertDatabaseError(t, insertAuthToken(ctx, nil, "erasable-token", user.ID, authTokenResetPassword, time.Now().Add(time.Hour)))
+		assertDatabaseError(t, eraseAccount(ctx, user.ID, user.Email))
+
+		var email string
+		var givenName, familyName, hashedPassword sql.NullString
+		row := sqldb.QueryRow(ctx, `SELECT email, given_name, family_name, hashed_password FROM users WHERE id = $1`, user.ID)
+		assertDatabaseError(t, row.Scan(&email, &givenName, &familyName, &hashedPassword))
+		if strings.Contains(email, "erasable") {
+			t.Errorf("email was not erased got %v", email)
+		}
+		if givenName.Valid || familyName.Valid || hashedPassword.Valid {
+			t.Errorf("personal fields were not erased got %v %v %v", givenName, familyName, hashedPassword)
+		}
+
+		var attempts, tokens int
+		row = sqldb.QueryRow(ctx, `SELECT (SELECT COUNT(*) FROM login_attempt WHERE email = 'erasable@gophercon.com'),
+		(SELECT COUNT(*) FROM auth_token WHERE user_id = $1)`, user.ID)
+		assertDatabaseError(t, row.Scan(&attempts, &tokens))
+		if attempts != 0 || tokens != 0 {
+			t.Errorf("login attempts and auth tokens were not erased got %v %v", attempts, tokens)
+		}
+
+		var papers int
+		row = sqldb.QueryRow(ctx, `SELECT COUNT(*) FROM paper_submission WHERE user_id = $1 AND id != $2`, user.ID, talkID)
+		assertDatabaseError(t, row.Scan(&papers))
+		if papers != 0 {
+			t.Errorf("papers were not erased got %v want 0", papers)
+		}
+
+		var notes string
+		var scheduled bool
+		row = sqldb.QueryRow(ctx, `SELECT notes, EXISTS(SELECT 1 FROM schedule_entry WHERE id = $2)
+		FROM paper_submission WHERE id = $1`, talkID, entry.ID)
+		assertDatabaseError(t, row.Scan(&notes, &scheduled))
+		if notes != "" {
+			t.Errorf("notes of the scheduled talk were not erased got %v", notes)
+		}
+		if !scheduled {
+			t.Errorf("the talk of the erased speaker was dropped from the schedule")
+		}
+
+		data, err := readPersonalData(ctx, user.ID)
+		assertDatabaseError(t, err)
+		if len(data.Claims) != 1 {
+			t.Errorf("claims should be kept for accounting got %v want %v", len(data.Claims), 1)
+		}
+		if len(data.Payments) != 1 || data.Payments[0].ID != payment.ID {
+			t.Errorf("payments should be kept for accounting got %+v", data.Payments)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RecordSpeakerPayableParams defines the inputs used by the RecordSpeakerPayable API method
+type RecordSpeakerPayableParams struct {
+	Payable *SpeakerPayable
+}
+
+// RecordSpeakerPayableResponse defines the output returned by the RecordSpeakerPayable API method
+type RecordSpeakerPayableResponse struct {
+	Payable *SpeakerPayable
+}
+
+// RecordSpeakerPayable adds an honorarium or travel grant owed to a speaker, only organizers can
+// do so
+// encore:api auth
+func RecordSpeakerPayable(ctx context.Context, params *RecordSpeakerPayableParams) (*RecordSpeakerPayableResponse, error) {
+	if params.Payable == nil {
+		return nil, fmt.Errorf("Payable is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	payable, err := recordSpeakerPayable(ctx, userID, params.Payable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record speaker payable: %w", err)
+	}
+
+	return &RecordSpeakerPayableResponse{Payable: payable}, nil
+}
+
+// SettleSpeakerPayableParams defines the inputs used by the SettleSpeakerPayable API method
+type SettleSpeakerPayableParams struct {
+	PayableID uint32
+	// PaymentRef identifies the transfer that paid the speaker.
+	PaymentRef string
+}
+
+// SettleSpeakerPayableResponse defines the output returned by the SettleSpeakerPayable API method
+type SettleSpeakerPayableResponse struct {
+	Payable *SpeakerPayable
+}
+
+// SettleSpeakerPayable marks a payable as paid, only organizers can do so
+// encore:api auth
+func SettleSpeakerPayable(ctx context.Context, params *SettleSpeakerPayableParams) (*SettleSpeakerPayableResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	payable, err := settleSpeakerPayable(ctx, userID, params.PayableID, params.PaymentRef, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to settle speaker payable: %w", err)
+	}
+
+	return &SettleSpeakerPayableResponse{Payable: payable}, nil
+}
@@ -0,0 +1,46 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// RegisterParams defines the inputs used by the Register API method
+type RegisterParams struct {
+	Email       string
+	Password    string
+	GivenName   string
+	FamilyName  string
+	CoCAccepted bool
+}
+
+// Register creates a user that logs in with a password and emails them a verification code, an
+// email that is already registered gets a notice instead and the response is the same
+// encore:api public
+func Register(ctx context.Context, params *RegisterParams) error {
+	_, _, err := registerLocalUser(ctx, &User{
+		Email:       params.Email,
+		GivenName:   params.GivenName,
+		FamilyName:  params.FamilyName,
+		CoCAccepted: params.CoCAccepted,
+	}, params.Password)
+	if err != nil {
+		return fmt.Errorf("failed to register: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyEmailParams defines the inputs used by the VerifyEmail API method
+type VerifyEmailParams struct {
+	Token string
+}
+
+// VerifyEmail confirms a user owns their email using the code we emailed them
+// encore:api public
+func VerifyEmail(ctx context.Context, params *VerifyEmailParams) error {
+	if err := verifyEmail(ctx, params.Token); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+	return nil
+}
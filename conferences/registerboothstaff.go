@@ -0,0 +1,67 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// RegisterBoothStaffParams defines the inputs used by the RegisterBoothStaff API method
+type RegisterBoothStaffParams struct {
+	SponsorID     uint32
+	EntitlementID uint32
+	// Emails are the staff to issue a ticket to, one each. Attendees are created for the emails
+	// that are not known yet.
+	Emails []string
+}
+
+// RegisterBoothStaffResponse defines the output returned by the RegisterBoothStaff API method
+type RegisterBoothStaffResponse struct {
+	Claims []SlotClaim
+}
+
+// RegisterBoothStaff issues the complimentary tickets of a sponsor the authenticated user is a
+// contact of to its staff
+// encore:api auth
+func RegisterBoothStaff(ctx context.Context, params *RegisterBoothStaffParams) (*RegisterBoothStaffResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := registerBoothStaff(ctx, userID, params.SponsorID, params.EntitlementID, params.Emails)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register booth staff: %w", err)
+	}
+
+	return &RegisterBoothStaffResponse{Claims: claims}, nil
+}
+
+// PostSponsorJobParams defines the inputs used by the PostSponsorJob API method
+type PostSponsorJobParams struct {
+	SponsorID     uint32
+	EntitlementID uint32
+	// Job is listed under the name of the sponsor, its company name and rank are not used.
+	Job Job
+}
+
+// PostSponsorJobResponse defines the output returned by the PostSponsorJob API method
+type PostSponsorJobResponse struct {
+	Job *Job
+}
+
+// PostSponsorJob posts a job of a sponsor the authenticated user is a contact of using one of its
+// job post entitlements, it is listed once approved
+// encore:api auth
+func PostSponsorJob(ctx context.Context, params *PostSponsorJobParams) (*PostSponsorJobResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := postSponsorJob(ctx, userID, params.SponsorID, params.EntitlementID, params.Job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post sponsor job: %w", err)
+	}
+
+	return &PostSponsorJobResponse{Job: job}, nil
+}
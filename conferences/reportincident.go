@@ -0,0 +1,44 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+
+	"encore.dev/beta/auth"
+)
+
+// ReportIncidentParams defines the inputs used by the ReportIncident API method
+type ReportIncidentParams struct {
+	Incident *Incident
+	// Anonymous hides the identity of an authenticated reporter.
+	Anonymous bool
+}
+
+// ReportIncidentResponse defines the output returned by the ReportIncident API method
+type ReportIncidentResponse struct {
+	IncidentID uint32
+}
+
+// ReportIncident files a Code of Conduct incident report, it can be done anonymously
+// encore:api public
+func ReportIncident(ctx context.Context, params *ReportIncidentParams) (*ReportIncidentResponse, error) {
+	if params.Incident == nil {
+		return nil, fmt.Errorf("Incident is required")
+	}
+
+	var reporterID uint32
+	if _, ok := auth.UserID(); ok && !params.Anonymous {
+		id, err := authenticatedUserID()
+		if err != nil {
+			return nil, err
+		}
+		reporterID = id
+	}
+
+	incident, err := fileIncident(ctx, params.Incident, reporterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to report incident: %w", err)
+	}
+
+	return &ReportIncidentResponse{IncidentID: incident.ID}, nil
+}
@@ -0,0 +1,35 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// RequestPasswordResetParams defines the inputs used by the RequestPasswordReset API method
+type RequestPasswordResetParams struct {
+	Email string
+}
+
+// RequestPasswordReset emails a one-time password reset code
+// encore:api public
+func RequestPasswordReset(ctx context.Context, params *RequestPasswordResetParams) error {
+	if _, err := requestPasswordReset(ctx, params.Email); err != nil {
+		return fmt.Errorf("failed to request password reset: %w", err)
+	}
+	return nil
+}
+
+// ResetPasswordParams defines the inputs used by the ResetPassword API method
+type ResetPasswordParams struct {
+	Token       string
+	NewPassword string
+}
+
+// ResetPassword sets a new password using the code emailed by RequestPasswordReset
+// encore:api public
+func ResetPassword(ctx context.Context, params *ResetPasswordParams) error {
+	if err := resetPassword(ctx, params.Token, params.NewPassword); err != nil {
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+	return nil
+}
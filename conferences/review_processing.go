@@ -0,0 +1,180 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"encore.dev/storage/sqldb"
+)
+
+// setRubric replaces the scoring rubric of a conference.
+func setRubric(ctx context.Context, conferenceID uint32, rubric []ScoringCriterion) ([]ScoringCriterion, error) {
+	if len(rubric) == 0 {
+		return nil, fmt.Errorf("a rubric needs at least one criterion")
+	}
+	for _, c := range rubric {
+		if strings.TrimSpace(c.Name) == "" {
+			return nil, fmt.Errorf("criteria need a name")
+		}
+		if c.Weight <= 0 || c.MaxScore <= 0 {
+			return nil, fmt.Errorf("criterion %s needs a positive weight and max score", c.Name)
+		}
+	}
+
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	saved, err := replaceRubric(ctx, tx, conferenceID, rubric)
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return nil, fmt.Errorf("setting rubric: %w", err)
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return saved, nil
+}
+
+// reviewPaper saves the review of a paper by an assigned reviewer, the first review moves the
+// paper into review.
+func reviewPaper(ctx context.Context, reviewerID uint32, review *Review) (*Review, error) {
+	paper, err := readPaperByID(ctx, nil, review.PaperID)
+	if err != nil {
+		return nil, err
+	}
+	if paper == nil || paper.Withdrawn {
+		return nil, fmt.Errorf("no such paper")
+	}
+	coSpeakers, err := readCoSpeakers(ctx, []uint32{paper.ID})
+	if err != nil {
+		return nil, err
+	}
+	paper.CoSpeakers = coSpeakers[paper.ID]
+	if paper.isSpeaker(reviewerID) {
+		return nil, fmt.Errorf("reviewers cannot review their own papers")
+	}
+	if paper.Status != PaperStatusSubmitted && paper.Status != PaperStatusInReview {
+		return nil, fmt.Errorf("paper is %s and can no longer be reviewed", paper.Status)
+	}
+	assigned, err := isAssignedReviewer(ctx, paper.ConferenceID, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !assigned {
+		return nil, fmt.Errorf("user %d is not a reviewer for this conference", reviewerID)
+	}
+
+	rubric, err := readRubric(ctx, nil, paper.ConferenceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rubric) == 0 {
+		return nil, fmt.Errorf("the conference has no scoring rubric yet")
+	}
+	if len(review.Scores) != len(rubric) {
+		return nil, fmt.Errorf("every criterion in the rubric must be scored")
+	}
+	for _, c := range rubric {
+		score, ok := review.Scores[c.ID]
+		if !ok {
+			return nil, fmt.Errorf("criterion %s was not scored", c.Name)
+		}
+		if score < 0 || score > c.MaxScore {
+			return nil, fmt.Errorf("criterion %s must be scored between 0 and %d", c.Name, c.MaxScore)
+		}
+	}
+
+	review.ReviewerID = reviewerID
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	saved, err := upsertReview(ctx, tx, review)
+	if err == nil && paper.Status == PaperStatusSubmitted {
+		err = updatePaperStatus(ctx, tx, paper.ID, PaperStatusInReview)
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return nil, fmt.Errorf("reviewing paper: %w", err)
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return saved, nil
+}
+
+// paperRanking returns the papers of a conference ranked by their reviews.
+func paperRanking(ctx context.Context, conferenceID uint32) ([]PaperRanking, error) {
+	papers, err := readPapersByConference(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+	rubric, err := readRubric(ctx, nil, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := readReviewsByConference(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+	return rankPapers(papers, rubric, reviews), nil
+}
+
+// changePaperStatus moves a paper through the selection process.
+func changePaperStatus(ctx context.Context, paperID uint32, status PaperStatus) error {
+	paper, err := readPaperByID(ctx, nil, paperID)
+	if err != nil {
+		return err
+	}
+	if paper == nil {
+		return fmt.Errorf("no such paper")
+	}
+	if paper.Withdrawn {
+		return fmt.Errorf("withdrawn papers cannot change status")
+	}
+	if status == PaperStatusConfirmed {
+		return fmt.Errorf("papers are confirmed by their speakers")
+	}
+	if err := paper.Status.canTransition(status); err != nil {
+		return err
+	}
+	return updatePaperStatus(ctx, nil, paperID, status)
+}
+
+// papersForReview returns the papers of a conference as the program committee should see them.
+func papersForReview(ctx context.Context, userID, conferenceID uint32) ([]BlindPaper, error) {
+	assigned, err := isAssignedReviewer(ctx, conferenceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !assigned {
+		if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+			return nil, err
+		}
+	}
+
+	settings, err := readReviewSettings(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+	papers, err := readPapersByConference(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+	speakers, err := readSpeakerIdentities(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	blind := make([]BlindPaper, 0, len(papers))
+	for _, p := range papers {
+		blind = append(blind, blindPaper(p, *settings, speakers[p.ID]))
+	}
+	return blind, nil
+}
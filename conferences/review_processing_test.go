@@ -0,0 +1,96 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestReviewWorkflow(t *testing.T) {
+	ctx := context.Background()
+
+	speaker, err := createAttendee(ctx, nil, &User{Email: "reviewedspeaker@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	reviewer, err := createAttendee(ctx, nil, &User{Email: "reviewer@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	outsider, err := createAttendee(ctx, nil, &User{Email: "notareviewer@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	assertDatabaseError(t, assignReviewer(ctx, 2, reviewer.ID))
+
+	rubric, err := setRubric(ctx, 2, []ScoringCriterion{
+		{Name: "Relevance", Description: "Is it useful to gophers?", Weight: 2, MaxScore: 5},
+		{Name: "Clarity", Description: "Is the proposal clear?", Weight: 1, MaxScore: 5},
+	})
+	assertDatabaseError(t, err)
+
+	paperID, err := submitPaper(ctx, speaker.ID, &Paper{
+		ConferenceID:  2,
+		Title:         "Reviewing reviews",
+		ElevatorPitch: "Meta",
+		Description:   "A talk about reviewing talks",
+	})
+	assertDatabaseError(t, err)
+
+	scores := map[uint32]int{rubric[0].ID: 5, rubric[1].ID: 4}
+
+	t.Run("only assigned reviewers can review", func(t *testing.T) {
+		if _, err := reviewPaper(ctx, outsider.ID, &Review{PaperID: paperID, Scores: scores}); err == nil {
+			t.Fatalf("review by an unassigned user did not cause an error")
+		}
+	})
+
+	t.Run("co-speakers cannot review the paper they present", func(t *testing.T) {
+		lead, err := createAttendee(ctx, nil, &User{Email: "leadspeaker@gophercon.com", CoCAccepted: true})
+		assertDatabaseError(t, err)
+		sharedID, err := submitPaper(ctx, lead.ID, &Paper{
+			ConferenceID:  2,
+			Title:         "Presenting together",
+			ElevatorPitch: "Two voices",
+			Description:   "A talk given with a reviewer",
+		})
+		assertDatabaseError(t, err)
+		assertDatabaseError(t, inviteCoSpeaker(ctx, lead.ID, sharedID, reviewer.Email))
+		assertDatabaseError(t, answerCoSpeakerInvitation(ctx, reviewer.ID, sharedID, true))
+
+		if _, err := reviewPaper(ctx, reviewer.ID, &Review{PaperID: sharedID, Scores: scores}); err == nil {
+			t.Errorf("review by a co-speaker of the paper did not cause an error")
+		}
+	})
+
+	t.Run("scores must cover the rubric", func(t *testing.T) {
+		partial := map[uint32]int{rubric[0].ID: 5}
+		if _, err := reviewPaper(ctx, reviewer.ID, &Review{PaperID: paperID, Scores: partial}); err == nil {
+			t.Fatalf("partial scores did not cause an error")
+		}
+	})
+
+	t.Run("a review moves the paper into review and counts in the ranking", func(t *testing.T) {
+		_, err := reviewPaper(ctx, reviewer.ID, &Review{PaperID: paperID, Comment: "Great", Scores: scores})
+		assertDatabaseError(t, err)
+
+		paper, err := readPaperByID(ctx, nil, paperID)
+		assertDatabaseError(t, err)
+		if paper.Status != PaperStatusInReview {
+			t.Errorf("incorrect status got %v want %v", paper.Status, PaperStatusInReview)
+		}
+
+		rankings, err := paperRanking(ctx, 2)
+		assertDatabaseError(t, err)
+		for _, r := range rankings {
+			if r.Paper.ID == paperID && r.Reviews != 1 {
+				t.Errorf("incorrect number of reviews got %v want %v", r.Reviews, 1)
+			}
+		}
+	})
+
+	t.Run("accepted papers are confirmed by their speaker", func(t *testing.T) {
+		assertDatabaseError(t, changePaperStatus(ctx, paperID, PaperStatusAccepted))
+		if err := changePaperStatus(ctx, paperID, PaperStatusConfirmed); err == nil {
+			t.Errorf("confirmation by an organizer did not cause an error")
+		}
+		if err := confirmPaper(ctx, reviewer.ID, paperID, time.Now()); err == nil {
+			t.Errorf("confirmation by someone else did not cause an error")
+		}
+		assertDatabaseError(t, confirmPaper(ctx, speaker.ID, paperID, time.Now()))
+	})
+}
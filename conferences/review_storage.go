@@ -0,0 +1,202 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"encore.dev/storage/sqldb"
+	"github.com/lib/pq"
+)
+
+// assignReviewer allows a user to review the papers of a conference.
+func assignReviewer(ctx context.Context, conferenceID, userID uint32) error {
+	_, err := sqldb.Exec(ctx, `INSERT INTO review_assignment (conference_id, user_id) VALUES ($1, $2)
+	ON CONFLICT DO NOTHING`, conferenceID, userID)
+	if err != nil {
+		return fmt.Errorf("assigning reviewer: %w", err)
+	}
+	return nil
+}
+
+// isAssignedReviewer returns true if the user can review papers of the conference.
+func isAssignedReviewer(ctx context.Context, conferenceID, userID uint32) (bool, error) {
+	row := sqldb.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM review_assignment WHERE conference_id = $1 AND user_id = $2)`,
+		conferenceID, userID)
+
+	var assigned bool
+	if err := row.Scan(&assigned); err != nil {
+		return false, fmt.Errorf("checking reviewer assignment: %w", err)
+	}
+	return assigned, nil
+}
+
+// readRubric returns the scoring criteria in use for a conference.
+func readRubric(ctx context.Context, tx *sqldb.Tx, conferenceID uint32) ([]ScoringCriterion, error) {
+	sqlStatement := `SELECT id, conference_id, name, description, weight, max_score FROM scoring_criterion
+	WHERE conference_id = $1 AND retired = FALSE ORDER BY id`
+	var rows *sqldb.Rows
+	var err error
+
+	if tx != nil {
+		rows, err = sqldb.QueryTx(tx, ctx, sqlStatement, conferenceID)
+	} else {
+		rows, err = sqldb.Query(ctx, sqlStatement, conferenceID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying rubric: %w", err)
+	}
+	defer rows.Close()
+
+	rubric := []ScoringCriterion{}
+	for rows.Next() {
+		c := ScoringCriterion{}
+		if err := rows.Scan(&c.ID, &c.ConferenceID, &c.Name, &c.Description, &c.Weight, &c.MaxScore); err != nil {
+			return nil, fmt.Errorf("scanning criterion: %w", err)
+		}
+		rubric = append(rubric, c)
+	}
+	return rubric, nil
+}
+
+// replaceRubric retires the current criteria of a conference and saves new ones, scores given
+// against retired criteria are kept but no longer count.
+func replaceRubric(ctx context.Context, tx *sqldb.Tx, conferenceID uint32, rubric []ScoringCriterion) ([]ScoringCriterion, error) {
+	_, err := sqldb.ExecTx(tx, ctx, `UPDATE scoring_criterion SET retired = TRUE WHERE conference_id = $1`, conferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("retiring rubric: %w", err)
+	}
+
+	saved := make([]ScoringCriterion, 0, len(rubric))
+	for _, c := range rubric {
+		row := sqldb.QueryRowTx(tx, ctx, `INSERT INTO scoring_criterion (conference_id, name, description, weight, max_score)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`, conferenceID, c.Name, c.Description, c.Weight, c.MaxScore)
+		c.ConferenceID = conferenceID
+		if err := row.Scan(&c.ID); err != nil {
+			return nil, fmt.Errorf("saving criterion: %w", err)
+		}
+		saved = append(saved, c)
+	}
+	return saved, nil
+}
+
+// upsertReview saves the review of a paper by a reviewer, replacing a previous one.
+func upsertReview(ctx context.Context, tx *sqldb.Tx, review *Review) (*Review, error) {
+	row := sqldb.QueryRowTx(tx, ctx, `INSERT INTO paper_review (paper_id, reviewer_id, comment) VALUES ($1, $2, $3)
+	ON CONFLICT (paper_id, reviewer_id) DO UPDATE SET comment = $3, updated_at = NOW()
+	RETURNING id, updated_at`, review.PaperID, review.ReviewerID, review.Comment)
+
+	saved := *review
+	if err := row.Scan(&saved.ID, &saved.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("saving review: %w", err)
+	}
+
+	_, err := sqldb.ExecTx(tx, ctx, `DELETE FROM paper_review_score WHERE review_id = $1`, saved.ID)
+	if err != nil {
+		return nil, fmt.Errorf("clearing review scores: %w", err)
+	}
+	for criterionID, score := range review.Scores {
+		_, err := sqldb.ExecTx(tx, ctx, `INSERT INTO paper_review_score (review_id, criterion_id, score) VALUES ($1, $2, $3)`,
+			saved.ID, criterionID, score)
+		if err != nil {
+			return nil, fmt.Errorf("saving review score: %w", err)
+		}
+	}
+	return &saved, nil
+}
+
+// readReviewsByConference returns every review of the papers of a conference with their scores.
+func readReviewsByConference(ctx context.Context, conferenceID uint32) ([]Review, error) {
+	rows, err := sqldb.Query(ctx, `SELECT paper_review.id, paper_review.paper_id, paper_review.reviewer_id,
+	paper_review.comment, paper_review.updated_at, COALESCE(paper_review_score.criterion_id, 0), COALESCE(paper_review_score.score, 0)
+	FROM paper_review
+	JOIN paper_submission ON paper_review.paper_id = paper_submission.id
+	LEFT JOIN paper_review_score ON paper_review_score.review_id = paper_review.id
+	WHERE paper_submission.conference_id = $1
+	ORDER BY paper_review.id`, conferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []Review{}
+	for rows.Next() {
+		r := Review{}
+		var criterionID uint32
+		var score int
+		if err := rows.Scan(&r.ID, &r.PaperID, &r.ReviewerID, &r.Comment, &r.UpdatedAt, &criterionID, &score); err != nil {
+			return nil, fmt.Errorf("scanning review: %w", err)
+		}
+		if len(reviews) == 0 || reviews[len(reviews)-1].ID != r.ID {
+			r.Scores = map[uint32]int{}
+			reviews = append(reviews, r)
+		}
+		if criterionID != 0 {
+			reviews[len(reviews)-1].Scores[criterionID] = score
+		}
+	}
+	return reviews, nil
+}
+
+// readReviewSettings returns the review settings of a conference, blind with links redacted if
+// none were saved.
+func readReviewSettings(ctx context.Context, conferenceID uint32) (*ReviewSettings, error) {
+	row := sqldb.QueryRow(ctx, `SELECT unblinded, redact_links, redaction_terms FROM review_settings
+	WHERE conference_id = $1`, conferenceID)
+
+	settings := ReviewSettings{ConferenceID: conferenceID, RedactLinks: true, RedactionTerms: []string{}}
+	var terms pq.StringArray
+	err := row.Scan(&settings.Unblinded, &settings.RedactLinks, &terms)
+	if err == sql.ErrNoRows {
+		return &settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading review settings: %w", err)
+	}
+	settings.RedactionTerms = terms
+	return &settings, nil
+}
+
+// upsertReviewSettings saves the review settings of a conference.
+func upsertReviewSettings(ctx context.Context, settings *ReviewSettings) error {
+	_, err := sqldb.Exec(ctx, `INSERT INTO review_settings (conference_id, unblinded, redact_links, redaction_terms)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (conference_id) DO UPDATE SET unblinded = $2, redact_links = $3, redaction_terms = $4`,
+		settings.ConferenceID, settings.Unblinded, settings.RedactLinks, pq.StringArray(settings.RedactionTerms))
+	if err != nil {
+		return fmt.Errorf("saving review settings: %w", err)
+	}
+	return nil
+}
+
+// readSpeakerIdentities returns the identifying details of the speakers of each paper of a
+// conference, co-speakers included whether or not they answered, by paper ID.
+func readSpeakerIdentities(ctx context.Context, conferenceID uint32) (map[uint32][]User, error) {
+	rows, err := sqldb.Query(ctx, `SELECT paper_submission.id, users.id, users.email,
+	COALESCE(users.given_name, ''), COALESCE(users.family_name, '')
+	FROM paper_submission
+	JOIN users ON paper_submission.user_id = users.id
+	WHERE paper_submission.conference_id = $1
+	UNION ALL
+	SELECT paper_cospeaker.paper_id, COALESCE(users.id, 0), paper_cospeaker.email,
+	COALESCE(users.given_name, ''), COALESCE(users.family_name, '')
+	FROM paper_cospeaker
+	JOIN paper_submission ON paper_cospeaker.paper_id = paper_submission.id
+	LEFT JOIN users ON paper_cospeaker.user_id = users.id
+	WHERE paper_submission.conference_id = $1`, conferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying speakers: %w", err)
+	}
+	defer rows.Close()
+
+	speakers := map[uint32][]User{}
+	for rows.Next() {
+		var paperID uint32
+		u := User{}
+		if err := rows.Scan(&paperID, &u.ID, &u.Email, &u.GivenName, &u.FamilyName); err != nil {
+			return nil, fmt.Errorf("scanning speaker: %w", err)
+		}
+		speakers[paperID] = append(speakers[paperID], u)
+	}
+	return speakers, nil
+}
@@ -0,0 +1,280 @@
+package conferences
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PaperStatus is the stage of the selection process a paper is in.
+type PaperStatus string
+
+// These are the valid paper statuses
+const (
+	PaperStatusSubmitted  PaperStatus = "submitted"
+	PaperStatusInReview   PaperStatus = "in_review"
+	PaperStatusAccepted   PaperStatus = "accepted"
+	PaperStatusWaitlisted PaperStatus = "waitlisted"
+	PaperStatusRejected   PaperStatus = "rejected"
+	// PaperStatusConfirmed means the speaker confirmed they will give an accepted talk.
+	PaperStatusConfirmed PaperStatus = "confirmed"
+)
+
+// paperStatusTransitions holds the statuses a paper can move to from each status.
+var paperStatusTransitions = map[PaperStatus][]PaperStatus{
+	PaperStatusSubmitted:  {PaperStatusInReview, PaperStatusRejected},
+	PaperStatusInReview:   {PaperStatusAccepted, PaperStatusWaitlisted, PaperStatusRejected},
+	PaperStatusWaitlisted: {PaperStatusAccepted, PaperStatusRejected},
+	PaperStatusAccepted:   {PaperStatusConfirmed, PaperStatusWaitlisted, PaperStatusRejected},
+	PaperStatusRejected:   {},
+	PaperStatusConfirmed:  {},
+}
+
+// canTransition returns an error if a paper cannot move from one status to the other.
+func (s PaperStatus) canTransition(to PaperStatus) error {
+	allowed, ok := paperStatusTransitions[s]
+	if !ok {
+		return fmt.Errorf("unknown paper status %q", s)
+	}
+	if _, ok := paperStatusTransitions[to]; !ok {
+		return fmt.Errorf("unknown paper status %q", to)
+	}
+	for _, status := range allowed {
+		if status == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("a paper cannot go from %s to %s", s, to)
+}
+
+// ScoringCriterion is one line of the rubric reviewers use to score papers for a conference.
+type ScoringCriterion struct {
+	ID           uint32
+	ConferenceID uint32
+	Name         string
+	Description  string
+	// Weight is how much this criterion counts towards the total compared to the others.
+	Weight   int
+	MaxScore int
+}
+
+// Review is the assessment of a paper by one reviewer.
+type Review struct {
+	ID         uint32
+	PaperID    uint32
+	ReviewerID uint32
+	Comment    string
+	// Scores maps ScoringCriterion IDs to the score given.
+	Scores    map[uint32]int
+	UpdatedAt time.Time
+}
+
+// PaperRanking is the aggregated result of all the reviews of a paper.
+type PaperRanking struct {
+	Paper   Paper
+	Reviews int
+	// StaleReviews were made under a rubric that was since replaced, they do not count in the scores.
+	StaleReviews int
+	// MeanScore is the mean of the weighted review scores, normalized to 0-100.
+	MeanScore float64
+	// StdDev is high for controversial talks where reviewers disagree.
+	StdDev float64
+}
+
+// weightedScore returns a review score normalized to 0-100 using the rubric weights, false if the
+// review did not score every criterion of the rubric as happens once the rubric is replaced.
+func weightedScore(rubric []ScoringCriterion, scores map[uint32]int) (float64, bool) {
+	var total, possible float64
+	for _, c := range rubric {
+		score, ok := scores[c.ID]
+		if !ok {
+			return 0, false
+		}
+		total += float64(score) / float64(c.MaxScore) * float64(c.Weight)
+		possible += float64(c.Weight)
+	}
+	if possible == 0 {
+		return 0, false
+	}
+	return total / possible * 100, true
+}
+
+// rankPapers aggregates the reviews made under the rubric into a ranking sorted by mean score,
+// best first.
+func rankPapers(papers []Paper, rubric []ScoringCriterion, reviews []Review) []PaperRanking {
+	byPaper := map[uint32][]float64{}
+	stale := map[uint32]int{}
+	for _, r := range reviews {
+		score, ok := weightedScore(rubric, r.Scores)
+		if !ok {
+			stale[r.PaperID]++
+			continue
+		}
+		byPaper[r.PaperID] = append(byPaper[r.PaperID], score)
+	}
+
+	rankings := make([]PaperRanking, 0, len(papers))
+	for _, p := range papers {
+		scores := byPaper[p.ID]
+		ranking := PaperRanking{Paper: p, Reviews: len(scores), StaleReviews: stale[p.ID]}
+		if len(scores) > 0 {
+			var sum float64
+			for _, s := range scores {
+				sum += s
+			}
+			ranking.MeanScore = sum / float64(len(scores))
+			var variance float64
+			for _, s := range scores {
+				variance += (s - ranking.MeanScore) * (s - ranking.MeanScore)
+			}
+			ranking.StdDev = math.Sqrt(variance / float64(len(scores)))
+		}
+		rankings = append(rankings, ranking)
+	}
+
+	sort.SliceStable(rankings, func(i, j int) bool {
+		if rankings[i].MeanScore != rankings[j].MeanScore {
+			return rankings[i].MeanScore > rankings[j].MeanScore
+		}
+		return rankings[i].Paper.ID < rankings[j].Paper.ID
+	})
+	return rankings
+}
+
+// ReviewSettings configures how the program committee sees the papers of a conference.
+type ReviewSettings struct {
+	ConferenceID uint32
+	// Unblinded reveals who submitted each paper, until then reviews are blind.
+	Unblinded bool
+	// RedactLinks removes URLs, which often point to the speaker blog or repositories.
+	RedactLinks bool
+	// RedactionTerms are extra words to hide, such as company names.
+	RedactionTerms []string
+}
+
+// BlindPaper is a Paper as seen by reviewers, UserID and Notes are only set once unblinded.
+type BlindPaper struct {
+	ID              uint32
+	ConferenceID    uint32
+	Title           string
+	ElevatorPitch   string
+	Description     string
+	Status          PaperStatus
+	Format          TalkFormat
+	DurationMinutes int
+	AudienceLevel   AudienceLevel
+	Tracks          []string
+	Blind           bool
+	UserID          uint32
+	Notes           string
+}
+
+// redactedText replaces text that identifies a speaker.
+const redactedText = "[redacted]"
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+// redactor hides identifying terms from a text.
+type redactor struct {
+	links bool
+	terms []*regexp.Regexp
+}
+
+// newRedactor returns a redactor for the identifying terms of the speakers plus the configured
+// ones. Every word of the speakers' names is hidden, however short.
+func newRedactor(settings ReviewSettings, speakers []User) *redactor {
+	terms := append([]string{}, settings.RedactionTerms...)
+	for _, speaker := range speakers {
+		terms = append(terms, speaker.Email)
+		terms = append(terms, strings.Fields(speaker.GivenName+" "+speaker.FamilyName)...)
+	}
+	r := &redactor{links: settings.RedactLinks}
+	seen := map[string]bool{}
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" || seen[strings.ToLower(term)] {
+			continue
+		}
+		seen[strings.ToLower(term)] = true
+		r.terms = append(r.terms, regexp.MustCompile(`(?i)`+regexp.QuoteMeta(term)))
+	}
+	return r
+}
+
+// isWordRune returns true for the runes a term cannot be next to, so Ann is not hidden in Annual.
+// \b only knows about ASCII so names like José need this.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// replaceTerm replaces every match of the term that is not part of a longer word. Boundaries are
+// checked on the runes around each match rather than matched, so adjacent occurrences are all
+// replaced.
+func replaceTerm(text string, term *regexp.Regexp) string {
+	var b strings.Builder
+	copied, pos := 0, 0
+	for pos < len(text) {
+		loc := term.FindStringIndex(text[pos:])
+		if loc == nil || loc[0] == loc[1] {
+			break
+		}
+		start, end := pos+loc[0], pos+loc[1]
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if (start == 0 || !isWordRune(before)) && (end == len(text) || !isWordRune(after)) {
+			b.WriteString(text[copied:start])
+			b.WriteString(redactedText)
+			copied, pos = end, end
+			continue
+		}
+		// a match inside a word may still overlap one that is not.
+		_, size := utf8.DecodeRuneInString(text[start:])
+		pos = start + size
+	}
+	b.WriteString(text[copied:])
+	return b.String()
+}
+
+// redact returns the text with every identifying term replaced.
+func (r *redactor) redact(text string) string {
+	if r.links {
+		text = linkPattern.ReplaceAllString(text, redactedText)
+	}
+	for _, term := range r.terms {
+		text = replaceTerm(text, term)
+	}
+	return text
+}
+
+// blindPaper returns the paper as reviewers should see it under the passed settings, speakers are
+// everyone presenting it.
+func blindPaper(paper Paper, settings ReviewSettings, speakers []User) BlindPaper {
+	blind := BlindPaper{
+		ID:              paper.ID,
+		ConferenceID:    paper.ConferenceID,
+		Title:           paper.Title,
+		ElevatorPitch:   paper.ElevatorPitch,
+		Description:     paper.Description,
+		Status:          paper.Status,
+		Format:          paper.Format,
+		DurationMinutes: paper.DurationMinutes,
+		AudienceLevel:   paper.AudienceLevel,
+		Tracks:          paper.Tracks,
+		Blind:           !settings.Unblinded,
+	}
+	if settings.Unblinded {
+		blind.UserID = paper.UserID
+		blind.Notes = paper.Notes
+		return blind
+	}
+	r := newRedactor(settings, speakers)
+	blind.Title = r.redact(blind.Title)
+	blind.ElevatorPitch = r.redact(blind.ElevatorPitch)
+	blind.Description = r.redact(blind.Description)
+	return blind
+}
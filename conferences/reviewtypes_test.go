@@ -0,0 +1,126 @@
+package conferences
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPaperStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from    PaperStatus
+		to      PaperStatus
+		wantErr bool
+	}{
+		{from: PaperStatusSubmitted, to: PaperStatusInReview},
+		{from: PaperStatusInReview, to: PaperStatusAccepted},
+		{from: PaperStatusWaitlisted, to: PaperStatusAccepted},
+		{from: PaperStatusAccepted, to: PaperStatusConfirmed},
+		{from: PaperStatusSubmitted, to: PaperStatusConfirmed, wantErr: true},
+		{from: PaperStatusRejected, to: PaperStatusAccepted, wantErr: true},
+		{from: PaperStatusInReview, to: PaperStatus("maybe"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			if err := tt.from.canTransition(tt.to); (err != nil) != tt.wantErr {
+				t.Errorf("canTransition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRankPapers(t *testing.T) {
+	rubric := []ScoringCriterion{
+		{ID: 1, Name: "content", Weight: 3, MaxScore: 5},
+		{ID: 2, Name: "delivery", Weight: 1, MaxScore: 10},
+	}
+	papers := []Paper{{ID: 1}, {ID: 2}, {ID: 3}}
+	reviews := []Review{
+		// paper 1, everyone agrees it is fine.
+		{PaperID: 1, Scores: map[uint32]int{1: 4, 2: 8}},
+		{PaperID: 1, Scores: map[uint32]int{1: 4, 2: 8}},
+		// paper 2, controversial.
+		{PaperID: 2, Scores: map[uint32]int{1: 5, 2: 10}},
+		{PaperID: 2, Scores: map[uint32]int{1: 0, 2: 0}},
+		// paper 3, only reviewed under a rubric that was replaced.
+		{PaperID: 3, Scores: map[uint32]int{7: 5}},
+	}
+
+	got := rankPapers(papers, rubric, reviews)
+
+	if len(got) != 3 {
+		t.Fatalf("incorrect number of rankings got %v want %v", len(got), 3)
+	}
+	if got[0].Paper.ID != 1 || got[1].Paper.ID != 2 || got[2].Paper.ID != 3 {
+		t.Errorf("incorrect ranking order got %v, %v, %v", got[0].Paper.ID, got[1].Paper.ID, got[2].Paper.ID)
+	}
+	if math.Abs(got[0].MeanScore-80) > 0.001 || got[0].StdDev != 0 {
+		t.Errorf("incorrect score for paper 1 got %v±%v want 80±0", got[0].MeanScore, got[0].StdDev)
+	}
+	if math.Abs(got[1].MeanScore-50) > 0.001 || math.Abs(got[1].StdDev-50) > 0.001 {
+		t.Errorf("incorrect score for paper 2 got %v±%v want 50±50", got[1].MeanScore, got[1].StdDev)
+	}
+	if got[2].Reviews != 0 || got[2].StaleReviews != 1 || got[2].MeanScore != 0 {
+		t.Errorf("stale review counted got %+v", got[2])
+	}
+}
+
+func TestBlindPaper(t *testing.T) {
+	speakers := []User{
+		{Email: "jose@example.com", GivenName: "José", FamilyName: "Gopher"},
+		{Email: "ana@example.com", GivenName: "Ana", FamilyName: "Co"},
+	}
+	paper := Paper{
+		ID:            1,
+		UserID:        7,
+		Title:         "Scaling at Acme",
+		ElevatorPitch: "How José scaled Acme",
+		Description:   "I am josé gopher, see https://jose.example.com or mail jose@example.com. Gophers welcome.",
+		Notes:         "I spoke at GopherCon before",
+	}
+	coPresented := Paper{
+		ID:          2,
+		Description: "Ana Co joins José, write to ana@example.com.",
+	}
+
+	t.Run("hides the speaker while blind", func(t *testing.T) {
+		got := blindPaper(paper, ReviewSettings{RedactLinks: true, RedactionTerms: []string{"Acme"}}, speakers[:1])
+
+		if !got.Blind || got.UserID != 0 || got.Notes != "" {
+			t.Errorf("identity leaked got UserID %v Notes %q", got.UserID, got.Notes)
+		}
+		if got.Title != "Scaling at [redacted]" {
+			t.Errorf("incorrect title got %q", got.Title)
+		}
+		want := "I am [redacted] [redacted], see [redacted] or mail [redacted]. Gophers welcome."
+		if got.Description != want {
+			t.Errorf("incorrect description got %q want %q", got.Description, want)
+		}
+	})
+
+	t.Run("hides adjacent and short names", func(t *testing.T) {
+		short := []User{{Email: "li@example.com", GivenName: "Li", FamilyName: "Erin"}}
+		got := blindPaper(Paper{Description: "Erin Erin and li, not Linux"}, ReviewSettings{}, short)
+
+		want := "[redacted] [redacted] and [redacted], not Linux"
+		if got.Description != want {
+			t.Errorf("incorrect description got %q want %q", got.Description, want)
+		}
+	})
+
+	t.Run("hides every speaker of the paper", func(t *testing.T) {
+		got := blindPaper(coPresented, ReviewSettings{}, speakers)
+
+		want := "[redacted] [redacted] joins [redacted], write to [redacted]."
+		if got.Description != want {
+			t.Errorf("incorrect description got %q want %q", got.Description, want)
+		}
+	})
+
+	t.Run("reveals the speaker once unblinded", func(t *testing.T) {
+		got := blindPaper(paper, ReviewSettings{Unblinded: true, RedactLinks: true}, speakers)
+
+		if got.Blind || got.UserID != paper.UserID || got.Notes != paper.Notes || got.Description != paper.Description {
+			t.Errorf("unblinded paper was altered got %+v", got)
+		}
+	})
+}
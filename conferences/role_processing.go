@@ -0,0 +1,55 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// bootstrapOrganizer makes the user the first organizer if their verified email is the configured
+// one and there is no organizer yet.
+func bootstrapOrganizer(ctx context.Context, userID uint32) error {
+	if secrets.FirstOrganizerEmail == "" {
+		return fmt.Errorf("no first organizer is configured")
+	}
+	granted, err := grantFirstOrganizer(ctx, userID, secrets.FirstOrganizerEmail)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return fmt.Errorf("user %d cannot become the first organizer", userID)
+	}
+	return nil
+}
+
+// grantUserRole grants a role to a user, only organizers can do so.
+func grantUserRole(ctx context.Context, actorID, userID uint32, role Role) error {
+	if err := requireRole(ctx, actorID, RoleOrganizer); err != nil {
+		return err
+	}
+	if !role.valid() {
+		return fmt.Errorf("invalid role %q", role)
+	}
+	user, err := readAttendeeByID(ctx, nil, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return fmt.Errorf("no such user")
+	}
+	return grantRole(ctx, nil, userID, role)
+}
+
+// revokeUserRole takes a role away from a user, only organizers can do so. Organizers cannot
+// revoke their own organizer role so there is always one left.
+func revokeUserRole(ctx context.Context, actorID, userID uint32, role Role) error {
+	if err := requireRole(ctx, actorID, RoleOrganizer); err != nil {
+		return err
+	}
+	if !role.valid() {
+		return fmt.Errorf("invalid role %q", role)
+	}
+	if actorID == userID && role == RoleOrganizer {
+		return fmt.Errorf("organizers cannot revoke their own organizer role")
+	}
+	return revokeRole(ctx, userID, role)
+}
@@ -0,0 +1,49 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRoles(t *testing.T) {
+	ctx := context.Background()
+
+	organizer := createOrganizer(t, ctx, "role-admin@gophercon.com")
+	attendee, err := createAttendee(ctx, nil, &User{Email: "role-attendee@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+
+	t.Run("only organizers grant and revoke roles", func(t *testing.T) {
+		if err := grantUserRole(ctx, attendee.ID, attendee.ID, RoleOrganizer); err == nil {
+			t.Errorf("an attendee granting a role did not cause an error")
+		}
+		if err := grantUserRole(ctx, organizer.ID, attendee.ID, "overlord"); err == nil {
+			t.Errorf("granting an unknown role did not cause an error")
+		}
+		assertDatabaseError(t, grantUserRole(ctx, organizer.ID, attendee.ID, RoleCoCTeam))
+		granted, err := hasRole(ctx, nil, attendee.ID, RoleCoCTeam)
+		assertDatabaseError(t, err)
+		if !granted {
+			t.Errorf("the role was not granted")
+		}
+
+		assertDatabaseError(t, revokeUserRole(ctx, organizer.ID, attendee.ID, RoleCoCTeam))
+		granted, err = hasRole(ctx, nil, attendee.ID, RoleCoCTeam)
+		assertDatabaseError(t, err)
+		if granted {
+			t.Errorf("the role was not revoked")
+		}
+		if err := revokeUserRole(ctx, organizer.ID, organizer.ID, RoleOrganizer); err == nil {
+			t.Errorf("an organizer revoking their own organizer role did not cause an error")
+		}
+	})
+
+	t.Run("there is only one first organizer", func(t *testing.T) {
+		previous := secrets.FirstOrganizerEmail
+		secrets.FirstOrganizerEmail = attendee.Email
+		defer func() { secrets.FirstOrganizerEmail = previous }()
+
+		if err := bootstrapOrganizer(ctx, attendee.ID); err == nil {
+			t.Errorf("bootstrapping an organizer when there is one did not cause an error")
+		}
+	})
+}
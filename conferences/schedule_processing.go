@@ -0,0 +1,213 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// isScheduleable returns true for papers that can be put in the schedule.
+func isScheduleable(p *Paper) bool {
+	return !p.Withdrawn && (p.Status == PaperStatusAccepted || p.Status == PaperStatusConfirmed)
+}
+
+// scheduleTalk places an accepted paper in the schedule filling in defaults from the slot and the
+// paper. It returns the conflicts the entry is involved in, blocking ones keep it from being saved,
+// and a nil entry, unless force is set.
+func scheduleTalk(ctx context.Context, userID uint32, entry ScheduleEntry, force bool) (*ScheduleEntry, []ScheduleConflict, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, nil, err
+	}
+
+	paper, err := readPaperByID(ctx, nil, entry.PaperID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if paper == nil {
+		return nil, nil, fmt.Errorf("no such paper")
+	}
+	if !isScheduleable(paper) {
+		return nil, nil, fmt.Errorf("only accepted papers can be scheduled")
+	}
+	slot, err := readScheduleSlot(ctx, entry.ConferenceSlotID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if slot == nil || slot.ConferenceID != paper.ConferenceID {
+		return nil, nil, fmt.Errorf("no such slot in the conference of the paper")
+	}
+
+	entry.ConferenceID = paper.ConferenceID
+	if entry.LocationID == 0 {
+		entry.LocationID = slot.Location.ID
+	}
+	if entry.StartsAt.IsZero() {
+		entry.StartsAt = slot.StartDate
+	}
+	if entry.EndsAt.IsZero() {
+		entry.EndsAt = entry.StartsAt.Add(time.Duration(paper.DurationMinutes) * time.Minute)
+	}
+	if entry.Track == "" && len(paper.Tracks) > 0 {
+		entry.Track = paper.Tracks[0]
+	}
+	if !entry.EndsAt.After(entry.StartsAt) {
+		return nil, nil, fmt.Errorf("a talk must end after it starts")
+	}
+	if entry.StartsAt.Before(slot.StartDate) || entry.EndsAt.After(slot.EndDate) {
+		return nil, nil, fmt.Errorf("the talk must happen within slot %q", slot.Name)
+	}
+	if entry.ExpectedAttendance < 0 {
+		return nil, nil, fmt.Errorf("expected attendance cannot be negative")
+	}
+
+	entries, err := readScheduleEntries(ctx, entry.ConferenceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	proposed := []ScheduleEntry{entry}
+	for _, e := range entries {
+		if e.PaperID != entry.PaperID {
+			proposed = append(proposed, e)
+		}
+	}
+	conflicts, locations, err := conflictsOf(ctx, proposed)
+	if err != nil {
+		return nil, nil, err
+	}
+	venueID, err := readConferenceVenueID(ctx, entry.ConferenceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if location, ok := locations[entry.LocationID]; !ok || location.VenueID != venueID {
+		return nil, nil, fmt.Errorf("no such location at the venue of the conference")
+	}
+
+	involved := []ScheduleConflict{}
+	blocked := false
+	for _, c := range conflicts {
+		if c.involves(entry.PaperID) {
+			involved = append(involved, c)
+			blocked = blocked || c.blocking()
+		}
+	}
+	if blocked && !force {
+		return nil, involved, nil
+	}
+
+	saved, err := upsertScheduleEntry(ctx, &entry)
+	if err != nil {
+		return nil, nil, err
+	}
+	return saved, involved, nil
+}
+
+// conflictsOf loads the speakers and locations involved in a schedule and returns its conflicts
+// and the locations by ID.
+func conflictsOf(ctx context.Context, entries []ScheduleEntry) ([]ScheduleConflict, map[uint32]Location, error) {
+	paperIDs := make([]uint32, 0, len(entries))
+	locationIDs := make([]uint32, 0, len(entries))
+	for _, e := range entries {
+		paperIDs = append(paperIDs, e.PaperID)
+		locationIDs = append(locationIDs, e.LocationID)
+	}
+	speakers, err := readPaperSpeakerIDs(ctx, paperIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	locations, err := readLocations(ctx, locationIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	capacities := map[uint32]int{}
+	for id, l := range locations {
+		capacities[id] = l.Capacity
+	}
+	return detectConflicts(entries, speakers, capacities), locations, nil
+}
+
+// unscheduleTalk takes a paper out of the schedule.
+func unscheduleTalk(ctx context.Context, userID, paperID uint32) error {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+	return deleteScheduleEntry(ctx, paperID)
+}
+
+// scheduleConflicts returns every conflict in the schedule of a conference.
+func scheduleConflicts(ctx context.Context, userID, conferenceID uint32) ([]ScheduleConflict, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	entries, err := readScheduleEntries(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+	conflicts, _, err := conflictsOf(ctx, entries)
+	return conflicts, err
+}
+
+// conferenceSchedule returns the public schedule of a conference with days in the passed time zone.
+func conferenceSchedule(ctx context.Context, conferenceID uint32, loc *time.Location) ([]ScheduleDay, error) {
+	talks, err := scheduledTalks(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+	return groupSchedule(talks, loc), nil
+}
+
+// scheduledTalks returns the scheduled talks of a conference, papers that were withdrawn or are
+// no longer accepted are left out.
+func scheduledTalks(ctx context.Context, conferenceID uint32) ([]ScheduledTalk, error) {
+	entries, err := readScheduleEntries(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+	papers, paperSpeakers, err := readAcceptedTalks(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	talks := map[uint32]PublicTalk{}
+	userIDs := []uint32{}
+	for _, p := range papers {
+		talks[p.ID] = publicTalk(p)
+		userIDs = append(userIDs, paperSpeakers[p.ID]...)
+	}
+	locationIDs := make([]uint32, 0, len(entries))
+	for _, e := range entries {
+		locationIDs = append(locationIDs, e.LocationID)
+	}
+	profiles, err := readSpeakerProfiles(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := readLocations(ctx, locationIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := []ScheduledTalk{}
+	for _, e := range entries {
+		talk, ok := talks[e.PaperID]
+		if !ok {
+			continue
+		}
+		speakers := []SpeakerProfile{}
+		for _, userID := range paperSpeakers[e.PaperID] {
+			if profile, ok := profiles[userID]; ok {
+				speakers = append(speakers, *profile)
+			}
+		}
+		scheduled = append(scheduled, ScheduledTalk{
+			EntryID:          e.ID,
+			ConferenceSlotID: e.ConferenceSlotID,
+			StartsAt:         e.StartsAt,
+			EndsAt:           e.EndsAt,
+			Location:         locations[e.LocationID],
+			Track:            e.Track,
+			Talk:             talk,
+			Speakers:         speakers,
+		})
+	}
+	return scheduled, nil
+}
@@ -0,0 +1,79 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+func TestScheduleTalk(t *testing.T) {
+	ctx := context.Background()
+
+	organizer := createOrganizer(t, ctx, "scheduler@gophercon.com")
+	speaker, err := createAttendee(ctx, nil, &User{Email: "busy-speaker@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+
+	start := time.Date(2020, 11, 10, 17, 0, 0, 0, time.UTC)
+	row := sqldb.QueryRow(ctx, `INSERT INTO conference_slot (name, description, cost, capacity, start_date, end_date,
+	purchaseable_from, purchaseable_until, available_to_public, conference_id, location_id)
+	VALUES ('Talks', 'Main track', 0, 400, $1, $2, $1, $1, FALSE, 1, 1) RETURNING id`, start, start.Add(4*time.Hour))
+	var slotID uint32
+	assertDatabaseError(t, row.Scan(&slotID))
+
+	submit := func(title string) uint32 {
+		paperID, err := submitPaper(ctx, speaker.ID, &Paper{
+			ConferenceID:  1,
+			Title:         title,
+			ElevatorPitch: "Pitch",
+			Description:   "Description",
+			Format:        TalkFormatShort,
+			Tracks:        []string{"runtime"},
+		})
+		assertDatabaseError(t, err)
+		assertDatabaseError(t, updatePaperStatus(ctx, nil, paperID, PaperStatusAccepted))
+		return paperID
+	}
+	first := submit("First talk")
+	second := submit("Second talk")
+
+	t.Run("defaults come from the slot and the paper", func(t *testing.T) {
+		entry, conflicts, err := scheduleTalk(ctx, organizer.ID, ScheduleEntry{PaperID: first, ConferenceSlotID: slotID}, false)
+		assertDatabaseError(t, err)
+		if len(conflicts) != 0 {
+			t.Errorf("unexpected conflicts %+v", conflicts)
+		}
+		if entry == nil || entry.LocationID != 1 || !entry.StartsAt.Equal(start) ||
+			!entry.EndsAt.Equal(start.Add(25*time.Minute)) || entry.Track != "runtime" {
+			t.Errorf("incorrect defaults got %+v", entry)
+		}
+	})
+
+	t.Run("double booked speaker blocks scheduling", func(t *testing.T) {
+		entry, conflicts, err := scheduleTalk(ctx, organizer.ID, ScheduleEntry{PaperID: second, ConferenceSlotID: slotID}, false)
+		assertDatabaseError(t, err)
+		if entry != nil {
+			t.Errorf("talk was scheduled despite conflicts")
+		}
+		if len(conflicts) == 0 {
+			t.Errorf("no conflicts were reported")
+		}
+	})
+
+	t.Run("the public schedule lists the talk", func(t *testing.T) {
+		days, err := conferenceSchedule(ctx, 1, time.UTC)
+		assertDatabaseError(t, err)
+		found := false
+		for _, day := range days {
+			for _, track := range day.Tracks {
+				for _, talk := range track.Talks {
+					found = found || talk.Talk.PaperID == first
+				}
+			}
+		}
+		if !found {
+			t.Errorf("scheduled talk missing from the schedule")
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"encore.dev/storage/sqldb"
+	"github.com/lib/pq"
+)
+
+// readScheduleSlot returns the conference, times and location of a slot, nil if it does not exist.
+func readScheduleSlot(ctx context.Context, slotID uint32) (*ConferenceSlot, error) {
+	row := sqldb.QueryRow(ctx, `SELECT id, name, conference_id, start_date, end_date, COALESCE(location_id, 0)
+	FROM conference_slot WHERE id = $1`, slotID)
+
+	slot := ConferenceSlot{}
+	err := row.Scan(&slot.ID, &slot.Name, &slot.ConferenceID, &slot.StartDate, &slot.EndDate, &slot.Location.ID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading slot: %w", err)
+	}
+	return &slot, nil
+}
+
+// readLocations returns the passed locations by ID.
+func readLocations(ctx context.Context, locationIDs []uint32) (map[uint32]Location, error) {
+	ids := make(pq.Int64Array, 0, len(locationIDs))
+	for _, id := range locationIDs {
+		ids = append(ids, int64(id))
+	}
+	rows, err := sqldb.Query(ctx, `SELECT id, name, description, address, directions, COALESCE(google_maps_url, ''),
+	capacity, venue_id FROM location WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("querying locations: %w", err)
+	}
+	defer rows.Close()
+
+	locations := map[uint32]Location{}
+	for rows.Next() {
+		l := Location{}
+		err := rows.Scan(&l.ID, &l.Name, &l.Description, &l.Address, &l.Directions, &l.GoogleMapsURL, &l.Capacity, &l.VenueID)
+		if err != nil {
+			return nil, fmt.Errorf("scanning location: %w", err)
+		}
+		locations[l.ID] = l
+	}
+	return locations, nil
+}
+
+const scheduleEntryColumns = `id, conference_id, paper_id, conference_slot_id, location_id, starts_at, ends_at, track,
+	expected_attendance`
+
+// scanScheduleEntry scans a row selected with scheduleEntryColumns.
+func scanScheduleEntry(scan func(dest ...interface{}) error) (*ScheduleEntry, error) {
+	var e ScheduleEntry
+	err := scan(&e.ID,
+		&e.ConferenceID,
+		&e.PaperID,
+		&e.ConferenceSlotID,
+		&e.LocationID,
+		&e.StartsAt,
+		&e.EndsAt,
+		&e.Track,
+		&e.ExpectedAttendance)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// readScheduleEntries returns the schedule of a conference in chronological order.
+func readScheduleEntries(ctx context.Context, conferenceID uint32) ([]ScheduleEntry, error) {
+	rows, err := sqldb.Query(ctx, `SELECT `+scheduleEntryColumns+` FROM schedule_entry
+	WHERE conference_id = $1 ORDER BY starts_at, id`, conferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying schedule: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []ScheduleEntry{}
+	for rows.Next() {
+		entry, err := scanScheduleEntry(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning schedule entry: %w", err)
+		}
+		entries = append(entries, *entry)
+	}
+	return entries, nil
+}
+
+// upsertScheduleEntry schedules a paper, moving it if it was already scheduled.
+func upsertScheduleEntry(ctx context.Context, e *ScheduleEntry) (*ScheduleEntry, error) {
+	row := sqldb.QueryRow(ctx, `INSERT INTO schedule_entry (conference_id, paper_id, conference_slot_id, location_id,
+	starts_at, ends_at, track, expected_attendance) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (paper_id) DO UPDATE SET conference_id = $1, conference_slot_id = $3, location_id = $4,
+	starts_at = $5, ends_at = $6, track = $7, expected_attendance = $8
+	RETURNING `+scheduleEntryColumns,
+		e.ConferenceID, e.PaperID, e.ConferenceSlotID, e.LocationID, e.StartsAt, e.EndsAt, e.Track, e.ExpectedAttendance)
+
+	saved, err := scanScheduleEntry(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("saving schedule entry: %w", err)
+	}
+	return saved, nil
+}
+
+// deleteScheduleEntry takes a paper out of the schedule.
+func deleteScheduleEntry(ctx context.Context, paperID uint32) error {
+	res, err := sqldb.Exec(ctx, `DELETE FROM schedule_entry WHERE paper_id = $1`, paperID)
+	if err != nil {
+		return fmt.Errorf("removing schedule entry: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	if ra == 0 {
+		return fmt.Errorf("paper is not scheduled")
+	}
+	return nil
+}
+
+// readPaperSpeakerIDs returns the users presenting each of the passed papers by paper ID.
+func readPaperSpeakerIDs(ctx context.Context, paperIDs []uint32) (map[uint32][]uint32, error) {
+	ids := make(pq.Int64Array, 0, len(paperIDs))
+	for _, id := range paperIDs {
+		ids = append(ids, int64(id))
+	}
+	rows, err := sqldb.Query(ctx, `SELECT speakers.paper_id, speakers.user_id FROM `+paperSpeakers+`
+	WHERE speakers.paper_id = ANY($1) ORDER BY speakers.paper_id, speakers.user_id`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("querying paper speakers: %w", err)
+	}
+	defer rows.Close()
+
+	speakers := map[uint32][]uint32{}
+	for rows.Next() {
+		var paperID, userID uint32
+		if err := rows.Scan(&paperID, &userID); err != nil {
+			return nil, fmt.Errorf("scanning paper speaker: %w", err)
+		}
+		speakers[paperID] = append(speakers[paperID], userID)
+	}
+	return speakers, nil
+}
+
+// readConferenceVenueID returns the venue hosting a conference.
+func readConferenceVenueID(ctx context.Context, conferenceID uint32) (uint32, error) {
+	row := sqldb.QueryRow(ctx, `SELECT venue_id FROM conference WHERE id = $1`, conferenceID)
+
+	var venueID uint32
+	err := row.Scan(&venueID)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("no such conference")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading conference venue: %w", err)
+	}
+	return venueID, nil
+}
+
+// readScheduleEntryByPaper returns where and when a paper is scheduled, nil if it is not.
+func readScheduleEntryByPaper(ctx context.Context, paperID uint32) (*ScheduleEntry, error) {
+	row := sqldb.QueryRow(ctx, `SELECT `+scheduleEntryColumns+` FROM schedule_entry WHERE paper_id = $1`, paperID)
+
+	entry, err := scanScheduleEntry(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading schedule entry: %w", err)
+	}
+	return entry, nil
+}
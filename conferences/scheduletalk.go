@@ -0,0 +1,61 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// ScheduleTalkParams defines the inputs used by the ScheduleTalk API method
+type ScheduleTalkParams struct {
+	Entry *ScheduleEntry
+	// Force schedules the talk despite rooms or speakers being double booked.
+	Force bool
+}
+
+// ScheduleTalkResponse defines the output returned by the ScheduleTalk API method, Entry is nil
+// when a blocking conflict kept the talk from being scheduled
+type ScheduleTalkResponse struct {
+	Entry     *ScheduleEntry
+	Conflicts []ScheduleConflict
+}
+
+// ScheduleTalk assigns an accepted paper to a slot, room and time, or moves it if it was already
+// scheduled, only organizers can do so
+// encore:api auth
+func ScheduleTalk(ctx context.Context, params *ScheduleTalkParams) (*ScheduleTalkResponse, error) {
+	if params.Entry == nil {
+		return nil, fmt.Errorf("Entry is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, conflicts, err := scheduleTalk(ctx, userID, *params.Entry, params.Force)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule talk: %w", err)
+	}
+
+	return &ScheduleTalkResponse{Entry: entry, Conflicts: conflicts}, nil
+}
+
+// UnscheduleTalkParams defines the inputs used by the UnscheduleTalk API method
+type UnscheduleTalkParams struct {
+	PaperID uint32
+}
+
+// UnscheduleTalk takes a paper out of the schedule, only organizers can do so
+// encore:api auth
+func UnscheduleTalk(ctx context.Context, params *UnscheduleTalkParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := unscheduleTalk(ctx, userID, params.PaperID); err != nil {
+		return fmt.Errorf("failed to unschedule talk: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package conferences
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ScheduleEntry places an accepted paper in a room at a time within one of the conference slots
+type ScheduleEntry struct {
+	ID               uint32
+	ConferenceID     uint32
+	PaperID          uint32
+	ConferenceSlotID uint32
+	// LocationID defaults to the location of the slot.
+	LocationID uint32
+	// StartsAt defaults to the start of the slot and EndsAt to the duration of the paper after it.
+	StartsAt time.Time
+	EndsAt   time.Time
+	// Track defaults to the first track of the paper.
+	Track string
+	// ExpectedAttendance is how many people organizers expect, zero if they have no estimate.
+	ExpectedAttendance int
+}
+
+func (e *ScheduleEntry) overlaps(other *ScheduleEntry) bool {
+	return e.StartsAt.Before(other.EndsAt) && other.StartsAt.Before(e.EndsAt)
+}
+
+// ConflictKind is a problem found in a schedule
+type ConflictKind string
+
+// Conflicts detected in a schedule
+const (
+	ConflictRoomOverlap         ConflictKind = "room_overlap"
+	ConflictSpeakerDoubleBooked ConflictKind = "speaker_double_booked"
+	ConflictOverCapacity        ConflictKind = "over_capacity"
+)
+
+// ScheduleConflict is a problem involving one or more scheduled papers
+type ScheduleConflict struct {
+	Kind     ConflictKind
+	PaperIDs []uint32
+	Detail   string
+}
+
+// blocking returns true for conflicts that keep a talk from being scheduled unless forced, an
+// overflowing room can be dealt with but nobody can be in two places at once.
+func (c ScheduleConflict) blocking() bool {
+	return c.Kind != ConflictOverCapacity
+}
+
+// involves returns true if the paper is part of the conflict.
+func (c ScheduleConflict) involves(paperID uint32) bool {
+	for _, id := range c.PaperIDs {
+		if id == paperID {
+			return true
+		}
+	}
+	return false
+}
+
+// detectConflicts returns the conflicts of a schedule given the speakers of each paper and the
+// capacity of each location.
+func detectConflicts(entries []ScheduleEntry, speakers map[uint32][]uint32, capacities map[uint32]int) []ScheduleConflict {
+	sorted := make([]ScheduleEntry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].StartsAt.Equal(sorted[j].StartsAt) {
+			return sorted[i].StartsAt.Before(sorted[j].StartsAt)
+		}
+		return sorted[i].PaperID < sorted[j].PaperID
+	})
+
+	conflicts := []ScheduleConflict{}
+	for i := range sorted {
+		a := &sorted[i]
+		if capacity, ok := capacities[a.LocationID]; ok && a.ExpectedAttendance > capacity {
+			conflicts = append(conflicts, ScheduleConflict{
+				Kind:     ConflictOverCapacity,
+				PaperIDs: []uint32{a.PaperID},
+				Detail:   fmt.Sprintf("%d attendees are expected in a room for %d", a.ExpectedAttendance, capacity),
+			})
+		}
+		for j := i + 1; j < len(sorted); j++ {
+			b := &sorted[j]
+			if !b.StartsAt.Before(a.EndsAt) {
+				// sorted by start, nothing after b can overlap a either.
+				break
+			}
+			if !a.overlaps(b) {
+				continue
+			}
+			if a.LocationID == b.LocationID {
+				conflicts = append(conflicts, ScheduleConflict{
+					Kind:     ConflictRoomOverlap,
+					PaperIDs: []uint32{a.PaperID, b.PaperID},
+					Detail:   fmt.Sprintf("both are in location %d at the same time", a.LocationID),
+				})
+			}
+			for _, speaker := range sharedSpeakers(speakers[a.PaperID], speakers[b.PaperID]) {
+				conflicts = append(conflicts, ScheduleConflict{
+					Kind:     ConflictSpeakerDoubleBooked,
+					PaperIDs: []uint32{a.PaperID, b.PaperID},
+					Detail:   fmt.Sprintf("user %d presents both at the same time", speaker),
+				})
+			}
+		}
+	}
+	return conflicts
+}
+
+func sharedSpeakers(a, b []uint32) []uint32 {
+	shared := []uint32{}
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				shared = append(shared, x)
+			}
+		}
+	}
+	return shared
+}
+
+// ScheduledTalk is a talk in the public schedule
+type ScheduledTalk struct {
+	EntryID          uint32
+	ConferenceSlotID uint32
+	StartsAt         time.Time
+	EndsAt           time.Time
+	Location         Location
+	Track            string
+	Talk             PublicTalk
+	Speakers         []SpeakerProfile
+}
+
+// ScheduleTrack holds the talks of a track on a day in the order they happen
+type ScheduleTrack struct {
+	Name  string
+	Talks []ScheduledTalk
+}
+
+// ScheduleDay holds the tracks of a day of the conference, Date is formatted as 2006-01-02
+type ScheduleDay struct {
+	Date   string
+	Tracks []ScheduleTrack
+}
+
+// groupSchedule groups talks by the day they start on in the passed time zone and then by track,
+// days and talks are in chronological order and tracks in alphabetical order.
+func groupSchedule(talks []ScheduledTalk, loc *time.Location) []ScheduleDay {
+	sorted := make([]ScheduledTalk, len(talks))
+	copy(sorted, talks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].StartsAt.Equal(sorted[j].StartsAt) {
+			return sorted[i].StartsAt.Before(sorted[j].StartsAt)
+		}
+		return sorted[i].Location.Name < sorted[j].Location.Name
+	})
+
+	days := []ScheduleDay{}
+	for _, talk := range sorted {
+		talk.StartsAt = talk.StartsAt.In(loc)
+		talk.EndsAt = talk.EndsAt.In(loc)
+		date := talk.StartsAt.Format("2006-01-02")
+		if len(days) == 0 || days[len(days)-1].Date != date {
+			days = append(days, ScheduleDay{Date: date, Tracks: []ScheduleTrack{}})
+		}
+		day := &days[len(days)-1]
+
+		i := sort.Search(len(day.Tracks), func(i int) bool { return day.Tracks[i].Name >= talk.Track })
+		if i == len(day.Tracks) || day.Tracks[i].Name != talk.Track {
+			day.Tracks = append(day.Tracks, ScheduleTrack{})
+			copy(day.Tracks[i+1:], day.Tracks[i:])
+			day.Tracks[i] = ScheduleTrack{Name: talk.Track}
+		}
+		day.Tracks[i].Talks = append(day.Tracks[i].Talks, talk)
+	}
+	return days
+}
@@ -0,0 +1,72 @@
+package conferences
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectConflicts(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2021, 11, 10, hour, 0, 0, 0, time.UTC) }
+	entries := []ScheduleEntry{
+		{PaperID: 1, LocationID: 1, StartsAt: at(9), EndsAt: at(10)},
+		// same room, overlapping the first one.
+		{PaperID: 2, LocationID: 1, StartsAt: at(9), EndsAt: at(11)},
+		// other room, shares a speaker with the first one.
+		{PaperID: 3, LocationID: 2, StartsAt: at(9), EndsAt: at(10), ExpectedAttendance: 500},
+		// same room as the first one, right after it.
+		{PaperID: 4, LocationID: 1, StartsAt: at(11), EndsAt: at(12)},
+	}
+	speakers := map[uint32][]uint32{1: {10}, 2: {20}, 3: {10, 30}, 4: {10}}
+	capacities := map[uint32]int{1: 100, 2: 300}
+
+	conflicts := detectConflicts(entries, speakers, capacities)
+
+	want := map[ConflictKind][]uint32{
+		ConflictRoomOverlap:         {1, 2},
+		ConflictSpeakerDoubleBooked: {1, 3},
+		ConflictOverCapacity:        {3},
+	}
+	if len(conflicts) != len(want) {
+		t.Fatalf("incorrect number of conflicts got %+v", conflicts)
+	}
+	for _, c := range conflicts {
+		papers, ok := want[c.Kind]
+		if !ok {
+			t.Errorf("unexpected conflict %+v", c)
+			continue
+		}
+		for _, id := range papers {
+			if !c.involves(id) {
+				t.Errorf("conflict %v does not involve paper %v got %v", c.Kind, id, c.PaperIDs)
+			}
+		}
+	}
+}
+
+func TestGroupSchedule(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	talks := []ScheduledTalk{
+		{EntryID: 1, Track: "web", StartsAt: time.Date(2021, 11, 11, 15, 0, 0, 0, time.UTC)},
+		// still the 10th in New York.
+		{EntryID: 2, Track: "tooling", StartsAt: time.Date(2021, 11, 11, 2, 0, 0, 0, time.UTC)},
+		{EntryID: 3, Track: "tooling", StartsAt: time.Date(2021, 11, 11, 14, 0, 0, 0, time.UTC)},
+		{EntryID: 4, Track: "tooling", StartsAt: time.Date(2021, 11, 11, 16, 0, 0, 0, time.UTC)},
+	}
+
+	days := groupSchedule(talks, newYork)
+
+	if len(days) != 2 || days[0].Date != "2021-11-10" || days[1].Date != "2021-11-11" {
+		t.Fatalf("incorrect days got %+v", days)
+	}
+	second := days[1]
+	if len(second.Tracks) != 2 || second.Tracks[0].Name != "tooling" || second.Tracks[1].Name != "web" {
+		t.Fatalf("incorrect tracks got %+v", second.Tracks)
+	}
+	tooling := second.Tracks[0].Talks
+	if len(tooling) != 2 || tooling[0].EntryID != 3 || tooling[1].EntryID != 4 {
+		t.Errorf("incorrect tooling talks got %+v", tooling)
+	}
+}
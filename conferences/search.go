@@ -0,0 +1,39 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+
+	"encore.dev/beta/auth"
+)
+
+// SearchParams defines the inputs used by the Search API method
+type SearchParams struct {
+	Query SearchQuery
+}
+
+// SearchResponse defines the output returned by the Search API method
+type SearchResponse struct {
+	Results []SearchResult
+}
+
+// Search looks for papers, jobs, sponsors and sessions matching a query. Anyone can search, only
+// organizers find unapproved jobs and papers that have not been accepted.
+// encore:api public
+func Search(ctx context.Context, params *SearchParams) (*SearchResponse, error) {
+	var userID uint32
+	if _, ok := auth.UserID(); ok {
+		id, err := authenticatedUserID()
+		if err != nil {
+			return nil, err
+		}
+		userID = id
+	}
+
+	results, err := search(ctx, userID, params.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	return &SearchResponse{Results: results}, nil
+}
@@ -0,0 +1,22 @@
+package conferences
+
+import (
+	"context"
+)
+
+// search runs a search for userID, zero for anonymous users. Organizers also find what is hidden
+// from the public.
+func search(ctx context.Context, userID uint32, q SearchQuery) ([]SearchResult, error) {
+	if err := q.normalize(); err != nil {
+		return nil, err
+	}
+	includeHidden := false
+	if userID != 0 {
+		organizer, err := hasRole(ctx, nil, userID, RoleOrganizer)
+		if err != nil {
+			return nil, err
+		}
+		includeHidden = organizer
+	}
+	return searchAll(ctx, &q, includeHidden)
+}
@@ -0,0 +1,71 @@
+package conferences
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+
+	speaker, err := createAttendee(ctx, nil, &User{Email: "searchedspeaker@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	organizer := createOrganizer(t, ctx, "searchingorganizer@gophercon.com")
+
+	accepted, err := submitPaper(ctx, speaker.ID, &Paper{
+		ConferenceID:  2,
+		Title:         "Zygomorphic schedulers",
+		ElevatorPitch: "How the runtime schedules goroutines",
+		Description:   "A tour of zygomorphic scheduling in the Go runtime",
+	})
+	assertDatabaseError(t, err)
+	assertDatabaseError(t, updatePaperStatus(ctx, nil, accepted, PaperStatusAccepted))
+	submitted, err := submitPaper(ctx, speaker.ID, &Paper{
+		ConferenceID:  2,
+		Title:         "Zygomorphic allocators",
+		ElevatorPitch: "Still under review",
+		Description:   "Allocation, zygomorphic style",
+	})
+	assertDatabaseError(t, err)
+
+	find := func(userID uint32, types ...SearchResultType) map[uint32]SearchResult {
+		results, err := search(ctx, userID, SearchQuery{Text: "zygomorphic", ConferenceID: 2, Types: types})
+		assertDatabaseError(t, err)
+		byID := map[uint32]SearchResult{}
+		for _, r := range results {
+			if r.Type != SearchResultPaper {
+				t.Errorf("expected only papers to match, got %+v", r)
+			}
+			byID[r.ID] = r
+		}
+		return byID
+	}
+
+	t.Run("the public only finds accepted papers", func(t *testing.T) {
+		results := find(0)
+		if _, ok := results[submitted]; ok {
+			t.Errorf("found a paper that has not been accepted")
+		}
+		result, ok := results[accepted]
+		if !ok {
+			t.Fatalf("accepted paper was not found")
+		}
+		if result.Title != "Zygomorphic schedulers" || !strings.Contains(result.Highlight, "<b>") {
+			t.Errorf("incorrect result got %+v", result)
+		}
+	})
+
+	t.Run("organizers find every paper", func(t *testing.T) {
+		results := find(organizer.ID)
+		if _, ok := results[submitted]; !ok {
+			t.Errorf("submitted paper was not found")
+		}
+	})
+
+	t.Run("results can be restricted by type", func(t *testing.T) {
+		if results := find(organizer.ID, SearchResultSponsor); len(results) != 0 {
+			t.Errorf("expected no sponsor to match, got %+v", results)
+		}
+	})
+}
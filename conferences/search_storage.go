@@ -0,0 +1,78 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+
+	"encore.dev/storage/sqldb"
+	"github.com/lib/pq"
+)
+
+// searchDocuments is the text searched for each kind of thing, the to_tsvector expressions match
+// the indexes added in the 27_add_search_indexes migration. Hidden things are only matched when
+// $2 is true.
+const searchDocuments = `
+	SELECT 'paper' AS type, id, conference_id, title,
+		title || ' ' || elevator_pitch || ' ' || description AS body,
+		ts_rank(to_tsvector('english', title || ' ' || elevator_pitch || ' ' || description), query) AS rank
+	FROM paper_submission, search_query
+	WHERE to_tsvector('english', title || ' ' || elevator_pitch || ' ' || description) @@ query
+	AND ($2 OR (status IN ('accepted', 'confirmed') AND NOT withdrawn))
+	AND ($3 = 0 OR conference_id = $3)
+	AND (cardinality($4::TEXT[]) = 0 OR 'paper' = ANY($4))
+	UNION ALL
+	SELECT 'job', id, 0, title,
+		title || ' ' || company_name || ' ' || description,
+		ts_rank(to_tsvector('english', title || ' ' || company_name || ' ' || description), query)
+	FROM job_board, search_query
+	WHERE to_tsvector('english', title || ' ' || company_name || ' ' || description) @@ query
+	AND ($2 OR approved = TRUE)
+	AND (cardinality($4::TEXT[]) = 0 OR 'job' = ANY($4))
+	UNION ALL
+	SELECT 'sponsor', id, conference_id, name, name, ts_rank(to_tsvector('english', name), query)
+	FROM sponsor, search_query
+	WHERE to_tsvector('english', name) @@ query
+	AND ($2 OR stage IN ('signed', 'invoiced', 'paid'))
+	AND ($3 = 0 OR conference_id = $3)
+	AND (cardinality($4::TEXT[]) = 0 OR 'sponsor' = ANY($4))
+	UNION ALL
+	SELECT 'session', id, conference_id, name, name || ' ' || description,
+		ts_rank(to_tsvector('english', name || ' ' || description), query)
+	FROM conference_slot, search_query
+	WHERE to_tsvector('english', name || ' ' || description) @@ query
+	AND ($2 OR (available_to_public AND retired_at IS NULL))
+	AND ($3 = 0 OR conference_id = $3)
+	AND (cardinality($4::TEXT[]) = 0 OR 'session' = ANY($4))`
+
+// searchAll returns the best matches of the query, includeHidden also matches unapproved jobs,
+// papers not accepted, sponsors not signed and slots not on sale to the public.
+func searchAll(ctx context.Context, q *SearchQuery, includeHidden bool) ([]SearchResult, error) {
+	types := make(pq.StringArray, 0, len(q.Types))
+	for _, t := range q.Types {
+		types = append(types, string(t))
+	}
+	// Highlights are only computed for the results returned.
+	rows, err := sqldb.Query(ctx, `WITH search_query AS (SELECT websearch_to_tsquery('english', $1) AS query),
+	best AS (`+searchDocuments+`
+	ORDER BY rank DESC, type, id LIMIT $5)
+	SELECT type, id, conference_id, title,
+		ts_headline('english', translate(body, $6::TEXT || $7::TEXT, ''), query,
+			'MaxFragments=2, MinWords=5, MaxWords=20, StartSel=' || $6::TEXT || ', StopSel=' || $7::TEXT), rank
+	FROM best, search_query
+	ORDER BY rank DESC, type, id`, q.Text, includeHidden, q.ConferenceID, types, q.Limit, highlightStart, highlightStop)
+	if err != nil {
+		return nil, fmt.Errorf("searching: %w", err)
+	}
+	defer rows.Close()
+
+	results := []SearchResult{}
+	for rows.Next() {
+		var r SearchResult
+		if err := rows.Scan(&r.Type, &r.ID, &r.ConferenceID, &r.Title, &r.Highlight, &r.Rank); err != nil {
+			return nil, fmt.Errorf("scanning search result: %w", err)
+		}
+		r.Highlight = highlightHTML(r.Highlight)
+		results = append(results, r)
+	}
+	return results, nil
+}
@@ -0,0 +1,89 @@
+package conferences
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// SearchResultType is the kind of thing a search result is
+type SearchResultType string
+
+// Kinds of things that can be searched for
+const (
+	SearchResultPaper   SearchResultType = "paper"
+	SearchResultJob     SearchResultType = "job"
+	SearchResultSponsor SearchResultType = "sponsor"
+	SearchResultSession SearchResultType = "session"
+)
+
+var searchResultTypes = []SearchResultType{SearchResultPaper, SearchResultJob, SearchResultSponsor, SearchResultSession}
+
+// SearchQuery is what to search for
+type SearchQuery struct {
+	// Text is written as in a search box: words, "quoted phrases", OR and -excluded words.
+	Text string
+	// ConferenceID restricts papers, sponsors and sessions to a conference, jobs are not attached
+	// to any and are always searched.
+	ConferenceID uint32
+	// Types restricts the results to some kinds of things, all of them if empty.
+	Types []SearchResultType
+	// Limit is how many results to return, defaultPageLimit if zero and at most maxPageLimit.
+	Limit int
+}
+
+// SearchResult is one thing matching a search
+type SearchResult struct {
+	Type SearchResultType
+	// ID is the ID of the paper, job, sponsor or conference slot.
+	ID uint32
+	// ConferenceID is zero for jobs.
+	ConferenceID uint32
+	Title        string
+	// Highlight is an excerpt of the matching text, HTML escaped, with the matching words wrapped
+	// in <b></b>.
+	Highlight string
+	// Rank is how well the thing matches, results come from the best to the worst.
+	Rank float64
+}
+
+// normalize trims the query text and checks the query can be run.
+func (q *SearchQuery) normalize() error {
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Text == "" {
+		return fmt.Errorf("Text is required")
+	}
+	for _, t := range q.Types {
+		known := false
+		for _, st := range searchResultTypes {
+			known = known || t == st
+		}
+		if !known {
+			return fmt.Errorf("unknown result type %q", t)
+		}
+	}
+	switch {
+	case q.Limit < 0:
+		return fmt.Errorf("limit cannot be negative")
+	case q.Limit == 0:
+		q.Limit = defaultPageLimit
+	case q.Limit > maxPageLimit:
+		q.Limit = maxPageLimit
+	}
+	return nil
+}
+
+// The database marks the matching words of a highlight with control characters, which are taken
+// out of the text beforehand, so the text can be escaped before the marks become tags.
+const (
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+)
+
+var highlightTags = strings.NewReplacer(highlightStart, "<b>", highlightStop, "</b>")
+
+// highlightHTML escapes a highlight marked with highlightStart and highlightStop and wraps the
+// matching words in <b></b>.
+func highlightHTML(marked string) string {
+	return highlightTags.Replace(html.EscapeString(marked))
+}
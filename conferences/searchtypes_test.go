@@ -0,0 +1,43 @@
+package conferences
+
+import (
+	"testing"
+)
+
+func TestSearchQueryNormalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     SearchQuery
+		wantErr   bool
+		wantText  string
+		wantLimit int
+	}{
+		{name: "defaults", query: SearchQuery{Text: "  generics "}, wantText: "generics", wantLimit: defaultPageLimit},
+		{name: "limit is clamped", query: SearchQuery{Text: "go", Limit: 1000}, wantText: "go", wantLimit: maxPageLimit},
+		{name: "known types", query: SearchQuery{Text: "go", Types: []SearchResultType{SearchResultJob, SearchResultSession}, Limit: 5},
+			wantText: "go", wantLimit: 5},
+		{name: "blank text", query: SearchQuery{Text: "  "}, wantErr: true},
+		{name: "unknown type", query: SearchQuery{Text: "go", Types: []SearchResultType{"venue"}}, wantErr: true},
+		{name: "negative limit", query: SearchQuery{Text: "go", Limit: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.query
+			err := q.normalize()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalize() got error %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && (q.Text != tt.wantText || q.Limit != tt.wantLimit) {
+				t.Errorf("normalize() got %q limited to %d, want %q limited to %d", q.Text, q.Limit, tt.wantText, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestHighlightHTML(t *testing.T) {
+	marked := "<script>alert(1)</script> \x02schedulers\x03 & more"
+	want := "&lt;script&gt;alert(1)&lt;/script&gt; <b>schedulers</b> &amp; more"
+	if got := highlightHTML(marked); got != want {
+		t.Errorf("highlightHTML() = %q, want %q", got, want)
+	}
+}
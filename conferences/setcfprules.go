@@ -0,0 +1,41 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// SetCFPRulesParams defines the inputs used by the SetCFPRules API method
+type SetCFPRulesParams struct {
+	Rules *CFPRules
+}
+
+// SetCFPRules defines the call for papers window and submission rules of a conference
+// encore:api auth
+func SetCFPRules(ctx context.Context, params *SetCFPRulesParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+
+	if params.Rules == nil {
+		return fmt.Errorf("Rules is required")
+	}
+	if !params.Rules.ClosesAt.After(params.Rules.OpensAt) {
+		return fmt.Errorf("the call for papers must close after it opens")
+	}
+	if params.Rules.MaxSubmissionsPerSpeaker < 0 || params.Rules.TitleMaxLength < 0 ||
+		params.Rules.ElevatorPitchMaxLength < 0 || params.Rules.DescriptionMinLength < 0 ||
+		params.Rules.DescriptionMaxLength < 0 {
+		return fmt.Errorf("limits cannot be negative")
+	}
+
+	if err := upsertCFPRules(ctx, params.Rules); err != nil {
+		return fmt.Errorf("failed to set cfp rules: %w", err)
+	}
+
+	return nil
+}
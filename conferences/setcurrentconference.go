@@ -0,0 +1,28 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// SetCurrentConferenceParams defines the inputs used by the SetCurrentConference API method
+type SetCurrentConferenceParams struct {
+	ConferenceID uint32
+	Override     CurrentOverride
+}
+
+// SetCurrentConference makes a conference always or never the current one of its event, or lets
+// its dates decide again, only organizers can do so
+// encore:api auth
+func SetCurrentConference(ctx context.Context, params *SetCurrentConferenceParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := setCurrentOverride(ctx, userID, params.ConferenceID, params.Override); err != nil {
+		return fmt.Errorf("failed to set current conference: %w", err)
+	}
+
+	return nil
+}
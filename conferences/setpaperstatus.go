@@ -0,0 +1,52 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SetPaperStatusParams defines the inputs used by the SetPaperStatus API method
+type SetPaperStatusParams struct {
+	PaperID uint32
+	Status  PaperStatus
+}
+
+// SetPaperStatus accepts, waitlists or rejects a paper
+// encore:api auth
+func SetPaperStatus(ctx context.Context, params *SetPaperStatusParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+
+	if err := changePaperStatus(ctx, params.PaperID, params.Status); err != nil {
+		return fmt.Errorf("failed to set paper status: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmPaperParams defines the inputs used by the ConfirmPaper API method
+type ConfirmPaperParams struct {
+	PaperID uint32
+}
+
+// ConfirmPaper lets a speaker confirm they will give their accepted talk before the confirmation
+// deadline, its speakers get a complimentary admission if the conference has one for speakers
+// encore:api auth
+func ConfirmPaper(ctx context.Context, params *ConfirmPaperParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := confirmPaper(ctx, userID, params.PaperID, time.Now()); err != nil {
+		return fmt.Errorf("failed to confirm paper: %w", err)
+	}
+
+	return nil
+}
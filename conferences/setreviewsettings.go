@@ -0,0 +1,33 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// SetReviewSettingsParams defines the inputs used by the SetReviewSettings API method
+type SetReviewSettingsParams struct {
+	Settings *ReviewSettings
+}
+
+// SetReviewSettings configures redaction and unblinds, or blinds again, the review of a conference
+// encore:api auth
+func SetReviewSettings(ctx context.Context, params *SetReviewSettingsParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+
+	if params.Settings == nil {
+		return fmt.Errorf("Settings is required")
+	}
+
+	if err := upsertReviewSettings(ctx, params.Settings); err != nil {
+		return fmt.Errorf("failed to set review settings: %w", err)
+	}
+
+	return nil
+}
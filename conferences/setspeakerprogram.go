@@ -0,0 +1,31 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// SetSpeakerProgramParams defines the inputs used by the SetSpeakerProgram API method
+type SetSpeakerProgramParams struct {
+	Program *SpeakerProgram
+}
+
+// SetSpeakerProgram sets the slot speakers get a complimentary admission to and the deadline for
+// them to confirm their talks, only organizers can do so
+// encore:api auth
+func SetSpeakerProgram(ctx context.Context, params *SetSpeakerProgramParams) error {
+	if params.Program == nil {
+		return fmt.Errorf("Program is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := setSpeakerProgram(ctx, userID, params.Program); err != nil {
+		return fmt.Errorf("failed to set speaker program: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// SetSponsorContractParams defines the inputs used by the SetSponsorContract API method
+type SetSponsorContractParams struct {
+	SponsorID           uint32
+	ContractAmountCents int64
+	BillingContactID    uint32
+	// Schedule replaces the installments of the contract, they must add up to its amount.
+	Schedule []Installment
+}
+
+// SetSponsorContractResponse defines the output returned by the SetSponsorContract API method
+type SetSponsorContractResponse struct {
+	Deal *SponsorDeal
+}
+
+// SetSponsorContract saves the amount, billing contact and payment schedule of the contract with a
+// sponsor until it is signed, only organizers can do so
+// encore:api auth
+func SetSponsorContract(ctx context.Context, params *SetSponsorContractParams) (*SetSponsorContractResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	deal, err := setSponsorContract(ctx, userID, &SponsorDeal{
+		SponsorID:           params.SponsorID,
+		ContractAmountCents: params.ContractAmountCents,
+		BillingContactID:    params.BillingContactID,
+		Schedule:            params.Schedule,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set sponsor contract: %w", err)
+	}
+
+	return &SetSponsorContractResponse{Deal: deal}, nil
+}
+
+// MoveSponsorDealParams defines the inputs used by the MoveSponsorDeal API method
+type MoveSponsorDealParams struct {
+	SponsorID uint32
+	Stage     DealStage
+}
+
+// MoveSponsorDealResponse defines the output returned by the MoveSponsorDeal API method
+type MoveSponsorDealResponse struct {
+	Deal *SponsorDeal
+}
+
+// MoveSponsorDeal moves the deal with a sponsor through the pipeline up to signed, invoices and
+// payments take it further. Only organizers can do so
+// encore:api auth
+func MoveSponsorDeal(ctx context.Context, params *MoveSponsorDealParams) (*MoveSponsorDealResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	deal, err := moveSponsorDeal(ctx, userID, params.SponsorID, params.Stage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move sponsor deal: %w", err)
+	}
+
+	return &MoveSponsorDealResponse{Deal: deal}, nil
+}
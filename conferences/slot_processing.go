@@ -0,0 +1,156 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// saveConferenceSlot creates a slot of a conference, or edits it if it has an ID. The location
+// must belong to the venue of the conference and the slot it depends on to the same conference.
+func saveConferenceSlot(ctx context.Context, userID uint32, slot *ConferenceSlot) (*ConferenceSlot, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	s := *slot
+
+	var existing *ConferenceSlot
+	if s.ID != 0 {
+		var err error
+		existing, err = readConferenceSlotByID(ctx, nil, uint64(s.ID), false)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, fmt.Errorf("no such slot")
+		}
+		if existing.Retired {
+			return nil, fmt.Errorf("retired slots cannot be changed")
+		}
+		// Slots stay in their conference, claims and payments were made for it.
+		s.ConferenceID = existing.ConferenceID
+	}
+
+	conference, err := readConferenceByID(ctx, s.ConferenceID)
+	if err != nil {
+		return nil, err
+	}
+	if conference == nil || conference.Archived {
+		return nil, fmt.Errorf("no such conference")
+	}
+	location, err := readLocationByID(ctx, s.Location.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := location.atVenue(conference.Venue.ID); err != nil {
+		return nil, err
+	}
+	if location.Archived && (existing == nil || existing.Location.ID != location.ID) {
+		return nil, fmt.Errorf("location %s was archived", location.Name)
+	}
+	if err := s.validate(location); err != nil {
+		return nil, err
+	}
+
+	if s.DependsOn != 0 {
+		dependency, err := readConferenceSlotByID(ctx, nil, uint64(s.DependsOn), false)
+		if err != nil {
+			return nil, err
+		}
+		if dependency == nil || dependency.ConferenceID != s.ConferenceID {
+			return nil, fmt.Errorf("no such slot to depend on in the conference")
+		}
+		// a slot may keep depending on a slot retired since, it cannot start to.
+		if dependency.Retired && (existing == nil || existing.DependsOn != dependency.ID) {
+			return nil, fmt.Errorf("slot %d was retired", dependency.ID)
+		}
+		if s.ID != 0 {
+			if err := requireNoDependencyCycle(ctx, s.ID, dependency); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var saved *ConferenceSlot
+	if existing == nil {
+		saved, err = createConferenceSlot(ctx, nil, &s, int64(s.ConferenceID))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		claims, err := countSlotClaims(ctx, nil, s.ID)
+		if err != nil {
+			return nil, err
+		}
+		if s.Capacity < claims {
+			return nil, fmt.Errorf("capacity cannot be lower than the %d claims already made", claims)
+		}
+		if err := updateConferenceSlot(ctx, nil, &s, int64(s.ConferenceID)); err != nil {
+			return nil, err
+		}
+		saved, err = readConferenceSlotByID(ctx, nil, uint64(s.ID), false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	saved.Location = *location
+	return saved, nil
+}
+
+// requireNoDependencyCycle returns an error if the slot is somewhere down the chain of slots
+// the dependency depends on, as depending on it would close a cycle.
+func requireNoDependencyCycle(ctx context.Context, slotID uint32, dependency *ConferenceSlot) error {
+	if dependency.ID == slotID {
+		return fmt.Errorf("slot %d cannot depend on itself", slotID)
+	}
+	seen := map[uint32]bool{}
+	for next := dependency; next != nil && next.DependsOn != 0; {
+		if next.DependsOn == slotID {
+			return fmt.Errorf("slot %d cannot depend on slot %d, which depends on it", slotID, dependency.ID)
+		}
+		if seen[next.DependsOn] {
+			return nil
+		}
+		seen[next.DependsOn] = true
+		var err error
+		next, err = readConferenceSlotByID(ctx, nil, uint64(next.DependsOn), false)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cloneConferenceSlot copies a slot, into another conference if conferenceID is not zero and
+// moved to startDate if it is not zero. A zero locationID keeps the location of the original,
+// the copy only keeps what it depends on when it stays in the same conference.
+func cloneConferenceSlot(ctx context.Context, userID, slotID, conferenceID, locationID uint32, startDate time.Time, name string) (*ConferenceSlot, error) {
+	source, err := readConferenceSlotByID(ctx, nil, uint64(slotID), false)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, fmt.Errorf("no such slot")
+	}
+
+	c := source.clone(startDate)
+	if name != "" {
+		c.Name = name
+	}
+	if conferenceID != 0 && conferenceID != c.ConferenceID {
+		c.ConferenceID = conferenceID
+		c.DependsOn = 0
+	}
+	if locationID != 0 {
+		c.Location = Location{ID: locationID}
+	}
+	return saveConferenceSlot(ctx, userID, &c)
+}
+
+// retireSlot takes a slot off sale, only organizers can do so.
+func retireSlot(ctx context.Context, userID, slotID uint32, now time.Time) error {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+	return retireConferenceSlot(ctx, nil, slotID, now)
+}
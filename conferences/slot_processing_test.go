@@ -0,0 +1,140 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestConferenceSlotManagement(t *testing.T) {
+	ctx := context.Background()
+
+	organizer := createOrganizer(t, ctx, "slot-manager@gophercon.com")
+	attendee, err := createAttendee(ctx, nil, &User{Email: "slot-buyer@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+
+	// GopherCon 2021 takes place at Disneyworld, where Cinderella Castle is.
+	start := time.Date(2021, 11, 10, 17, 0, 0, 0, time.UTC)
+	newSlot := func() *ConferenceSlot {
+		return &ConferenceSlot{
+			Name:              "Managed workshop",
+			Description:       "Hands on",
+			Cost:              20000,
+			Capacity:          100,
+			StartDate:         start,
+			EndDate:           start.Add(4 * time.Hour),
+			PurchaseableFrom:  start.Add(-60 * 24 * time.Hour),
+			PurchaseableUntil: start.Add(-24 * time.Hour),
+			AvailableToPublic: true,
+			Location:          Location{ID: 2},
+			ConferenceID:      2,
+		}
+	}
+
+	var slot, dependent *ConferenceSlot
+	t.Run("create", func(t *testing.T) {
+		if _, err := saveConferenceSlot(ctx, attendee.ID, newSlot()); err == nil {
+			t.Errorf("attendees creating slots did not cause an error")
+		}
+		elsewhere := newSlot()
+		elsewhere.Location = Location{ID: 1}
+		if _, err := saveConferenceSlot(ctx, organizer.ID, elsewhere); err == nil {
+			t.Errorf("slot at a location of another venue did not cause an error")
+		}
+		crowded := newSlot()
+		crowded.Capacity = 301
+		if _, err := saveConferenceSlot(ctx, organizer.ID, crowded); err == nil {
+			t.Errorf("slot over the location capacity did not cause an error")
+		}
+
+		slot, err = saveConferenceSlot(ctx, organizer.ID, newSlot())
+		assertDatabaseError(t, err)
+		if slot.ID == 0 || slot.ConferenceID != 2 || slot.Location.ID != 2 || slot.Location.Name == "" {
+			t.Fatalf("incorrect slot got %+v", slot)
+		}
+
+		d := newSlot()
+		d.Name = "Workshop lunch"
+		d.DependsOn = slot.ID
+		dependent, err = saveConferenceSlot(ctx, organizer.ID, d)
+		assertDatabaseError(t, err)
+		if dependent.DependsOn != slot.ID {
+			t.Errorf("dependency was not saved got %+v", dependent)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		edited := *slot
+		edited.Name = "Managed workshop, extended"
+		edited.EndDate = start.Add(6 * time.Hour)
+		edited.ConferenceID = 1
+		updated, err := saveConferenceSlot(ctx, organizer.ID, &edited)
+		assertDatabaseError(t, err)
+		if updated.Name != edited.Name || !updated.EndDate.Equal(edited.EndDate) || updated.ConferenceID != 2 {
+			t.Errorf("incorrect update got %+v", updated)
+		}
+
+		cyclic := *slot
+		cyclic.DependsOn = dependent.ID
+		if _, err := saveConferenceSlot(ctx, organizer.ID, &cyclic); err == nil {
+			t.Errorf("slots depending on each other did not cause an error")
+		}
+		c := newSlot()
+		c.Name = "Workshop dessert"
+		c.DependsOn = dependent.ID
+		chained, err := saveConferenceSlot(ctx, organizer.ID, c)
+		assertDatabaseError(t, err)
+		cyclic.DependsOn = chained.ID
+		if _, err := saveConferenceSlot(ctx, organizer.ID, &cyclic); err == nil {
+			t.Errorf("slots depending on each other through a chain did not cause an error")
+		}
+
+		_, err = claimSlots(ctx, attendee, []ConferenceSlot{*slot})
+		assertDatabaseError(t, err)
+		shrunk := *slot
+		shrunk.Capacity = 0
+		if _, err := saveConferenceSlot(ctx, organizer.ID, &shrunk); err == nil {
+			t.Errorf("capacity below the claims made did not cause an error")
+		}
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		nextDay := start.Add(24 * time.Hour)
+		clone, err := cloneConferenceSlot(ctx, organizer.ID, dependent.ID, 0, 0, nextDay, "Second workshop lunch")
+		assertDatabaseError(t, err)
+		if clone.ID == dependent.ID || clone.Name != "Second workshop lunch" || !clone.StartDate.Equal(nextDay) ||
+			clone.DependsOn != slot.ID {
+			t.Errorf("incorrect clone got %+v", clone)
+		}
+		if _, err := cloneConferenceSlot(ctx, organizer.ID, dependent.ID, 1, 0, time.Time{}, ""); err == nil {
+			t.Errorf("cloning into a conference at another venue without a location did not cause an error")
+		}
+	})
+
+	t.Run("retire", func(t *testing.T) {
+		if err := retireSlot(ctx, attendee.ID, slot.ID, time.Now()); err == nil {
+			t.Errorf("attendees retiring slots did not cause an error")
+		}
+		assertDatabaseError(t, retireSlot(ctx, organizer.ID, slot.ID, time.Now()))
+		retired, err := readConferenceSlotByID(ctx, nil, uint64(slot.ID), false)
+		assertDatabaseError(t, err)
+		if !retired.Retired || retired.AvailableToPublic {
+			t.Errorf("incorrect retired slot got %+v", retired)
+		}
+		if _, err := saveConferenceSlot(ctx, organizer.ID, retired); err == nil {
+			t.Errorf("editing a retired slot did not cause an error")
+		}
+		stillDependent := *dependent
+		stillDependent.Name = "Workshop lunch, moved"
+		_, err = saveConferenceSlot(ctx, organizer.ID, &stillDependent)
+		assertDatabaseError(t, err)
+		d := newSlot()
+		d.DependsOn = slot.ID
+		if _, err := saveConferenceSlot(ctx, organizer.ID, d); err == nil {
+			t.Errorf("depending on a retired slot did not cause an error")
+		}
+		if err := retireSlot(ctx, organizer.ID, slot.ID, time.Now()); err == nil {
+			t.Errorf("retiring twice did not cause an error")
+		}
+	})
+}
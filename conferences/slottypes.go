@@ -0,0 +1,56 @@
+package conferences
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// validate trims the slot and returns an error if it is not valid or does not fit in the
+// location it takes place at.
+func (s *ConferenceSlot) validate(location *Location) error {
+	s.Name = strings.TrimSpace(s.Name)
+	s.Description = strings.TrimSpace(s.Description)
+	if s.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if s.Cost < 0 || s.Capacity < 0 {
+		return fmt.Errorf("cost and capacity cannot be negative")
+	}
+	if s.StartDate.IsZero() || !s.EndDate.After(s.StartDate) {
+		return fmt.Errorf("end date must be after start date")
+	}
+	if s.PurchaseableFrom.IsZero() || !s.PurchaseableUntil.After(s.PurchaseableFrom) {
+		return fmt.Errorf("purchase window must end after it starts")
+	}
+	if s.PurchaseableUntil.After(s.StartDate) {
+		return fmt.Errorf("purchase window must close before the slot starts")
+	}
+	if s.Location.ID != location.ID {
+		return fmt.Errorf("slot does not take place at location %d", location.ID)
+	}
+	if s.Capacity > location.Capacity {
+		return fmt.Errorf("capacity cannot exceed the %d people %s holds", location.Capacity, location.Name)
+	}
+	if s.DependsOn != 0 && s.DependsOn == s.ID {
+		return fmt.Errorf("a slot cannot depend on itself")
+	}
+	return nil
+}
+
+// clone returns a copy of the slot, not saved yet, moved so it starts at startDate, its purchase
+// window moves along. A zero startDate keeps the dates.
+func (s *ConferenceSlot) clone(startDate time.Time) ConferenceSlot {
+	c := *s
+	c.ID = 0
+	c.Retired = false
+	if startDate.IsZero() {
+		return c
+	}
+	shift := startDate.Sub(s.StartDate)
+	c.StartDate = s.StartDate.Add(shift)
+	c.EndDate = s.EndDate.Add(shift)
+	c.PurchaseableFrom = s.PurchaseableFrom.Add(shift)
+	c.PurchaseableUntil = s.PurchaseableUntil.Add(shift)
+	return c
+}
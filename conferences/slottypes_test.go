@@ -0,0 +1,71 @@
+package conferences
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConferenceSlotValidate(t *testing.T) {
+	start := time.Date(2021, 11, 10, 17, 0, 0, 0, time.UTC)
+	location := &Location{ID: 2, Name: "Cinderella Castle", Capacity: 300}
+	valid := ConferenceSlot{
+		ID:                7,
+		Name:              "Workshop",
+		Capacity:          300,
+		StartDate:         start,
+		EndDate:           start.Add(4 * time.Hour),
+		PurchaseableFrom:  start.Add(-60 * 24 * time.Hour),
+		PurchaseableUntil: start.Add(-24 * time.Hour),
+		Location:          Location{ID: 2},
+	}
+
+	tests := []struct {
+		name    string
+		edit    func(s *ConferenceSlot)
+		wantErr bool
+	}{
+		{name: "valid slot", edit: func(s *ConferenceSlot) {}},
+		{name: "missing name", edit: func(s *ConferenceSlot) { s.Name = "" }, wantErr: true},
+		{name: "ends before it starts", edit: func(s *ConferenceSlot) { s.EndDate = start }, wantErr: true},
+		{name: "sale closes after it starts", edit: func(s *ConferenceSlot) { s.PurchaseableUntil = start.Add(time.Hour) }, wantErr: true},
+		{name: "sale closes before it opens", edit: func(s *ConferenceSlot) { s.PurchaseableFrom = s.PurchaseableUntil }, wantErr: true},
+		{name: "over location capacity", edit: func(s *ConferenceSlot) { s.Capacity = 301 }, wantErr: true},
+		{name: "another location", edit: func(s *ConferenceSlot) { s.Location.ID = 1 }, wantErr: true},
+		{name: "depends on itself", edit: func(s *ConferenceSlot) { s.DependsOn = 7 }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid
+			tt.edit(&s)
+			if err := s.validate(location); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConferenceSlotClone(t *testing.T) {
+	start := time.Date(2021, 11, 10, 17, 0, 0, 0, time.UTC)
+	slot := ConferenceSlot{
+		ID:                7,
+		Retired:           true,
+		StartDate:         start,
+		EndDate:           start.Add(4 * time.Hour),
+		PurchaseableFrom:  start.Add(-48 * time.Hour),
+		PurchaseableUntil: start.Add(-24 * time.Hour),
+	}
+
+	same := slot.clone(time.Time{})
+	if same.ID != 0 || same.Retired || !same.StartDate.Equal(start) {
+		t.Errorf("incorrect clone got %+v", same)
+	}
+
+	nextYear := start.AddDate(1, 0, 0)
+	moved := slot.clone(nextYear)
+	shift := nextYear.Sub(start)
+	if !moved.StartDate.Equal(nextYear) || !moved.EndDate.Equal(slot.EndDate.Add(shift)) ||
+		!moved.PurchaseableFrom.Equal(slot.PurchaseableFrom.Add(shift)) ||
+		!moved.PurchaseableUntil.Equal(slot.PurchaseableUntil.Add(shift)) {
+		t.Errorf("incorrect moved clone got %+v", moved)
+	}
+}
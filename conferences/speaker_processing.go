@@ -0,0 +1,108 @@
+package conferences
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// headshotKey is where the headshot of a user is kept in the upload store.
+func headshotKey(userID uint32) string {
+	return fmt.Sprintf("headshots/%d", userID)
+}
+
+// updateSpeakerProfile saves the profile of the user and returns it as stored.
+func updateSpeakerProfile(ctx context.Context, userID uint32, profile *SpeakerProfile) (*SpeakerProfile, error) {
+	edited := *profile
+	edited.UserID = userID
+	if err := edited.normalize(); err != nil {
+		return nil, err
+	}
+	if err := upsertSpeakerProfile(ctx, &edited); err != nil {
+		return nil, err
+	}
+	return readSpeakerProfile(ctx, userID)
+}
+
+// uploadHeadshot stores an image as the headshot of the user replacing any previous one.
+func uploadHeadshot(ctx context.Context, userID uint32, image []byte) error {
+	contentType, err := detectImage(image)
+	if err != nil {
+		return err
+	}
+	key := headshotKey(userID)
+	if err := uploads.put(key, image); err != nil {
+		return err
+	}
+	return updateHeadshot(ctx, userID, key, contentType)
+}
+
+// publicHeadshot returns the headshot of a speaker with an accepted talk and its content type,
+// others are not published.
+func publicHeadshot(ctx context.Context, userID uint32) ([]byte, string, error) {
+	public, err := isPublicSpeaker(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	key, contentType, err := readHeadshot(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !public || key == "" {
+		return nil, "", fmt.Errorf("no such headshot")
+	}
+	image, err := uploads.get(key)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, "", fmt.Errorf("no such headshot")
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return image, contentType, nil
+}
+
+// conferenceSpeakers returns the speakers of the accepted talks of a conference sorted by name.
+func conferenceSpeakers(ctx context.Context, conferenceID uint32) ([]Speaker, error) {
+	papers, paperSpeakers, err := readAcceptedTalks(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	talks := map[uint32][]PublicTalk{}
+	userIDs := []uint32{}
+	for _, p := range papers {
+		for _, userID := range paperSpeakers[p.ID] {
+			if _, ok := talks[userID]; !ok {
+				userIDs = append(userIDs, userID)
+			}
+			talks[userID] = append(talks[userID], publicTalk(p))
+		}
+	}
+	profiles, err := readSpeakerProfiles(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	speakers := make([]Speaker, 0, len(userIDs))
+	for _, userID := range userIDs {
+		profile, ok := profiles[userID]
+		if !ok {
+			continue
+		}
+		speakers = append(speakers, Speaker{Profile: *profile, Talks: talks[userID]})
+	}
+	sort.SliceStable(speakers, func(i, j int) bool {
+		a, b := speakers[i].Profile, speakers[j].Profile
+		if !strings.EqualFold(a.FamilyName, b.FamilyName) {
+			return strings.ToLower(a.FamilyName) < strings.ToLower(b.FamilyName)
+		}
+		if !strings.EqualFold(a.GivenName, b.GivenName) {
+			return strings.ToLower(a.GivenName) < strings.ToLower(b.GivenName)
+		}
+		return a.UserID < b.UserID
+	})
+	return speakers, nil
+}
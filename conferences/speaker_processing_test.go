@@ -0,0 +1,54 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+)
+
+func TestListSpeakers(t *testing.T) {
+	ctx := context.Background()
+
+	accepted, err := createAttendee(ctx, nil, &User{Email: "accepted@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	rejected, err := createAttendee(ctx, nil, &User{Email: "rejected@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+
+	_, err = updateSpeakerProfile(ctx, accepted.ID, &SpeakerProfile{Bio: "Gopher since 2012", Twitter: "@accepted"})
+	assertDatabaseError(t, err)
+
+	submit := func(speakerID uint32, title string, status PaperStatus) uint32 {
+		paperID, err := submitPaper(ctx, speakerID, &Paper{
+			ConferenceID:  1,
+			Title:         title,
+			ElevatorPitch: "Pitch",
+			Description:   "Description",
+		})
+		assertDatabaseError(t, err)
+		assertDatabaseError(t, updatePaperStatus(ctx, nil, paperID, status))
+		return paperID
+	}
+	acceptedPaper := submit(accepted.ID, "Accepted talk", PaperStatusAccepted)
+	submit(rejected.ID, "Rejected talk", PaperStatusRejected)
+
+	speakers, err := conferenceSpeakers(ctx, 1)
+	assertDatabaseError(t, err)
+
+	var found *Speaker
+	for i, s := range speakers {
+		if s.Profile.UserID == rejected.ID {
+			t.Errorf("speaker without accepted talks was listed")
+		}
+		if s.Profile.UserID == accepted.ID {
+			found = &speakers[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("speaker with an accepted talk was not listed")
+	}
+	if found.Profile.Twitter != "accepted" || found.Profile.Bio != "Gopher since 2012" {
+		t.Errorf("incorrect profile got %+v", found.Profile)
+	}
+	if len(found.Talks) != 1 || found.Talks[0].PaperID != acceptedPaper {
+		t.Errorf("incorrect talks got %+v", found.Talks)
+	}
+}
@@ -0,0 +1,164 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"encore.dev/storage/sqldb"
+	"github.com/lib/pq"
+)
+
+const speakerProfileColumns = `users.id, COALESCE(users.given_name, ''), COALESCE(users.family_name, ''),
+	COALESCE(speaker_profile.bio, ''), COALESCE(speaker_profile.company, ''), COALESCE(speaker_profile.pronouns, ''),
+	COALESCE(speaker_profile.twitter, ''), COALESCE(speaker_profile.github, ''), COALESCE(speaker_profile.mastodon, ''),
+	COALESCE(speaker_profile.linkedin, ''), COALESCE(speaker_profile.website, ''),
+	COALESCE(speaker_profile.headshot_key, '') <> ''
+	FROM users LEFT JOIN speaker_profile ON speaker_profile.user_id = users.id`
+
+// scanSpeakerProfile scans a row selected with speakerProfileColumns.
+func scanSpeakerProfile(scan func(dest ...interface{}) error) (*SpeakerProfile, error) {
+	var p SpeakerProfile
+	err := scan(&p.UserID,
+		&p.GivenName,
+		&p.FamilyName,
+		&p.Bio,
+		&p.Company,
+		&p.Pronouns,
+		&p.Twitter,
+		&p.GitHub,
+		&p.Mastodon,
+		&p.LinkedIn,
+		&p.Website,
+		&p.HasHeadshot)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// readSpeakerProfile returns the profile of a user, empty if they did not fill it in and nil if
+// there is no such user.
+func readSpeakerProfile(ctx context.Context, userID uint32) (*SpeakerProfile, error) {
+	row := sqldb.QueryRow(ctx, `SELECT `+speakerProfileColumns+` WHERE users.id = $1`, userID)
+
+	profile, err := scanSpeakerProfile(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading speaker profile: %w", err)
+	}
+	return profile, nil
+}
+
+// readSpeakerProfiles returns the profiles of the passed users by user ID.
+func readSpeakerProfiles(ctx context.Context, userIDs []uint32) (map[uint32]*SpeakerProfile, error) {
+	ids := make(pq.Int64Array, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, int64(id))
+	}
+	rows, err := sqldb.Query(ctx, `SELECT `+speakerProfileColumns+` WHERE users.id = ANY($1)`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("querying speaker profiles: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := map[uint32]*SpeakerProfile{}
+	for rows.Next() {
+		profile, err := scanSpeakerProfile(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning speaker profile: %w", err)
+		}
+		profiles[profile.UserID] = profile
+	}
+	return profiles, nil
+}
+
+// upsertSpeakerProfile saves the editable fields of a speaker profile, the headshot is left as is.
+func upsertSpeakerProfile(ctx context.Context, p *SpeakerProfile) error {
+	_, err := sqldb.Exec(ctx, `INSERT INTO speaker_profile (user_id, bio, company, pronouns, twitter, github, mastodon,
+	linkedin, website) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (user_id) DO UPDATE SET bio = $2, company = $3, pronouns = $4, twitter = $5, github = $6,
+	mastodon = $7, linkedin = $8, website = $9, updated_at = NOW()`,
+		p.UserID, p.Bio, p.Company, p.Pronouns, p.Twitter, p.GitHub, p.Mastodon, p.LinkedIn, p.Website)
+	if err != nil {
+		return fmt.Errorf("saving speaker profile: %w", err)
+	}
+	return nil
+}
+
+// updateHeadshot records where the headshot of a user is stored.
+func updateHeadshot(ctx context.Context, userID uint32, key, contentType string) error {
+	_, err := sqldb.Exec(ctx, `INSERT INTO speaker_profile (user_id, headshot_key, headshot_content_type)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (user_id) DO UPDATE SET headshot_key = $2, headshot_content_type = $3, updated_at = NOW()`,
+		userID, key, contentType)
+	if err != nil {
+		return fmt.Errorf("saving headshot: %w", err)
+	}
+	return nil
+}
+
+// readHeadshot returns where the headshot of a user is stored, an empty key if they have none.
+func readHeadshot(ctx context.Context, userID uint32) (key string, contentType string, err error) {
+	row := sqldb.QueryRow(ctx, `SELECT headshot_key, headshot_content_type FROM speaker_profile WHERE user_id = $1`, userID)
+
+	err = row.Scan(&key, &contentType)
+	if err == sql.ErrNoRows {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("reading headshot: %w", err)
+	}
+	return key, contentType, nil
+}
+
+// acceptedTalksCondition matches papers attendees can see.
+const acceptedTalksCondition = `paper_submission.withdrawn = FALSE AND paper_submission.status IN ('accepted', 'confirmed')`
+
+// paperSpeakers lists every speaker of a paper, the one who submitted it and accepted co-speakers.
+const paperSpeakers = `(SELECT id AS paper_id, user_id FROM paper_submission
+	UNION SELECT paper_id, user_id FROM paper_cospeaker WHERE status = 'accepted') AS speakers`
+
+// isPublicSpeaker returns true if the user presents an accepted talk at any conference.
+func isPublicSpeaker(ctx context.Context, userID uint32) (bool, error) {
+	row := sqldb.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM paper_submission
+	JOIN `+paperSpeakers+` ON speakers.paper_id = paper_submission.id
+	WHERE speakers.user_id = $1 AND `+acceptedTalksCondition+`)`, userID)
+
+	var public bool
+	if err := row.Scan(&public); err != nil {
+		return false, fmt.Errorf("checking speaker talks: %w", err)
+	}
+	return public, nil
+}
+
+// readAcceptedTalks returns the accepted papers of a conference and their speakers by paper ID.
+func readAcceptedTalks(ctx context.Context, conferenceID uint32) ([]Paper, map[uint32][]uint32, error) {
+	rows, err := sqldb.Query(ctx, `SELECT speakers.user_id, `+prefixedPaperColumns+` FROM paper_submission
+	JOIN `+paperSpeakers+` ON speakers.paper_id = paper_submission.id
+	WHERE paper_submission.conference_id = $1 AND `+acceptedTalksCondition+`
+	ORDER BY paper_submission.id, speakers.user_id`, conferenceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("querying accepted talks: %w", err)
+	}
+	defer rows.Close()
+
+	papers := []Paper{}
+	speakers := map[uint32][]uint32{}
+	for rows.Next() {
+		var speakerID uint32
+		paper, err := scanPaper(func(dest ...interface{}) error {
+			return rows.Scan(append([]interface{}{&speakerID}, dest...)...)
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("scanning accepted talk: %w", err)
+		}
+		if len(papers) == 0 || papers[len(papers)-1].ID != paper.ID {
+			papers = append(papers, *paper)
+		}
+		speakers[paper.ID] = append(speakers[paper.ID], speakerID)
+	}
+	return papers, speakers, nil
+}
@@ -0,0 +1,277 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+// complimentaryAdmissionDetail describes the discount covering the admission of speakers.
+const complimentaryAdmissionDetail = "100% speaker"
+
+// confirmPaper records that the speaker will give their accepted talk before the confirmation
+// deadline and hands its speakers their complimentary admission. Confirming again hands out any
+// admission that could not be issued the first time.
+func confirmPaper(ctx context.Context, speakerID, paperID uint32, now time.Time) error {
+	paper, err := readPaperByID(ctx, nil, paperID)
+	if err != nil {
+		return err
+	}
+	if paper == nil || paper.UserID != speakerID || paper.Withdrawn {
+		return fmt.Errorf("no such paper")
+	}
+	program, err := readSpeakerProgram(ctx, paper.ConferenceID)
+	if err != nil {
+		return err
+	}
+
+	switch paper.Status {
+	case PaperStatusAccepted:
+		if !program.ConfirmBy.IsZero() && now.After(program.ConfirmBy) {
+			return fmt.Errorf("the confirmation deadline has passed, contact the organizers")
+		}
+		if err := updatePaperStatus(ctx, nil, paperID, PaperStatusConfirmed); err != nil {
+			return err
+		}
+	case PaperStatusConfirmed:
+	default:
+		return fmt.Errorf("only accepted papers can be confirmed")
+	}
+	return issueSpeakerAdmissions(ctx, paper, program)
+}
+
+// issueSpeakerAdmissions claims the admission slot of the conference for every speaker of the
+// paper who does not hold it yet, fully paid with a conference discount.
+func issueSpeakerAdmissions(ctx context.Context, paper *Paper, program *SpeakerProgram) error {
+	if program.AdmissionSlotID == 0 {
+		return nil
+	}
+	slot, err := readConferenceSlotByID(ctx, nil, uint64(program.AdmissionSlotID), false)
+	if err != nil {
+		return err
+	}
+	if slot == nil {
+		return fmt.Errorf("no such admission slot")
+	}
+	speakers, err := readPaperSpeakerIDs(ctx, []uint32{paper.ID})
+	if err != nil {
+		return err
+	}
+
+	for _, speakerID := range speakers[paper.ID] {
+		claimed, err := hasActiveClaim(ctx, speakerID, slot.ID)
+		if err != nil {
+			return err
+		}
+		if claimed {
+			continue
+		}
+		attendee, err := readAttendeeByID(ctx, nil, speakerID)
+		if err != nil {
+			return err
+		}
+		if attendee == nil {
+			continue
+		}
+		if err := issueSpeakerAdmission(ctx, attendee, slot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// issueSpeakerAdmission claims the admission slot for a speaker and pays for it in the same
+// transaction, so a speaker is never left with an admission they would have to pay for.
+func issueSpeakerAdmission(ctx context.Context, attendee *User, slot *ConferenceSlot) error {
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	claims, err := claimSlotsTx(ctx, tx, attendee, []ConferenceSlot{*slot})
+	if err != nil {
+		err = fmt.Errorf("claiming speaker admission: %w", err)
+	} else {
+		_, err = payClaimsTx(ctx, tx, attendee, claims, []FinancialInstrument{
+			&PaymentMethodConferenceDiscount{Detail: complimentaryAdmissionDetail, AmountCents: int64(slot.Cost)},
+		})
+		if err != nil {
+			err = fmt.Errorf("paying speaker admission: %w", err)
+		}
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return err
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// setSpeakerProgram saves the admission slot and confirmation deadline for the speakers of a conference.
+func setSpeakerProgram(ctx context.Context, userID uint32, program *SpeakerProgram) error {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+	if program.AdmissionSlotID != 0 {
+		slot, err := readScheduleSlot(ctx, program.AdmissionSlotID)
+		if err != nil {
+			return err
+		}
+		if slot == nil || slot.ConferenceID != program.ConferenceID {
+			return fmt.Errorf("no such slot in the conference")
+		}
+	}
+	return upsertSpeakerProgram(ctx, program)
+}
+
+// speakerPapers returns the accepted papers of a conference by speaker.
+func speakerPapers(ctx context.Context, conferenceID uint32) (map[uint32][]SpeakerPaperStatus, []uint32, error) {
+	papers, paperSpeakers, err := readAcceptedTalks(ctx, conferenceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	bySpeaker := map[uint32][]SpeakerPaperStatus{}
+	userIDs := []uint32{}
+	for _, p := range papers {
+		for _, userID := range paperSpeakers[p.ID] {
+			if _, ok := bySpeaker[userID]; !ok {
+				userIDs = append(userIDs, userID)
+			}
+			bySpeaker[userID] = append(bySpeaker[userID], SpeakerPaperStatus{PaperID: p.ID, Title: p.Title, Status: p.Status})
+		}
+	}
+	return bySpeaker, userIDs, nil
+}
+
+// requireConferenceSpeaker returns an error unless the user presents an accepted paper at the conference.
+func requireConferenceSpeaker(ctx context.Context, conferenceID, userID uint32) error {
+	bySpeaker, _, err := speakerPapers(ctx, conferenceID)
+	if err != nil {
+		return err
+	}
+	if _, ok := bySpeaker[userID]; !ok {
+		return fmt.Errorf("not a speaker of the conference")
+	}
+	return nil
+}
+
+// updateTravelNeeds saves the travel needs of a speaker of the conference.
+func updateTravelNeeds(ctx context.Context, userID uint32, needs *TravelNeeds) error {
+	edited := *needs
+	edited.UserID = userID
+	if err := edited.validate(); err != nil {
+		return err
+	}
+	if err := requireConferenceSpeaker(ctx, edited.ConferenceID, userID); err != nil {
+		return err
+	}
+	return upsertTravelNeeds(ctx, &edited)
+}
+
+// recordSpeakerPayable adds an honorarium or travel grant owed to a speaker to the ledger.
+func recordSpeakerPayable(ctx context.Context, userID uint32, payable *SpeakerPayable) (*SpeakerPayable, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	if payable.Kind != PayableHonorarium && payable.Kind != PayableTravelGrant {
+		return nil, fmt.Errorf("unknown payable kind %q", payable.Kind)
+	}
+	if payable.AmountCents <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	if err := requireConferenceSpeaker(ctx, payable.ConferenceID, payable.UserID); err != nil {
+		return nil, err
+	}
+	payable.Detail = strings.TrimSpace(payable.Detail)
+	return insertSpeakerPayable(ctx, payable)
+}
+
+// settleSpeakerPayable records the payment of a payable.
+func settleSpeakerPayable(ctx context.Context, userID, payableID uint32, paymentRef string, now time.Time) (*SpeakerPayable, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(paymentRef) == "" {
+		return nil, fmt.Errorf("a payment reference is required")
+	}
+	return markSpeakerPayablePaid(ctx, payableID, strings.TrimSpace(paymentRef), now)
+}
+
+// readSpeakerLogistics returns the logistics of the speakers of a conference, or only of userID
+// if it is not zero.
+func readSpeakerLogistics(ctx context.Context, conferenceID, userID uint32, now time.Time) ([]SpeakerLogistics, error) {
+	program, err := readSpeakerProgram(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+	bySpeaker, userIDs, err := speakerPapers(ctx, conferenceID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != 0 {
+		userIDs = []uint32{}
+		if _, ok := bySpeaker[userID]; ok {
+			userIDs = []uint32{userID}
+		}
+	}
+	contacts, err := readUserContacts(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	travel, err := readTravelNeeds(ctx, conferenceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	payables, err := readSpeakerPayables(ctx, conferenceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	logistics := make([]SpeakerLogistics, 0, len(userIDs))
+	for _, id := range userIDs {
+		contact := contacts[id]
+		l := SpeakerLogistics{
+			UserID:     id,
+			Email:      contact.Email,
+			GivenName:  contact.GivenName,
+			FamilyName: contact.FamilyName,
+			Papers:     bySpeaker[id],
+			Travel:     travel[id],
+			Payables:   []SpeakerPayable{},
+		}
+		for _, p := range payables {
+			if p.UserID == id {
+				l.Payables = append(l.Payables, p)
+			}
+		}
+		l.summarize(program, now)
+		logistics = append(logistics, l)
+	}
+	return logistics, nil
+}
+
+// speakerLogistics returns the logistics of every speaker of a conference for organizers.
+func speakerLogistics(ctx context.Context, userID, conferenceID uint32, now time.Time) ([]SpeakerLogistics, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	return readSpeakerLogistics(ctx, conferenceID, 0, now)
+}
+
+// mySpeakerLogistics returns the logistics of the user as a speaker of a conference.
+func mySpeakerLogistics(ctx context.Context, userID, conferenceID uint32, now time.Time) (*SpeakerLogistics, error) {
+	logistics, err := readSpeakerLogistics(ctx, conferenceID, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(logistics) == 0 {
+		return nil, fmt.Errorf("not a speaker of the conference")
+	}
+	return &logistics[0], nil
+}
@@ -0,0 +1,103 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+func TestSpeakerLogistics(t *testing.T) {
+	ctx := context.Background()
+
+	row := sqldb.QueryRow(ctx, `INSERT INTO conference (name, slug, start_date, end_date, event_id, venue_id)
+	VALUES ('GopherCon Logistics Test', 'gc-logistics-test', NOW() + INTERVAL '90 days', NOW() + INTERVAL '93 days', 1, 1)
+	RETURNING id`)
+	var conferenceID uint32
+	assertDatabaseError(t, row.Scan(&conferenceID))
+
+	row = sqldb.QueryRow(ctx, `INSERT INTO conference_slot (name, description, cost, capacity, start_date, end_date,
+	purchaseable_from, purchaseable_until, available_to_public, conference_id, location_id)
+	VALUES ('Speaker pass', 'All days', 50000, 100, NOW() + INTERVAL '90 days', NOW() + INTERVAL '93 days',
+	NOW(), NOW() + INTERVAL '89 days', FALSE, $1, 1) RETURNING id`, conferenceID)
+	var slotID uint32
+	assertDatabaseError(t, row.Scan(&slotID))
+
+	organizer := createOrganizer(t, ctx, "logistics@gophercon.com")
+	speaker, err := createAttendee(ctx, nil, &User{Email: "travelling-speaker@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+
+	submit := func(title string) uint32 {
+		paperID, err := submitPaper(ctx, speaker.ID, &Paper{
+			ConferenceID:  conferenceID,
+			Title:         title,
+			ElevatorPitch: "Pitch",
+			Description:   "Description",
+		})
+		assertDatabaseError(t, err)
+		assertDatabaseError(t, updatePaperStatus(ctx, nil, paperID, PaperStatusAccepted))
+		return paperID
+	}
+	first := submit("Confirmed in time")
+	second := submit("Confirmed too late")
+
+	now := time.Now()
+	assertDatabaseError(t, setSpeakerProgram(ctx, organizer.ID, &SpeakerProgram{
+		ConferenceID:    conferenceID,
+		AdmissionSlotID: slotID,
+		ConfirmBy:       now.Add(time.Hour),
+	}))
+
+	t.Run("confirming issues a complimentary admission", func(t *testing.T) {
+		assertDatabaseError(t, confirmPaper(ctx, speaker.ID, first, now))
+		// Confirming again must not claim a second admission.
+		assertDatabaseError(t, confirmPaper(ctx, speaker.ID, first, now))
+
+		var claims int
+		assertDatabaseError(t, sqldb.QueryRow(ctx, `SELECT COUNT(*) FROM slot_claim
+		WHERE user_id = $1 AND conference_slot_id = $2`, speaker.ID, slotID).Scan(&claims))
+		if claims != 1 {
+			t.Errorf("expected one admission claim, got %d", claims)
+		}
+	})
+
+	t.Run("confirming after the deadline is rejected", func(t *testing.T) {
+		if err := confirmPaper(ctx, speaker.ID, second, now.Add(2*time.Hour)); err == nil {
+			t.Fatalf("late confirmation did not cause an error")
+		}
+	})
+
+	t.Run("organizers track travel and payables", func(t *testing.T) {
+		assertDatabaseError(t, updateTravelNeeds(ctx, speaker.ID, &TravelNeeds{
+			ConferenceID:  conferenceID,
+			NeedsTravel:   true,
+			DepartureCity: "Buenos Aires",
+			NeedsHotel:    true,
+		}))
+		payable, err := recordSpeakerPayable(ctx, organizer.ID, &SpeakerPayable{
+			ConferenceID: conferenceID,
+			UserID:       speaker.ID,
+			Kind:         PayableTravelGrant,
+			AmountCents:  80000,
+		})
+		assertDatabaseError(t, err)
+		if _, err := recordSpeakerPayable(ctx, speaker.ID, payable); err == nil {
+			t.Errorf("speakers recording payables did not cause an error")
+		}
+
+		logistics, err := speakerLogistics(ctx, organizer.ID, conferenceID, now)
+		assertDatabaseError(t, err)
+		if len(logistics) != 1 || logistics[0].Travel == nil || logistics[0].OwedCents != 80000 || logistics[0].Confirmed {
+			t.Fatalf("incorrect logistics got %+v", logistics)
+		}
+
+		_, err = settleSpeakerPayable(ctx, organizer.ID, payable.ID, "wire-0042", now)
+		assertDatabaseError(t, err)
+		mine, err := mySpeakerLogistics(ctx, speaker.ID, conferenceID, now)
+		assertDatabaseError(t, err)
+		if mine.OwedCents != 0 {
+			t.Errorf("settled payable still owed, got %d", mine.OwedCents)
+		}
+	})
+}
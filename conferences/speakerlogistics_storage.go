@@ -0,0 +1,201 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"encore.dev/storage/sqldb"
+	"github.com/lib/pq"
+)
+
+// readSpeakerProgram returns how a conference looks after its speakers, empty if it was not set.
+func readSpeakerProgram(ctx context.Context, conferenceID uint32) (*SpeakerProgram, error) {
+	row := sqldb.QueryRow(ctx, `SELECT COALESCE(admission_slot_id, 0), confirm_by FROM speaker_program
+	WHERE conference_id = $1`, conferenceID)
+
+	program := SpeakerProgram{ConferenceID: conferenceID}
+	var confirmBy sql.NullTime
+	err := row.Scan(&program.AdmissionSlotID, &confirmBy)
+	if err == sql.ErrNoRows {
+		return &program, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading speaker program: %w", err)
+	}
+	program.ConfirmBy = confirmBy.Time
+	return &program, nil
+}
+
+// upsertSpeakerProgram saves how a conference looks after its speakers.
+func upsertSpeakerProgram(ctx context.Context, program *SpeakerProgram) error {
+	_, err := sqldb.Exec(ctx, `INSERT INTO speaker_program (conference_id, admission_slot_id, confirm_by)
+	VALUES ($1, NULLIF($2, 0), $3)
+	ON CONFLICT (conference_id) DO UPDATE SET admission_slot_id = NULLIF($2, 0), confirm_by = $3`,
+		program.ConferenceID, program.AdmissionSlotID, nullableTime(program.ConfirmBy))
+	if err != nil {
+		return fmt.Errorf("saving speaker program: %w", err)
+	}
+	return nil
+}
+
+// upsertTravelNeeds saves the travel needs of a speaker for a conference.
+func upsertTravelNeeds(ctx context.Context, t *TravelNeeds) error {
+	_, err := sqldb.Exec(ctx, `INSERT INTO speaker_travel (conference_id, user_id, needs_travel, departure_city,
+	arrive_on, leave_on, needs_hotel, notes) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (conference_id, user_id) DO UPDATE SET needs_travel = $3, departure_city = $4, arrive_on = $5,
+	leave_on = $6, needs_hotel = $7, notes = $8, updated_at = NOW()`,
+		t.ConferenceID,
+		t.UserID,
+		t.NeedsTravel,
+		t.DepartureCity,
+		nullableTime(t.ArriveOn),
+		nullableTime(t.LeaveOn),
+		t.NeedsHotel,
+		t.Notes)
+	if err != nil {
+		return fmt.Errorf("saving travel needs: %w", err)
+	}
+	return nil
+}
+
+// readTravelNeeds returns the travel needs of the speakers of a conference, or only those of
+// userID if it is not zero, by user ID.
+func readTravelNeeds(ctx context.Context, conferenceID, userID uint32) (map[uint32]*TravelNeeds, error) {
+	rows, err := sqldb.Query(ctx, `SELECT conference_id, user_id, needs_travel, departure_city, arrive_on, leave_on,
+	needs_hotel, notes FROM speaker_travel WHERE conference_id = $1 AND ($2 = 0 OR user_id = $2)`, conferenceID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying travel needs: %w", err)
+	}
+	defer rows.Close()
+
+	needs := map[uint32]*TravelNeeds{}
+	for rows.Next() {
+		t := TravelNeeds{}
+		var arriveOn, leaveOn sql.NullTime
+		err := rows.Scan(&t.ConferenceID,
+			&t.UserID,
+			&t.NeedsTravel,
+			&t.DepartureCity,
+			&arriveOn,
+			&leaveOn,
+			&t.NeedsHotel,
+			&t.Notes)
+		if err != nil {
+			return nil, fmt.Errorf("scanning travel needs: %w", err)
+		}
+		t.ArriveOn = arriveOn.Time
+		t.LeaveOn = leaveOn.Time
+		needs[t.UserID] = &t
+	}
+	return needs, nil
+}
+
+const speakerPayableColumns = `id, conference_id, user_id, kind, amount_cents, detail, created_at, paid_at, payment_ref`
+
+// scanSpeakerPayable scans a row selected with speakerPayableColumns.
+func scanSpeakerPayable(scan func(dest ...interface{}) error) (*SpeakerPayable, error) {
+	var p SpeakerPayable
+	var paidAt sql.NullTime
+	err := scan(&p.ID,
+		&p.ConferenceID,
+		&p.UserID,
+		&p.Kind,
+		&p.AmountCents,
+		&p.Detail,
+		&p.CreatedAt,
+		&paidAt,
+		&p.PaymentRef)
+	if err != nil {
+		return nil, err
+	}
+	p.PaidAt = paidAt.Time
+	return &p, nil
+}
+
+// insertSpeakerPayable records money owed to a speaker.
+func insertSpeakerPayable(ctx context.Context, p *SpeakerPayable) (*SpeakerPayable, error) {
+	row := sqldb.QueryRow(ctx, `INSERT INTO speaker_payable (conference_id, user_id, kind, amount_cents, detail)
+	VALUES ($1, $2, $3, $4, $5) RETURNING `+speakerPayableColumns,
+		p.ConferenceID, p.UserID, string(p.Kind), p.AmountCents, p.Detail)
+
+	saved, err := scanSpeakerPayable(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("saving speaker payable: %w", err)
+	}
+	return saved, nil
+}
+
+// markSpeakerPayablePaid settles a payable, settling it twice is an error.
+func markSpeakerPayablePaid(ctx context.Context, id uint32, paymentRef string, paidAt time.Time) (*SpeakerPayable, error) {
+	row := sqldb.QueryRow(ctx, `UPDATE speaker_payable SET paid_at = $1, payment_ref = $2
+	WHERE id = $3 AND paid_at IS NULL RETURNING `+speakerPayableColumns, paidAt, paymentRef, id)
+
+	saved, err := scanSpeakerPayable(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no such unpaid payable")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("settling speaker payable: %w", err)
+	}
+	return saved, nil
+}
+
+// readSpeakerPayables returns the payables of a conference, or only those of userID if it is not zero.
+func readSpeakerPayables(ctx context.Context, conferenceID, userID uint32) ([]SpeakerPayable, error) {
+	rows, err := sqldb.Query(ctx, `SELECT `+speakerPayableColumns+` FROM speaker_payable
+	WHERE conference_id = $1 AND ($2 = 0 OR user_id = $2) ORDER BY id`, conferenceID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying speaker payables: %w", err)
+	}
+	defer rows.Close()
+
+	payables := []SpeakerPayable{}
+	for rows.Next() {
+		p, err := scanSpeakerPayable(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning speaker payable: %w", err)
+		}
+		payables = append(payables, *p)
+	}
+	return payables, nil
+}
+
+// hasActiveClaim returns true if the user holds a paid claim for the slot that was not revoked,
+// unpaid claims do not admit anyone.
+func hasActiveClaim(ctx context.Context, userID, slotID uint32) (bool, error) {
+	row := sqldb.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM slot_claim
+	WHERE user_id = $1 AND conference_slot_id = $2 AND revoked = FALSE
+		AND id IN (SELECT slot_claim_id FROM claim_payment_slot_claim))`, userID, slotID)
+
+	var claimed bool
+	if err := row.Scan(&claimed); err != nil {
+		return false, fmt.Errorf("checking slot claims: %w", err)
+	}
+	return claimed, nil
+}
+
+// readUserContacts returns the email and names of the passed users by user ID.
+func readUserContacts(ctx context.Context, userIDs []uint32) (map[uint32]User, error) {
+	ids := make(pq.Int64Array, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, int64(id))
+	}
+	rows, err := sqldb.Query(ctx, `SELECT id, email, COALESCE(given_name, ''), COALESCE(family_name, '')
+	FROM users WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("querying user contacts: %w", err)
+	}
+	defer rows.Close()
+
+	users := map[uint32]User{}
+	for rows.Next() {
+		u := User{}
+		if err := rows.Scan(&u.ID, &u.Email, &u.GivenName, &u.FamilyName); err != nil {
+			return nil, fmt.Errorf("scanning user contact: %w", err)
+		}
+		users[u.ID] = u
+	}
+	return users, nil
+}
@@ -0,0 +1,112 @@
+package conferences
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SpeakerProgram holds how a conference looks after its speakers
+type SpeakerProgram struct {
+	ConferenceID uint32
+	// AdmissionSlotID is the slot speakers get a complimentary claim for once they confirm, zero
+	// if there is none.
+	AdmissionSlotID uint32
+	// ConfirmBy is the deadline for speakers to confirm their accepted papers, zero if there is none.
+	ConfirmBy time.Time
+}
+
+// TravelNeeds are what a speaker needs to get to and stay at a conference, zero dates are unknown
+type TravelNeeds struct {
+	ConferenceID  uint32
+	UserID        uint32
+	NeedsTravel   bool
+	DepartureCity string
+	ArriveOn      time.Time
+	LeaveOn       time.Time
+	NeedsHotel    bool
+	Notes         string
+}
+
+// validate trims the travel needs and returns an error if they do not make sense.
+func (t *TravelNeeds) validate() error {
+	t.DepartureCity = strings.TrimSpace(t.DepartureCity)
+	t.Notes = strings.TrimSpace(t.Notes)
+	if t.NeedsTravel && t.DepartureCity == "" {
+		return fmt.Errorf("departure city is required when travel is needed")
+	}
+	if !t.ArriveOn.IsZero() && !t.LeaveOn.IsZero() && !t.LeaveOn.After(t.ArriveOn) {
+		return fmt.Errorf("leaving must be after arriving")
+	}
+	return nil
+}
+
+// PayableKind is why the conference owes money to a speaker
+type PayableKind string
+
+// Kinds of payables to speakers
+const (
+	PayableHonorarium  PayableKind = "honorarium"
+	PayableTravelGrant PayableKind = "travel_grant"
+)
+
+// SpeakerPayable is an entry in the ledger of money the conference owes speakers, it is settled
+// once PaidAt is set
+type SpeakerPayable struct {
+	ID           uint32
+	ConferenceID uint32
+	UserID       uint32
+	Kind         PayableKind
+	AmountCents  int64 // Money is handled in cents as it is done by our payment processor (stripe)
+	Detail       string
+	CreatedAt    time.Time
+	PaidAt       time.Time
+	PaymentRef   string
+}
+
+// Paid returns true once the payable was settled.
+func (p *SpeakerPayable) Paid() bool {
+	return !p.PaidAt.IsZero()
+}
+
+// SpeakerPaperStatus is an accepted paper of a speaker and how far it got
+type SpeakerPaperStatus struct {
+	PaperID uint32
+	Title   string
+	Status  PaperStatus
+}
+
+// SpeakerLogistics gathers what organizers track for a speaker of a conference
+type SpeakerLogistics struct {
+	UserID     uint32
+	Email      string
+	GivenName  string
+	FamilyName string
+	Papers     []SpeakerPaperStatus
+	// Confirmed is set once every accepted paper of the speaker was confirmed, Overdue if they
+	// have not and the deadline passed.
+	Confirmed bool
+	Overdue   bool
+	Travel    *TravelNeeds
+	Payables  []SpeakerPayable
+	// OwedCents is the sum of the payables not paid yet.
+	OwedCents int64
+}
+
+// summarize fills in Confirmed, Overdue and OwedCents.
+func (l *SpeakerLogistics) summarize(program *SpeakerProgram, now time.Time) {
+	l.Confirmed = len(l.Papers) > 0
+	for _, p := range l.Papers {
+		if p.Status != PaperStatusConfirmed {
+			l.Confirmed = false
+		}
+	}
+	l.Overdue = !l.Confirmed && !program.ConfirmBy.IsZero() && now.After(program.ConfirmBy)
+
+	l.OwedCents = 0
+	for _, p := range l.Payables {
+		if !p.Paid() {
+			l.OwedCents += p.AmountCents
+		}
+	}
+}
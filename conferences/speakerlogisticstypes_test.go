@@ -0,0 +1,66 @@
+package conferences
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTravelNeedsValidate(t *testing.T) {
+	arrive := time.Date(2021, 11, 9, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		needs   TravelNeeds
+		wantErr bool
+	}{
+		{name: "no travel needed", needs: TravelNeeds{}},
+		{name: "travel from a city", needs: TravelNeeds{NeedsTravel: true, DepartureCity: " Lisbon ", ArriveOn: arrive, LeaveOn: arrive.Add(72 * time.Hour)}},
+		{name: "travel without a city", needs: TravelNeeds{NeedsTravel: true, DepartureCity: "  "}, wantErr: true},
+		{name: "leaving before arriving", needs: TravelNeeds{ArriveOn: arrive, LeaveOn: arrive.Add(-time.Hour)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.needs.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpeakerLogisticsSummarize(t *testing.T) {
+	deadline := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+	program := &SpeakerProgram{ConfirmBy: deadline}
+	payables := []SpeakerPayable{
+		{AmountCents: 50000},
+		{AmountCents: 30000, PaidAt: deadline},
+	}
+
+	tests := []struct {
+		name          string
+		papers        []SpeakerPaperStatus
+		now           time.Time
+		wantConfirmed bool
+		wantOverdue   bool
+	}{
+		{name: "confirmed", papers: []SpeakerPaperStatus{{Status: PaperStatusConfirmed}}, now: deadline.Add(time.Hour), wantConfirmed: true},
+		{name: "pending before deadline", papers: []SpeakerPaperStatus{{Status: PaperStatusAccepted}}, now: deadline.Add(-time.Hour)},
+		{
+			name:        "partly confirmed after deadline",
+			papers:      []SpeakerPaperStatus{{Status: PaperStatusConfirmed}, {Status: PaperStatusAccepted}},
+			now:         deadline.Add(time.Hour),
+			wantOverdue: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := SpeakerLogistics{Papers: tt.papers, Payables: payables}
+			l.summarize(program, tt.now)
+			if l.Confirmed != tt.wantConfirmed || l.Overdue != tt.wantOverdue {
+				t.Errorf("summarize() got confirmed %v overdue %v", l.Confirmed, l.Overdue)
+			}
+			if l.OwedCents != 50000 {
+				t.Errorf("summarize() got owed %d, want 50000", l.OwedCents)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// SpeakerProfileResponse defines the output returned by the speaker profile API methods
+type SpeakerProfileResponse struct {
+	Profile SpeakerProfile
+}
+
+// GetMySpeakerProfile retrieves the speaker profile of the authenticated user
+// encore:api auth
+func GetMySpeakerProfile(ctx context.Context) (*SpeakerProfileResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := readSpeakerProfile(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve speaker profile: %w", err)
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("no such user")
+	}
+
+	return &SpeakerProfileResponse{Profile: *profile}, nil
+}
+
+// UpdateMySpeakerProfileParams defines the inputs used by the UpdateMySpeakerProfile API method,
+// names come from the user account and the headshot is uploaded with UploadHeadshot
+type UpdateMySpeakerProfileParams struct {
+	Profile *SpeakerProfile
+}
+
+// UpdateMySpeakerProfile saves the speaker profile of the authenticated user
+// encore:api auth
+func UpdateMySpeakerProfile(ctx context.Context, params *UpdateMySpeakerProfileParams) (*SpeakerProfileResponse, error) {
+	if params.Profile == nil {
+		return nil, fmt.Errorf("Profile is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := updateSpeakerProfile(ctx, userID, params.Profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update speaker profile: %w", err)
+	}
+
+	return &SpeakerProfileResponse{Profile: *profile}, nil
+}
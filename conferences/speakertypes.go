@@ -0,0 +1,102 @@
+package conferences
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// SpeakerProfile is what we publish about a speaker, it never includes their email
+type SpeakerProfile struct {
+	UserID     uint32
+	GivenName  string
+	FamilyName string
+	Bio        string
+	Company    string
+	Pronouns   string
+	// Social handles are stored without a leading @, Website is a full URL.
+	Twitter  string
+	GitHub   string
+	Mastodon string
+	LinkedIn string
+	Website  string
+	// HasHeadshot tells whether GetHeadshot returns an image for this speaker.
+	HasHeadshot bool
+}
+
+const (
+	maxBioLength         = 2000
+	maxProfileTextLength = 100
+)
+
+// normalize trims the editable fields of the profile and returns an error if they are not valid.
+func (p *SpeakerProfile) normalize() error {
+	p.Bio = strings.TrimSpace(p.Bio)
+	if utf8.RuneCountInString(p.Bio) > maxBioLength {
+		return fmt.Errorf("bio must be at most %d characters long", maxBioLength)
+	}
+
+	fields := []struct {
+		name   string
+		value  *string
+		handle bool
+	}{
+		{"company", &p.Company, false},
+		{"pronouns", &p.Pronouns, false},
+		{"twitter", &p.Twitter, true},
+		{"github", &p.GitHub, true},
+		{"mastodon", &p.Mastodon, true},
+		{"linkedin", &p.LinkedIn, true},
+		{"website", &p.Website, false},
+	}
+	for _, f := range fields {
+		*f.value = strings.TrimSpace(*f.value)
+		if f.handle {
+			*f.value = strings.TrimPrefix(*f.value, "@")
+			if strings.ContainsAny(*f.value, " /") {
+				return fmt.Errorf("%s must be a handle, not a link", f.name)
+			}
+		}
+		if utf8.RuneCountInString(*f.value) > maxProfileTextLength {
+			return fmt.Errorf("%s must be at most %d characters long", f.name, maxProfileTextLength)
+		}
+	}
+	if p.Website != "" && !strings.HasPrefix(p.Website, "https://") && !strings.HasPrefix(p.Website, "http://") {
+		return fmt.Errorf("website must be an http or https link")
+	}
+	return nil
+}
+
+// PublicTalk is an accepted paper as attendees see it
+type PublicTalk struct {
+	PaperID         uint32
+	ConferenceID    uint32
+	Title           string
+	ElevatorPitch   string
+	Description     string
+	Format          TalkFormat
+	DurationMinutes int
+	AudienceLevel   AudienceLevel
+	Tracks          []string
+}
+
+// publicTalk leaves out of a paper what only its speakers and organizers can see.
+func publicTalk(p Paper) PublicTalk {
+	return PublicTalk{
+		PaperID:         p.ID,
+		ConferenceID:    p.ConferenceID,
+		Title:           p.Title,
+		ElevatorPitch:   p.ElevatorPitch,
+		Description:     p.Description,
+		Format:          p.Format,
+		DurationMinutes: p.DurationMinutes,
+		AudienceLevel:   p.AudienceLevel,
+		Tracks:          p.Tracks,
+	}
+}
+
+// Speaker is someone presenting at a conference with the talks they present
+type Speaker struct {
+	Profile SpeakerProfile
+	Talks   []PublicTalk
+}
@@ -0,0 +1,61 @@
+package conferences
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpeakerProfileNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile SpeakerProfile
+		wantErr bool
+	}{
+		{name: "empty profile", profile: SpeakerProfile{}},
+		{name: "handles lose their @", profile: SpeakerProfile{Twitter: " @gopher "}},
+		{name: "handles are not links", profile: SpeakerProfile{GitHub: "github.com/gopher"}, wantErr: true},
+		{name: "website must be a link", profile: SpeakerProfile{Website: "gopher.dev"}, wantErr: true},
+		{name: "bio too long", profile: SpeakerProfile{Bio: strings.Repeat("g", maxBioLength+1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := tt.profile
+			if err := profile.normalize(); (err != nil) != tt.wantErr {
+				t.Errorf("normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.HasPrefix(profile.Twitter, "@") || profile.Twitter != strings.TrimSpace(profile.Twitter) {
+				t.Errorf("handle was not cleaned up got %q", profile.Twitter)
+			}
+		})
+	}
+}
+
+func TestLocalUploadStore(t *testing.T) {
+	store := localUploadStore{dir: t.TempDir()}
+	png := []byte("\x89PNG\x0D\x0A\x1A\x0A fake image data")
+
+	contentType, err := detectImage(png)
+	if err != nil || contentType != "image/png" {
+		t.Fatalf("detectImage() = %q, %v want image/png", contentType, err)
+	}
+	if _, err := detectImage([]byte("<svg></svg>")); err == nil {
+		t.Errorf("detecting a non image did not cause an error")
+	}
+
+	if err := store.put(headshotKey(1), png); err != nil {
+		t.Fatalf("put() error = %v", err)
+	}
+	got, err := store.get(headshotKey(1))
+	if err != nil || string(got) != string(png) {
+		t.Errorf("get() = %q, %v want %q", got, err, png)
+	}
+	if err := store.remove(headshotKey(1)); err != nil {
+		t.Errorf("remove() error = %v", err)
+	}
+	if _, err := store.get(headshotKey(1)); err == nil {
+		t.Errorf("reading a removed upload did not cause an error")
+	}
+	if err := store.put("../escape", png); err == nil {
+		t.Errorf("writing outside of the store did not cause an error")
+	}
+}
@@ -0,0 +1,271 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+// saveSponsor creates the sponsor if it has no ID or updates it otherwise, sponsors stay with the
+// conference they were created for. Only organizers can do so.
+func saveSponsor(ctx context.Context, userID uint32, sponsor *Sponsor) (*Sponsor, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	s := *sponsor
+	if s.ID != 0 {
+		existing, err := readSponsorByID(ctx, s.ID)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, fmt.Errorf("no such sponsor")
+		}
+		s.ConferenceID = existing.ConferenceID
+	}
+	if err := s.normalize(); err != nil {
+		return nil, err
+	}
+
+	if s.ID == 0 {
+		conference, err := readConferenceByID(ctx, s.ConferenceID)
+		if err != nil {
+			return nil, err
+		}
+		if conference == nil || conference.Archived {
+			return nil, fmt.Errorf("no such conference")
+		}
+	}
+
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	var saved *Sponsor
+	// the tier stays locked until the sponsor is saved so its sponsors are counted one at a time.
+	tier, err := lockTier(ctx, tx, s.Tier.ID)
+	if err == nil && (tier == nil || tier.ConferenceID != s.ConferenceID) {
+		err = fmt.Errorf("no such tier for conference %d", s.ConferenceID)
+	}
+	if err == nil && tier.MaxSponsors > 0 {
+		var sponsors int
+		sponsors, err = countSponsorsInTier(ctx, tx, tier.ID, s.ID)
+		if err == nil && sponsors >= tier.MaxSponsors {
+			err = fmt.Errorf("tier %s already has its %d sponsors", tier.Name, tier.MaxSponsors)
+		}
+	}
+	if err == nil {
+		if s.ID == 0 {
+			saved, err = insertSponsor(ctx, tx, &s)
+		} else {
+			saved, err = updateSponsor(ctx, tx, &s)
+		}
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return nil, err
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return saved, nil
+}
+
+// saveTier creates the tier if it has no ID or updates it otherwise, tiers stay with the
+// conference they were created for. Only organizers can do so.
+func saveTier(ctx context.Context, userID uint32, tier *SponsorshipTier) (*SponsorshipTier, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	t := *tier
+	if t.ID != 0 {
+		existing, err := readTierByID(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, fmt.Errorf("no such tier")
+		}
+		t.ConferenceID = existing.ConferenceID
+	}
+	if err := t.normalize(); err != nil {
+		return nil, err
+	}
+	taken, err := tierTaken(ctx, t.ConferenceID, t.Name, t.Rank, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, fmt.Errorf("name %q or rank %d is already used by another tier of the conference", t.Name, t.Rank)
+	}
+
+	if t.ID == 0 {
+		conference, err := readConferenceByID(ctx, t.ConferenceID)
+		if err != nil {
+			return nil, err
+		}
+		if conference == nil || conference.Archived {
+			return nil, fmt.Errorf("no such conference")
+		}
+		return insertTier(ctx, &t)
+	}
+	if t.MaxSponsors > 0 {
+		sponsors, err := countSponsorsInTier(ctx, nil, t.ID, 0)
+		if err != nil {
+			return nil, err
+		}
+		if sponsors > t.MaxSponsors {
+			return nil, fmt.Errorf("the tier already has %d sponsors", sponsors)
+		}
+	}
+	return updateTier(ctx, &t)
+}
+
+// removeTier deletes a tier once no sponsor is at it, only organizers can do so.
+func removeTier(ctx context.Context, userID, tierID uint32) error {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+	sponsors, err := countSponsorsInTier(ctx, nil, tierID, 0)
+	if err != nil {
+		return err
+	}
+	if sponsors > 0 {
+		return fmt.Errorf("the tier still has %d sponsors", sponsors)
+	}
+	return deleteTier(ctx, tierID)
+}
+
+// removeSponsor deletes a sponsor and its contacts unless it was invoiced, only organizers can do so.
+func removeSponsor(ctx context.Context, userID, sponsorID uint32) error {
+	sponsor, err := sponsorWithContacts(ctx, userID, sponsorID)
+	if err != nil {
+		return err
+	}
+	if sponsor.Stage.index() >= DealInvoiced.index() {
+		return fmt.Errorf("sponsors that were invoiced are kept for accounting")
+	}
+	if err := deleteSponsor(ctx, sponsorID); err != nil {
+		return err
+	}
+	// once the sponsor is gone nothing points to its logo anymore.
+	return uploads.remove(sponsorLogoKey(sponsorID))
+}
+
+// sponsorWithContacts returns a sponsor along with its contacts, only organizers can see them.
+func sponsorWithContacts(ctx context.Context, userID, sponsorID uint32) (*Sponsor, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	sponsor, err := readSponsorByID(ctx, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	if sponsor == nil {
+		return nil, fmt.Errorf("no such sponsor")
+	}
+	return sponsor, nil
+}
+
+// sponsorInviteLifetime is how long a contact has to join the portal of their sponsor with an
+// invite.
+const sponsorInviteLifetime = 14 * 24 * time.Hour
+
+// addSponsorContact adds a contact to a sponsor and emails them an invite to its portal, only
+// organizers can do so.
+func addSponsorContact(ctx context.Context, userID, sponsorID uint32, contact *SponsorContactInformation) (*SponsorContactInformation, error) {
+	sponsor, err := sponsorWithContacts(ctx, userID, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	c := *contact
+	if err := c.normalize(); err != nil {
+		return nil, err
+	}
+	saved, err := insertSponsorContact(ctx, sponsorID, &c)
+	if err != nil {
+		return nil, err
+	}
+	if saved.Email == "" {
+		return saved, nil
+	}
+	if _, err := sendSponsorInvite(ctx, sponsor, saved); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// inviteSponsorContact emails a new invite to the portal of a sponsor to one of its contacts who
+// has not joined it yet and returns it, any previous invite stops working. Only organizers can do
+// so.
+func inviteSponsorContact(ctx context.Context, userID, sponsorID, contactID uint32) (string, error) {
+	sponsor, err := sponsorWithContacts(ctx, userID, sponsorID)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range sponsor.Contacts {
+		if c.ID == contactID {
+			return sendSponsorInvite(ctx, sponsor, &c)
+		}
+	}
+	return "", fmt.Errorf("no such contact found")
+}
+
+// sendSponsorInvite issues an invite to the portal of the sponsor to the contact, emails it to
+// them and returns it.
+func sendSponsorInvite(ctx context.Context, sponsor *Sponsor, contact *SponsorContactInformation) (string, error) {
+	token, tokenHash, err := newOneTimeToken()
+	if err != nil {
+		return "", err
+	}
+	err = updateSponsorContactInvite(ctx, sponsor.ID, contact.ID, tokenHash, time.Now().Add(sponsorInviteLifetime))
+	if err != nil {
+		return "", err
+	}
+	err = sendEmail(ctx, Email{
+		To:      contact.Email,
+		Subject: "Manage " + sponsor.Name + " as a sponsor",
+		Body:    fmt.Sprintf("Log in and join the sponsor portal with this code to manage %q: %s", sponsor.Name, token),
+	})
+	if err != nil {
+		return "", fmt.Errorf("sending sponsor portal invitation: %w", err)
+	}
+	return token, nil
+}
+
+// updateSponsorContact changes the name, role, email and phone of a sponsor contact, only
+// organizers can do so. A contact whose email changes is invited again at the new one.
+func updateSponsorContact(ctx context.Context, userID uint32, contact *SponsorContactInformation) error {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+	c := *contact
+	if err := c.normalize(); err != nil {
+		return err
+	}
+	sponsorID, emailChanged, err := updateSponsorContactInformation(ctx, &c)
+	if err != nil || !emailChanged || c.Email == "" {
+		return err
+	}
+	sponsor, err := readSponsorByID(ctx, sponsorID)
+	if err != nil {
+		return err
+	}
+	if sponsor == nil {
+		return fmt.Errorf("no such sponsor")
+	}
+	_, err = sendSponsorInvite(ctx, sponsor, &c)
+	return err
+}
+
+// removeSponsorContact removes a contact from a sponsor, only organizers can do so.
+func removeSponsorContact(ctx context.Context, userID, sponsorID, contactID uint32) error {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return err
+	}
+	return deleteSponsorContact(ctx, sponsorID, contactID)
+}
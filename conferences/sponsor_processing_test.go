@@ -0,0 +1,109 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSponsorManagement(t *testing.T) {
+	ctx := context.Background()
+
+	organizer := createOrganizer(t, ctx, "sponsor-admin@gophercon.com")
+	attendee, err := createAttendee(ctx, nil, &User{Email: "not-a-sponsor-admin@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+
+	silver, err := saveTier(ctx, organizer.ID, &SponsorshipTier{ConferenceID: 2, Name: "Sponsor Test Silver", Rank: 30})
+	assertDatabaseError(t, err)
+	gold, err := saveTier(ctx, organizer.ID, &SponsorshipTier{ConferenceID: 2, Name: "Sponsor Test Gold", Rank: 20, MaxSponsors: 1})
+	assertDatabaseError(t, err)
+
+	sponsor, err := saveSponsor(ctx, organizer.ID, &Sponsor{
+		Name:         "Gopher Tools",
+		Address:      "1 Burrow Lane",
+		Website:      "https://gophertools.example",
+		Tier:         SponsorshipTier{ID: silver.ID},
+		ConferenceID: 2,
+	})
+	assertDatabaseError(t, err)
+
+	t.Run("only organizers manage sponsors", func(t *testing.T) {
+		if _, err := saveSponsor(ctx, attendee.ID, &Sponsor{Name: "Rogue", ConferenceID: 2, Tier: SponsorshipTier{ID: silver.ID}}); err == nil {
+			t.Errorf("attendees creating sponsors did not cause an error")
+		}
+		if _, err := sponsorWithContacts(ctx, attendee.ID, sponsor.ID); err == nil {
+			t.Errorf("attendees reading sponsor contacts did not cause an error")
+		}
+	})
+
+	t.Run("tiers have unique names and ranks", func(t *testing.T) {
+		if _, err := saveTier(ctx, organizer.ID, &SponsorshipTier{ConferenceID: 2, Name: "Another", Rank: 30}); err == nil {
+			t.Errorf("duplicate rank did not cause an error")
+		}
+		if _, err := saveTier(ctx, organizer.ID, &SponsorshipTier{ConferenceID: 2, Name: "sponsor test gold", Rank: 25}); err == nil {
+			t.Errorf("duplicate name did not cause an error")
+		}
+	})
+
+	t.Run("sponsors stay with their conference", func(t *testing.T) {
+		updated, err := saveSponsor(ctx, organizer.ID, &Sponsor{
+			ID:           sponsor.ID,
+			Name:         "Gopher Tools",
+			Address:      "2 Burrow Lane",
+			Website:      "https://gophertools.example",
+			Tier:         SponsorshipTier{ID: gold.ID},
+			ConferenceID: 1,
+		})
+		assertDatabaseError(t, err)
+		if updated.ConferenceID != 2 || updated.Address != "2 Burrow Lane" || updated.Tier.Name != "Sponsor Test Gold" {
+			t.Errorf("incorrect sponsor got %+v", updated)
+		}
+	})
+
+	t.Run("tiers of other conferences cannot be used", func(t *testing.T) {
+		other, err := saveTier(ctx, organizer.ID, &SponsorshipTier{ConferenceID: 1, Name: "Sponsor Test Other", Rank: 30})
+		assertDatabaseError(t, err)
+		if _, err := saveSponsor(ctx, organizer.ID, &Sponsor{Name: "Elsewhere", ConferenceID: 2, Tier: SponsorshipTier{ID: other.ID}}); err == nil {
+			t.Errorf("a tier of another conference did not cause an error")
+		}
+	})
+
+	t.Run("tiers limit their sponsors", func(t *testing.T) {
+		if _, err := saveSponsor(ctx, organizer.ID, &Sponsor{Name: "Latecomer", ConferenceID: 2, Tier: SponsorshipTier{ID: gold.ID}}); err == nil {
+			t.Errorf("sponsoring a full tier did not cause an error")
+		}
+		if err := removeTier(ctx, organizer.ID, gold.ID); err == nil {
+			t.Errorf("removing a tier with sponsors did not cause an error")
+		}
+		assertDatabaseError(t, removeTier(ctx, organizer.ID, silver.ID))
+	})
+
+	t.Run("contacts are returned with the sponsor", func(t *testing.T) {
+		contact, err := addSponsorContact(ctx, organizer.ID, sponsor.ID, &SponsorContactInformation{
+			Name:  "Tina Tools",
+			Role:  ContactRoleMarketing,
+			Email: "tina@gophertools.example",
+		})
+		assertDatabaseError(t, err)
+
+		read, err := sponsorWithContacts(ctx, organizer.ID, sponsor.ID)
+		assertDatabaseError(t, err)
+		if read.Website != "https://gophertools.example" || len(read.Contacts) != 1 ||
+			read.Contacts[0].Role != ContactRoleMarketing || read.Contacts[0].Phone != "" {
+			t.Errorf("incorrect sponsor got %+v", read)
+		}
+
+		if err := removeSponsorContact(ctx, organizer.ID, sponsor.ID+1000, contact.ID); err == nil {
+			t.Errorf("removing a contact through another sponsor did not cause an error")
+		}
+		assertDatabaseError(t, removeSponsorContact(ctx, organizer.ID, sponsor.ID, contact.ID))
+	})
+
+	t.Run("removing a sponsor removes its contacts", func(t *testing.T) {
+		_, err := addSponsorContact(ctx, organizer.ID, sponsor.ID, &SponsorContactInformation{Name: "Tom Tools", Phone: "555"})
+		assertDatabaseError(t, err)
+		assertDatabaseError(t, removeSponsor(ctx, organizer.ID, sponsor.ID))
+		if _, err := sponsorWithContacts(ctx, organizer.ID, sponsor.ID); err == nil {
+			t.Errorf("removed sponsor was still found")
+		}
+	})
+}
@@ -0,0 +1,398 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"encore.dev/storage/sqldb"
+	"github.com/lib/pq"
+)
+
+// SponsorFilter narrows down the sponsors returned by GetConferenceSponsors, zero fields do not filter.
+type SponsorFilter struct {
+	TierID uint32
+	// Stage only filters sponsors listed to organizers, the public only sees signed sponsors.
+	Stage DealStage
+}
+
+// sponsorSortColumns are the fields sponsors can be sorted by, tiers sort from the highest.
+var sponsorSortColumns = map[string]sortColumn{
+	"tier": {expr: "sponsorship_tier.rank", sqlType: "INT"},
+	"name": {expr: "sponsor.name", sqlType: "TEXT"},
+	"id":   {expr: "sponsor.id", sqlType: "INT"},
+}
+
+const tierColumns = `sponsorship_tier.id, sponsorship_tier.conference_id, sponsorship_tier.name, sponsorship_tier.rank,
+	sponsorship_tier.price_cents, sponsorship_tier.benefits, sponsorship_tier.max_sponsors`
+
+// scanTier scans a row selected with tierColumns.
+func scanTier(scan func(dest ...interface{}) error) (*SponsorshipTier, error) {
+	var t SponsorshipTier
+	var benefits pq.StringArray
+	if err := scan(&t.ID, &t.ConferenceID, &t.Name, &t.Rank, &t.PriceCents, &benefits, &t.MaxSponsors); err != nil {
+		return nil, err
+	}
+	t.Benefits = benefits
+	return &t, nil
+}
+
+// readTiersByConference returns the tiers of a conference from the highest.
+func readTiersByConference(ctx context.Context, conferenceID uint32) ([]SponsorshipTier, error) {
+	rows, err := sqldb.Query(ctx, `SELECT `+tierColumns+` FROM sponsorship_tier
+	WHERE conference_id = $1 ORDER BY rank`, conferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying sponsorship tiers: %w", err)
+	}
+	defer rows.Close()
+
+	tiers := []SponsorshipTier{}
+	for rows.Next() {
+		t, err := scanTier(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning sponsorship tier: %w", err)
+		}
+		tiers = append(tiers, *t)
+	}
+	return tiers, nil
+}
+
+// readTierByID returns a tier, nil if it does not exist.
+func readTierByID(ctx context.Context, id uint32) (*SponsorshipTier, error) {
+	row := sqldb.QueryRow(ctx, `SELECT `+tierColumns+` FROM sponsorship_tier WHERE id = $1`, id)
+
+	t, err := scanTier(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading sponsorship tier: %w", err)
+	}
+	return t, nil
+}
+
+// lockTier reads a tier and locks it until the transaction ends, so sponsors are added to it one
+// at a time. It returns nil if there is no such tier.
+func lockTier(ctx context.Context, tx *sqldb.Tx, id uint32) (*SponsorshipTier, error) {
+	row := sqldb.QueryRowTx(tx, ctx, `SELECT `+tierColumns+` FROM sponsorship_tier WHERE id = $1 FOR UPDATE`, id)
+
+	t, err := scanTier(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("locking sponsorship tier: %w", err)
+	}
+	return t, nil
+}
+
+// tierTaken returns true if another tier than exceptID of the conference uses the name or rank.
+func tierTaken(ctx context.Context, conferenceID uint32, name string, rank int, exceptID uint32) (bool, error) {
+	row := sqldb.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM sponsorship_tier
+	WHERE conference_id = $1 AND (LOWER(name) = LOWER($2) OR rank = $3) AND id != $4)`, conferenceID, name, rank, exceptID)
+
+	var taken bool
+	if err := row.Scan(&taken); err != nil {
+		return false, fmt.Errorf("checking sponsorship tier: %w", err)
+	}
+	return taken, nil
+}
+
+// insertTier saves a new tier.
+func insertTier(ctx context.Context, t *SponsorshipTier) (*SponsorshipTier, error) {
+	row := sqldb.QueryRow(ctx, `INSERT INTO sponsorship_tier (conference_id, name, rank, price_cents, benefits, max_sponsors)
+	VALUES ($1, $2, $3, $4, $5, $6) RETURNING `+tierColumns,
+		t.ConferenceID, t.Name, t.Rank, t.PriceCents, pq.StringArray(t.Benefits), t.MaxSponsors)
+
+	saved, err := scanTier(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("saving sponsorship tier: %w", err)
+	}
+	return saved, nil
+}
+
+// updateTier changes the details of a tier, tiers do not move between conferences.
+func updateTier(ctx context.Context, t *SponsorshipTier) (*SponsorshipTier, error) {
+	row := sqldb.QueryRow(ctx, `UPDATE sponsorship_tier SET name = $1, rank = $2, price_cents = $3, benefits = $4,
+	max_sponsors = $5 WHERE id = $6 RETURNING `+tierColumns,
+		t.Name, t.Rank, t.PriceCents, pq.StringArray(t.Benefits), t.MaxSponsors, t.ID)
+
+	saved, err := scanTier(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no such tier")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("updating sponsorship tier: %w", err)
+	}
+	return saved, nil
+}
+
+// deleteTier removes a tier no sponsor is at.
+func deleteTier(ctx context.Context, id uint32) error {
+	res, err := sqldb.Exec(ctx, `DELETE FROM sponsorship_tier WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("deleting sponsorship tier: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting sponsorship tier: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("no such tier")
+	}
+	return nil
+}
+
+// countSponsorsInTier returns how many sponsors other than exceptID are at the tier.
+func countSponsorsInTier(ctx context.Context, tx *sqldb.Tx, tierID, exceptID uint32) (int, error) {
+	sqlStatement := `SELECT COUNT(*) FROM sponsor WHERE tier_id = $1 AND id != $2`
+	var row *sqldb.Row
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, tierID, exceptID)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, tierID, exceptID)
+	}
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting sponsors in tier: %w", err)
+	}
+	return count, nil
+}
+
+const sponsorColumns = `sponsor.id, sponsor.name, sponsor.address, sponsor.website, sponsor.conference_id, sponsor.stage,
+	sponsor.description, COALESCE(sponsor.logo_key, '') <> '', ` + tierColumns
+
+// sponsorTables are the tables sponsorColumns are selected from.
+const sponsorTables = `sponsor JOIN sponsorship_tier ON sponsorship_tier.id = sponsor.tier_id`
+
+// scanSponsor scans a row selected with sponsorColumns, contacts are left empty.
+func scanSponsor(scan func(dest ...interface{}) error) (*Sponsor, error) {
+	s := Sponsor{Contacts: []SponsorContactInformation{}}
+	tier, err := scanTier(func(dest ...interface{}) error {
+		return scan(append([]interface{}{&s.ID, &s.Name, &s.Address, &s.Website, &s.ConferenceID, &s.Stage,
+			&s.Description, &s.HasLogo}, dest...)...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.Tier = *tier
+	return &s, nil
+}
+
+// readSponsorsByConference returns a page of the sponsors of a conference matching the filter,
+// without their contacts. signedOnly leaves out the sponsors whose deal was not signed yet.
+func readSponsorsByConference(ctx context.Context, conferenceID uint32, filter SponsorFilter, signedOnly bool, page Page) ([]Sponsor, PageInfo, error) {
+	q, err := newPageQuery(page, "sponsor.id", sponsorSortColumns, "tier")
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	condition, pageArgs := q.condition(5)
+	rows, err := sqldb.Query(ctx, `SELECT `+sponsorColumns+`, `+q.keyColumn()+` FROM `+sponsorTables+`
+	WHERE sponsor.conference_id = $1
+	AND ($2 = 0 OR sponsor.tier_id = $2)
+	AND ($3 = '' OR sponsor.stage::TEXT = $3)
+	AND (NOT $4 OR sponsor.stage IN ('signed', 'invoiced', 'paid'))
+	AND `+condition+` `+q.orderBy(),
+		append([]interface{}{conferenceID, filter.TierID, string(filter.Stage), signedOnly}, pageArgs...)...)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("querying sponsors: %w", err)
+	}
+	defer rows.Close()
+
+	sponsors := []Sponsor{}
+	keys := []string{}
+	ids := []uint32{}
+	for rows.Next() {
+		var key string
+		sponsor, err := scanSponsor(func(dest ...interface{}) error {
+			return rows.Scan(append(dest, &key)...)
+		})
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("scanning sponsor: %w", err)
+		}
+		sponsors = append(sponsors, *sponsor)
+		keys = append(keys, key)
+		ids = append(ids, sponsor.ID)
+	}
+	n, info := q.pageInfo(keys, ids)
+	return sponsors[:n], info, nil
+}
+
+// readSponsorByID returns a sponsor with its contacts, nil if it does not exist.
+func readSponsorByID(ctx context.Context, id uint32) (*Sponsor, error) {
+	row := sqldb.QueryRow(ctx, `SELECT `+sponsorColumns+` FROM `+sponsorTables+` WHERE sponsor.id = $1`, id)
+
+	s, err := scanSponsor(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading sponsor: %w", err)
+	}
+	contacts, err := readSponsorContacts(ctx, []uint32{id})
+	if err != nil {
+		return nil, err
+	}
+	s.Contacts = contacts[id]
+	if s.Contacts == nil {
+		s.Contacts = []SponsorContactInformation{}
+	}
+	return s, nil
+}
+
+// insertSponsor saves a new sponsor.
+func insertSponsor(ctx context.Context, tx *sqldb.Tx, s *Sponsor) (*Sponsor, error) {
+	sqlStatement := `WITH saved AS (
+		INSERT INTO sponsor (name, address, website, tier_id, conference_id)
+		VALUES ($1, $2, $3, $4, $5) RETURNING *
+	) SELECT ` + sponsorColumns + ` FROM saved AS sponsor
+	JOIN sponsorship_tier ON sponsorship_tier.id = sponsor.tier_id`
+	sqlArgs := []interface{}{s.Name, s.Address, s.Website, s.Tier.ID, s.ConferenceID}
+	var row *sqldb.Row
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
+	}
+
+	saved, err := scanSponsor(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("saving sponsor: %w", err)
+	}
+	return saved, nil
+}
+
+// updateSponsor changes the details of a sponsor, sponsors do not move between conferences.
+func updateSponsor(ctx context.Context, tx *sqldb.Tx, s *Sponsor) (*Sponsor, error) {
+	sqlStatement := `WITH saved AS (
+		UPDATE sponsor SET name = $1, address = $2, website = $3, tier_id = $4 WHERE id = $5 RETURNING *
+	) SELECT ` + sponsorColumns + ` FROM saved AS sponsor
+	JOIN sponsorship_tier ON sponsorship_tier.id = sponsor.tier_id`
+	sqlArgs := []interface{}{s.Name, s.Address, s.Website, s.Tier.ID, s.ID}
+	var row *sqldb.Row
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
+	}
+
+	saved, err := scanSponsor(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no such sponsor")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("updating sponsor: %w", err)
+	}
+	return saved, nil
+}
+
+// deleteSponsor removes a sponsor along with its contacts.
+func deleteSponsor(ctx context.Context, id uint32) error {
+	res, err := sqldb.Exec(ctx, `DELETE FROM sponsor WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("deleting sponsor: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting sponsor: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("no such sponsor")
+	}
+	return nil
+}
+
+const sponsorContactColumns = `id, name, role, COALESCE(email, ''), COALESCE(phone, ''), COALESCE(user_id, 0)`
+
+// scanSponsorContact scans a row selected with sponsorContactColumns.
+func scanSponsorContact(scan func(dest ...interface{}) error) (*SponsorContactInformation, error) {
+	var c SponsorContactInformation
+	if err := scan(&c.ID, &c.Name, &c.Role, &c.Email, &c.Phone, &c.UserID); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// readSponsorContacts returns the contacts of the passed sponsors by sponsor.
+func readSponsorContacts(ctx context.Context, sponsorIDs []uint32) (map[uint32][]SponsorContactInformation, error) {
+	ids := make(pq.Int64Array, 0, len(sponsorIDs))
+	for _, id := range sponsorIDs {
+		ids = append(ids, int64(id))
+	}
+	rows, err := sqldb.Query(ctx, `SELECT sponsor_id, `+sponsorContactColumns+` FROM sponsor_contact_information
+	WHERE sponsor_id = ANY($1) ORDER BY id`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("querying sponsor contacts: %w", err)
+	}
+	defer rows.Close()
+
+	contacts := map[uint32][]SponsorContactInformation{}
+	for rows.Next() {
+		var sponsorID uint32
+		c, err := scanSponsorContact(func(dest ...interface{}) error {
+			return rows.Scan(append([]interface{}{&sponsorID}, dest...)...)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scanning sponsor contact: %w", err)
+		}
+		contacts[sponsorID] = append(contacts[sponsorID], *c)
+	}
+	return contacts, nil
+}
+
+// insertSponsorContact adds a contact to a sponsor.
+func insertSponsorContact(ctx context.Context, sponsorID uint32, c *SponsorContactInformation) (*SponsorContactInformation, error) {
+	row := sqldb.QueryRow(ctx, `INSERT INTO sponsor_contact_information (name, role, email, phone, sponsor_id)
+	VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5) RETURNING `+sponsorContactColumns,
+		c.Name, c.Role, c.Email, c.Phone, sponsorID)
+
+	saved, err := scanSponsorContact(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("saving sponsor contact: %w", err)
+	}
+	return saved, nil
+}
+
+// updateSponsorContactInformation saves the name, role, email and phone of a sponsor contact and
+// returns its sponsor and whether its email changed. A contact whose email changes is no longer
+// linked to the user who joined the portal and its pending invite is dropped, it needs a new one.
+func updateSponsorContactInformation(ctx context.Context, c *SponsorContactInformation) (uint32, bool, error) {
+	row := sqldb.QueryRow(ctx, `UPDATE sponsor_contact_information AS contact
+	SET name = $1, role = $2, email = NULLIF($3, ''), phone = NULLIF($4, ''),
+		user_id = CASE WHEN LOWER(previous.email) = LOWER($3) THEN contact.user_id END,
+		invite_token_hash = CASE WHEN LOWER(previous.email) = LOWER($3) THEN contact.invite_token_hash END,
+		invite_expires_at = CASE WHEN LOWER(previous.email) = LOWER($3) THEN contact.invite_expires_at END
+	FROM sponsor_contact_information AS previous
+	WHERE contact.id = $5 AND previous.id = contact.id
+	RETURNING contact.sponsor_id, LOWER(previous.email) IS DISTINCT FROM LOWER(contact.email)`,
+		c.Name, c.Role, c.Email, c.Phone, c.ID)
+
+	var sponsorID uint32
+	var emailChanged bool
+	err := row.Scan(&sponsorID, &emailChanged)
+	if err == sql.ErrNoRows {
+		return 0, false, fmt.Errorf("no such contact found")
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("updating sponsor contact: %w", err)
+	}
+	return sponsorID, emailChanged, nil
+}
+
+// deleteSponsorContact removes a contact of a sponsor.
+func deleteSponsorContact(ctx context.Context, sponsorID, contactID uint32) error {
+	res, err := sqldb.Exec(ctx, `DELETE FROM sponsor_contact_information WHERE id = $1 AND sponsor_id = $2`,
+		contactID, sponsorID)
+	if err != nil {
+		return fmt.Errorf("deleting sponsor contact: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting sponsor contact: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("no such contact found")
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+// sponsorDeal returns the deal with a sponsor, only organizers can see it.
+func sponsorDeal(ctx context.Context, userID, sponsorID uint32) (*SponsorDeal, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	deal, err := readSponsorDeal(ctx, nil, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	if deal == nil {
+		return nil, fmt.Errorf("no such sponsor")
+	}
+	return deal, nil
+}
+
+// setSponsorContract saves the contract amount, billing contact and payment schedule of a sponsor
+// until the deal is signed, only organizers can do so.
+func setSponsorContract(ctx context.Context, userID uint32, contract *SponsorDeal) (*SponsorDeal, error) {
+	sponsor, err := sponsorWithContacts(ctx, userID, contract.SponsorID)
+	if err != nil {
+		return nil, err
+	}
+	if sponsor.Stage.signed() {
+		return nil, fmt.Errorf("the contract was already signed")
+	}
+	d := *contract
+	if err := d.validateContract(); err != nil {
+		return nil, err
+	}
+	if d.BillingContactID != 0 {
+		found := false
+		for _, c := range sponsor.Contacts {
+			if c.ID == d.BillingContactID {
+				found = c.Email != ""
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("the billing contact must be a contact of the sponsor with an email")
+		}
+	}
+
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	if err := saveSponsorContract(ctx, tx, &d); err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return nil, err
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return readSponsorDeal(ctx, nil, d.SponsorID)
+}
+
+// moveSponsorDeal moves the deal with a sponsor through the stages up to signed, only organizers
+// can do so.
+func moveSponsorDeal(ctx context.Context, userID, sponsorID uint32, stage DealStage) (*SponsorDeal, error) {
+	deal, err := sponsorDeal(ctx, userID, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := deal.canMoveTo(stage); err != nil {
+		return nil, err
+	}
+	if err := updateSponsorStage(ctx, nil, sponsorID, stage); err != nil {
+		return nil, err
+	}
+	deal.Stage = stage
+	return deal, nil
+}
+
+// progressSponsorDeal moves the deal to the stage its invoices and payments take it to.
+func progressSponsorDeal(ctx context.Context, tx *sqldb.Tx, sponsorID uint32) (*SponsorDeal, error) {
+	deal, err := readSponsorDeal(ctx, tx, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	if stage := deal.progress(); stage != deal.Stage {
+		if err := updateSponsorStage(ctx, tx, sponsorID, stage); err != nil {
+			return nil, err
+		}
+		deal.Stage = stage
+	}
+	return deal, nil
+}
+
+// issueSponsorInvoice invoices an installment of a signed deal and emails it to the billing
+// contact, only organizers can do so. Issuing an installment that was already invoiced emails the
+// same invoice again, so an invoice whose email failed can be sent once more.
+func issueSponsorInvoice(ctx context.Context, userID, sponsorID, installmentID uint32, now time.Time) (*SponsorInvoice, error) {
+	sponsor, err := sponsorWithContacts(ctx, userID, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	deal, err := readSponsorDeal(ctx, nil, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	if !deal.Stage.signed() {
+		return nil, fmt.Errorf("the contract was not signed yet")
+	}
+	var installment *Installment
+	for i := range deal.Schedule {
+		if deal.Schedule[i].ID == installmentID {
+			installment = &deal.Schedule[i]
+		}
+	}
+	if installment == nil {
+		return nil, fmt.Errorf("no such installment")
+	}
+	if installment.InvoiceID != 0 {
+		for i := range deal.Invoices {
+			if deal.Invoices[i].ID != installment.InvoiceID {
+				continue
+			}
+			if err := sendSponsorInvoice(ctx, sponsor, &deal.Invoices[i]); err != nil {
+				return nil, err
+			}
+			return &deal.Invoices[i], nil
+		}
+		return nil, fmt.Errorf("no such invoice")
+	}
+	var billing *SponsorContactInformation
+	for i := range sponsor.Contacts {
+		if sponsor.Contacts[i].ID == deal.BillingContactID && sponsor.Contacts[i].Email != "" {
+			billing = &sponsor.Contacts[i]
+		}
+	}
+	if billing == nil {
+		return nil, fmt.Errorf("the sponsor has no billing contact")
+	}
+
+	invoice := SponsorInvoice{
+		SponsorID:     sponsorID,
+		InstallmentID: installment.ID,
+		BilledTo:      billing.Name,
+		BilledToEmail: billing.Email,
+		AmountCents:   installment.AmountCents,
+		IssuedAt:      now,
+		DueOn:         installment.DueOn,
+		Payments:      []SponsorPayment{},
+	}
+
+	// the invoice and the stage it takes the deal to are saved together, the email is sent after.
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	invoice.ID, err = insertSponsorInvoice(ctx, tx, &invoice)
+	if err == nil {
+		invoice.Number = invoiceNumber(invoice.ID)
+		invoice.settle()
+		installment.InvoiceID = invoice.ID
+		deal.Invoices = append(deal.Invoices, invoice)
+		if stage := deal.progress(); stage != deal.Stage {
+			err = updateSponsorStage(ctx, tx, sponsorID, stage)
+		}
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return nil, err
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	if err := sendSponsorInvoice(ctx, sponsor, &invoice); err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// sendSponsorInvoice emails an invoice to the contact it is billed to.
+func sendSponsorInvoice(ctx context.Context, sponsor *Sponsor, invoice *SponsorInvoice) error {
+	err := sendEmail(ctx, Email{
+		To:      invoice.BilledToEmail,
+		Subject: fmt.Sprintf("Invoice %s for the sponsorship of %s", invoice.Number, sponsor.Name),
+		Body: fmt.Sprintf("Dear %s,\n\nplease find invoice %s for %d.%02d due on %s.", invoice.BilledTo, invoice.Number,
+			invoice.AmountCents/100, invoice.AmountCents%100, invoice.DueOn.Format("2006-01-02")),
+	})
+	if err != nil {
+		return fmt.Errorf("sending invoice: %w", err)
+	}
+	return nil
+}
+
+// recordSponsorPayment adds a payment to the ledger of an invoice, the deal is paid once every
+// installment was invoiced and paid. Only organizers can do so.
+func recordSponsorPayment(ctx context.Context, userID uint32, payment *SponsorPayment, now time.Time) (*SponsorInvoice, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, err
+	}
+	p := *payment
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	if p.InvoiceID == 0 {
+		return nil, fmt.Errorf("no such invoice")
+	}
+	if p.ReceivedAt.IsZero() {
+		p.ReceivedAt = now
+	}
+
+	// the invoice stays locked until the payment and the stage it takes the deal to are saved, so
+	// concurrent payments cannot both find it unpaid.
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	var invoices []SponsorInvoice
+	found, err := lockSponsorInvoice(ctx, tx, p.InvoiceID)
+	if err == nil && !found {
+		err = fmt.Errorf("no such invoice")
+	}
+	if err == nil {
+		invoices, err = readSponsorInvoices(ctx, tx, 0, p.InvoiceID)
+	}
+	if err == nil && invoices[0].Paid {
+		err = fmt.Errorf("invoice %s is already paid", invoices[0].Number)
+	}
+	if err == nil {
+		err = insertSponsorPayment(ctx, tx, &p)
+	}
+	if err == nil {
+		_, err = progressSponsorDeal(ctx, tx, invoices[0].SponsorID)
+	}
+	if err == nil {
+		invoices, err = readSponsorInvoices(ctx, tx, 0, p.InvoiceID)
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return nil, err
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return &invoices[0], nil
+}
+
+// sponsorPipeline returns a page of every sponsor of a conference whatever their stage, only
+// organizers can see it.
+func sponsorPipeline(ctx context.Context, userID, conferenceID uint32, filter SponsorFilter, page Page) ([]Sponsor, PageInfo, error) {
+	if err := requireRole(ctx, userID, RoleOrganizer); err != nil {
+		return nil, PageInfo{}, err
+	}
+	return readSponsorsByConference(ctx, conferenceID, filter, false, page)
+}
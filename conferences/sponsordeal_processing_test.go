@@ -0,0 +1,106 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSponsorDealPipeline(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	organizer := createOrganizer(t, ctx, "sponsor-deals@gophercon.com")
+
+	tier, err := saveTier(ctx, organizer.ID, &SponsorshipTier{ConferenceID: 2, Name: "Deal Test", Rank: 50})
+	assertDatabaseError(t, err)
+	sponsor, err := saveSponsor(ctx, organizer.ID, &Sponsor{Name: "Dealing Gophers", ConferenceID: 2, Tier: SponsorshipTier{ID: tier.ID}})
+	assertDatabaseError(t, err)
+	billing, err := addSponsorContact(ctx, organizer.ID, sponsor.ID, &SponsorContactInformation{
+		Name:  "Bill Ing",
+		Role:  ContactRoleOther,
+		Email: "bill@dealinggophers.example",
+	})
+	assertDatabaseError(t, err)
+
+	isListed := func() bool {
+		sponsors, _, err := readSponsorsByConference(ctx, 2, SponsorFilter{TierID: tier.ID}, true, Page{})
+		assertDatabaseError(t, err)
+		return len(sponsors) == 1
+	}
+
+	t.Run("prospects are not public", func(t *testing.T) {
+		if sponsor.Stage != DealProspect || isListed() {
+			t.Errorf("expected a prospect that is not listed, got %+v", sponsor)
+		}
+		if _, err := moveSponsorDeal(ctx, organizer.ID, sponsor.ID, DealSigned); err == nil {
+			t.Errorf("signing without a contract did not cause an error")
+		}
+	})
+
+	deal, err := setSponsorContract(ctx, organizer.ID, &SponsorDeal{
+		SponsorID:           sponsor.ID,
+		ContractAmountCents: 1000000,
+		BillingContactID:    billing.ID,
+		Schedule: []Installment{
+			{DueOn: now.AddDate(0, 1, 0), AmountCents: 600000},
+			{DueOn: now.AddDate(0, 2, 0), AmountCents: 400000},
+		},
+	})
+	assertDatabaseError(t, err)
+	_, err = moveSponsorDeal(ctx, organizer.ID, sponsor.ID, DealSigned)
+	assertDatabaseError(t, err)
+
+	t.Run("signed contracts are fixed and public", func(t *testing.T) {
+		if !isListed() {
+			t.Errorf("signed sponsor is not listed")
+		}
+		if _, err := setSponsorContract(ctx, organizer.ID, &SponsorDeal{SponsorID: sponsor.ID, ContractAmountCents: 1,
+			Schedule: []Installment{{DueOn: now, AmountCents: 1}}}); err == nil {
+			t.Errorf("changing a signed contract did not cause an error")
+		}
+	})
+
+	t.Run("invoices and payments take the deal to paid", func(t *testing.T) {
+		first, err := issueSponsorInvoice(ctx, organizer.ID, sponsor.ID, deal.Schedule[0].ID, now)
+		assertDatabaseError(t, err)
+		if first.BilledToEmail != "bill@dealinggophers.example" || first.AmountCents != 600000 || first.Number == "" {
+			t.Errorf("incorrect invoice got %+v", first)
+		}
+		again, err := issueSponsorInvoice(ctx, organizer.ID, sponsor.ID, deal.Schedule[0].ID, now)
+		assertDatabaseError(t, err)
+		if again.ID != first.ID {
+			t.Errorf("invoicing an installment twice issued invoice %s, want %s sent again", again.Number, first.Number)
+		}
+
+		invoice, err := recordSponsorPayment(ctx, organizer.ID, &SponsorPayment{InvoiceID: first.ID, Type: ATCash,
+			AmountCents: 600000, Ref: "wire 1"}, now)
+		assertDatabaseError(t, err)
+		if !invoice.Paid {
+			t.Errorf("expected the invoice to be paid, got %+v", invoice)
+		}
+		if _, err := recordSponsorPayment(ctx, organizer.ID, &SponsorPayment{InvoiceID: first.ID, Type: ATCash,
+			AmountCents: 600000, Ref: "wire 1 again"}, now); err == nil {
+			t.Errorf("paying a paid invoice did not cause an error")
+		}
+		current, err := sponsorDeal(ctx, organizer.ID, sponsor.ID)
+		assertDatabaseError(t, err)
+		if current.Stage != DealInvoiced {
+			t.Errorf("expected the deal to be invoiced while an installment is left, got %s", current.Stage)
+		}
+
+		second, err := issueSponsorInvoice(ctx, organizer.ID, sponsor.ID, deal.Schedule[1].ID, now)
+		assertDatabaseError(t, err)
+		_, err = recordSponsorPayment(ctx, organizer.ID, &SponsorPayment{InvoiceID: second.ID, Type: ATCash,
+			AmountCents: 400000, Ref: "wire 2"}, now)
+		assertDatabaseError(t, err)
+		current, err = sponsorDeal(ctx, organizer.ID, sponsor.ID)
+		assertDatabaseError(t, err)
+		if current.Stage != DealPaid || len(current.Invoices) != 2 {
+			t.Errorf("expected a paid deal with two invoices, got %+v", current)
+		}
+		if err := removeSponsor(ctx, organizer.ID, sponsor.ID); err == nil {
+			t.Errorf("removing an invoiced sponsor did not cause an error")
+		}
+	})
+}
@@ -0,0 +1,208 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"encore.dev/storage/sqldb"
+)
+
+// readSponsorDeal returns the contract of a sponsor with its schedule, invoices and their
+// payments, nil if the sponsor does not exist.
+func readSponsorDeal(ctx context.Context, tx *sqldb.Tx, sponsorID uint32) (*SponsorDeal, error) {
+	d := SponsorDeal{SponsorID: sponsorID, Schedule: []Installment{}, Invoices: []SponsorInvoice{}}
+	sqlStatement := `SELECT stage, contract_amount_cents, COALESCE(billing_contact_id, 0)
+	FROM sponsor WHERE id = $1`
+	var row *sqldb.Row
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sponsorID)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sponsorID)
+	}
+	err := row.Scan(&d.Stage, &d.ContractAmountCents, &d.BillingContactID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading sponsor deal: %w", err)
+	}
+
+	sqlStatement = `SELECT sponsor_installment.id, sponsor_installment.due_on, sponsor_installment.amount_cents,
+	COALESCE(sponsor_invoice.id, 0)
+	FROM sponsor_installment LEFT JOIN sponsor_invoice ON sponsor_invoice.installment_id = sponsor_installment.id
+	WHERE sponsor_installment.sponsor_id = $1 ORDER BY sponsor_installment.due_on, sponsor_installment.id`
+	var rows *sqldb.Rows
+	if tx != nil {
+		rows, err = sqldb.QueryTx(tx, ctx, sqlStatement, sponsorID)
+	} else {
+		rows, err = sqldb.Query(ctx, sqlStatement, sponsorID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying installments: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i Installment
+		if err := rows.Scan(&i.ID, &i.DueOn, &i.AmountCents, &i.InvoiceID); err != nil {
+			return nil, fmt.Errorf("scanning installment: %w", err)
+		}
+		d.Schedule = append(d.Schedule, i)
+	}
+
+	invoices, err := readSponsorInvoices(ctx, tx, sponsorID, 0)
+	if err != nil {
+		return nil, err
+	}
+	d.Invoices = invoices
+	return &d, nil
+}
+
+// readSponsorInvoices returns the invoices of a sponsor, or only invoiceID if not zero, with their
+// payments.
+func readSponsorInvoices(ctx context.Context, tx *sqldb.Tx, sponsorID, invoiceID uint32) ([]SponsorInvoice, error) {
+	sqlStatement := `SELECT id, sponsor_id, installment_id, billed_to, billed_to_email, amount_cents,
+	issued_at, due_on
+	FROM sponsor_invoice WHERE ($1 = 0 OR sponsor_id = $1) AND ($2 = 0 OR id = $2) ORDER BY issued_at, id`
+	var rows *sqldb.Rows
+	var err error
+	if tx != nil {
+		rows, err = sqldb.QueryTx(tx, ctx, sqlStatement, sponsorID, invoiceID)
+	} else {
+		rows, err = sqldb.Query(ctx, sqlStatement, sponsorID, invoiceID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying sponsor invoices: %w", err)
+	}
+	defer rows.Close()
+
+	invoices := []SponsorInvoice{}
+	byID := map[uint32]int{}
+	for rows.Next() {
+		i := SponsorInvoice{Payments: []SponsorPayment{}}
+		err := rows.Scan(&i.ID, &i.SponsorID, &i.InstallmentID, &i.BilledTo, &i.BilledToEmail, &i.AmountCents,
+			&i.IssuedAt, &i.DueOn)
+		if err != nil {
+			return nil, fmt.Errorf("scanning sponsor invoice: %w", err)
+		}
+		i.Number = invoiceNumber(i.ID)
+		byID[i.ID] = len(invoices)
+		invoices = append(invoices, i)
+	}
+
+	sqlStatement = `SELECT sponsor_payment.id, sponsor_payment.invoice_id, sponsor_payment.asset_type,
+	sponsor_payment.amount_cents, sponsor_payment.ref, sponsor_payment.received_at
+	FROM sponsor_payment JOIN sponsor_invoice ON sponsor_invoice.id = sponsor_payment.invoice_id
+	WHERE ($1 = 0 OR sponsor_invoice.sponsor_id = $1) AND ($2 = 0 OR sponsor_invoice.id = $2)
+	ORDER BY sponsor_payment.received_at, sponsor_payment.id`
+	var payments *sqldb.Rows
+	if tx != nil {
+		payments, err = sqldb.QueryTx(tx, ctx, sqlStatement, sponsorID, invoiceID)
+	} else {
+		payments, err = sqldb.Query(ctx, sqlStatement, sponsorID, invoiceID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying sponsor payments: %w", err)
+	}
+	defer payments.Close()
+	for payments.Next() {
+		var p SponsorPayment
+		if err := payments.Scan(&p.ID, &p.InvoiceID, &p.Type, &p.AmountCents, &p.Ref, &p.ReceivedAt); err != nil {
+			return nil, fmt.Errorf("scanning sponsor payment: %w", err)
+		}
+		i := byID[p.InvoiceID]
+		invoices[i].Payments = append(invoices[i].Payments, p)
+	}
+
+	for i := range invoices {
+		invoices[i].settle()
+	}
+	return invoices, nil
+}
+
+// saveSponsorContract replaces the contract amount, billing contact and schedule of a sponsor.
+func saveSponsorContract(ctx context.Context, tx *sqldb.Tx, d *SponsorDeal) error {
+	_, err := sqldb.ExecTx(tx, ctx, `UPDATE sponsor SET contract_amount_cents = $1, billing_contact_id = NULLIF($2, 0)
+	WHERE id = $3`, d.ContractAmountCents, d.BillingContactID, d.SponsorID)
+	if err != nil {
+		return fmt.Errorf("saving sponsor contract: %w", err)
+	}
+	if _, err := sqldb.ExecTx(tx, ctx, `DELETE FROM sponsor_installment WHERE sponsor_id = $1`, d.SponsorID); err != nil {
+		return fmt.Errorf("replacing installments: %w", err)
+	}
+	for _, i := range d.Schedule {
+		_, err := sqldb.ExecTx(tx, ctx, `INSERT INTO sponsor_installment (sponsor_id, due_on, amount_cents)
+		VALUES ($1, $2, $3)`, d.SponsorID, i.DueOn, i.AmountCents)
+		if err != nil {
+			return fmt.Errorf("saving installment: %w", err)
+		}
+	}
+	return nil
+}
+
+// updateSponsorStage moves the deal with a sponsor to the stage.
+func updateSponsorStage(ctx context.Context, tx *sqldb.Tx, sponsorID uint32, stage DealStage) error {
+	sqlStatement := `UPDATE sponsor SET stage = $1 WHERE id = $2`
+	var err error
+	if tx != nil {
+		_, err = sqldb.ExecTx(tx, ctx, sqlStatement, string(stage), sponsorID)
+	} else {
+		_, err = sqldb.Exec(ctx, sqlStatement, string(stage), sponsorID)
+	}
+	if err != nil {
+		return fmt.Errorf("updating deal stage: %w", err)
+	}
+	return nil
+}
+
+// insertSponsorInvoice saves an invoice for an installment, fails if it was already invoiced.
+func insertSponsorInvoice(ctx context.Context, tx *sqldb.Tx, i *SponsorInvoice) (uint32, error) {
+	sqlStatement := `INSERT INTO sponsor_invoice (sponsor_id, installment_id, billed_to, billed_to_email,
+	amount_cents, issued_at, due_on) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	sqlArgs := []interface{}{i.SponsorID, i.InstallmentID, i.BilledTo, i.BilledToEmail, i.AmountCents, i.IssuedAt, i.DueOn}
+	var row *sqldb.Row
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
+	}
+
+	var id uint32
+	if err := row.Scan(&id); err != nil {
+		return 0, fmt.Errorf("saving sponsor invoice: %w", err)
+	}
+	return id, nil
+}
+
+// lockSponsorInvoice locks an invoice and its sponsor until the transaction ends, so payments are
+// recorded against them one at a time. It returns false if there is no such invoice.
+func lockSponsorInvoice(ctx context.Context, tx *sqldb.Tx, invoiceID uint32) (bool, error) {
+	var id uint32
+	err := sqldb.QueryRowTx(tx, ctx, `SELECT sponsor_invoice.id FROM sponsor_invoice
+	JOIN sponsor ON sponsor.id = sponsor_invoice.sponsor_id
+	WHERE sponsor_invoice.id = $1 FOR UPDATE`, invoiceID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("locking sponsor invoice: %w", err)
+	}
+	return true, nil
+}
+
+// insertSponsorPayment adds a payment to the ledger of an invoice.
+func insertSponsorPayment(ctx context.Context, tx *sqldb.Tx, p *SponsorPayment) error {
+	sqlStatement := `INSERT INTO sponsor_payment (invoice_id, asset_type, amount_cents, ref, received_at)
+	VALUES ($1, $2, $3, $4, $5)`
+	sqlArgs := []interface{}{p.InvoiceID, string(p.Type), p.AmountCents, p.Ref, p.ReceivedAt}
+	var err error
+	if tx != nil {
+		_, err = sqldb.ExecTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		_, err = sqldb.Exec(ctx, sqlStatement, sqlArgs...)
+	}
+	if err != nil {
+		return fmt.Errorf("recording sponsor payment: %w", err)
+	}
+	return nil
+}
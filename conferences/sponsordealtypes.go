@@ -0,0 +1,196 @@
+package conferences
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DealStage is how far the deal with a sponsor got
+type DealStage string
+
+// Stages of the deal with a sponsor, in order. Organizers move deals up to signed, issuing invoices
+// and receiving their payments moves them further.
+const (
+	DealProspect     DealStage = "prospect"
+	DealContacted    DealStage = "contacted"
+	DealContractSent DealStage = "contract_sent"
+	DealSigned       DealStage = "signed"
+	DealInvoiced     DealStage = "invoiced"
+	DealPaid         DealStage = "paid"
+)
+
+var dealStages = []DealStage{DealProspect, DealContacted, DealContractSent, DealSigned, DealInvoiced, DealPaid}
+
+// index returns the position of the stage in the pipeline, -1 if it is not a stage.
+func (s DealStage) index() int {
+	for i, stage := range dealStages {
+		if s == stage {
+			return i
+		}
+	}
+	return -1
+}
+
+// signed returns true for the stages past signing the contract, sponsors are public from then on.
+func (s DealStage) signed() bool {
+	return s.index() >= DealSigned.index()
+}
+
+// Installment is a payment due under the contract of a sponsor
+type Installment struct {
+	ID          uint32
+	DueOn       time.Time
+	AmountCents int64 // Money is handled in cents as it is done by our payment processor (stripe)
+	// InvoiceID is zero until the installment is invoiced.
+	InvoiceID uint32
+}
+
+// SponsorPayment is an entry in the ledger of payments of a sponsor invoice
+type SponsorPayment struct {
+	ID        uint32
+	InvoiceID uint32
+	// Type is cash for money received, receivable for credit extended and discount for amounts
+	// waived, as with FinancialInstrument.
+	Type        AssetType
+	AmountCents int64
+	Ref         string
+	ReceivedAt  time.Time
+}
+
+// instrument returns the payment as the FinancialInstrument it stands for.
+func (p *SponsorPayment) instrument() FinancialInstrument {
+	switch p.Type {
+	case ATDiscount:
+		return &PaymentMethodConferenceDiscount{ID: uint64(p.ID), Detail: p.Ref, AmountCents: p.AmountCents}
+	case ATReceivable:
+		return &PaymentMethodCreditNote{ID: uint64(p.ID), Detail: p.Ref, AmountCents: p.AmountCents}
+	default:
+		return &PaymentMethodMoney{ID: uint64(p.ID), PaymentRef: p.Ref, AmountCents: p.AmountCents}
+	}
+}
+
+// validate trims the payment reference and checks the payment can be recorded.
+func (p *SponsorPayment) validate() error {
+	p.Ref = strings.TrimSpace(p.Ref)
+	switch p.Type {
+	case ATCash, ATReceivable, ATDiscount:
+	default:
+		return fmt.Errorf("unknown payment type %q", p.Type)
+	}
+	if p.AmountCents <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+	if p.Type == ATCash && p.Ref == "" {
+		return fmt.Errorf("cash payments need a reference")
+	}
+	return nil
+}
+
+// SponsorInvoice bills an installment to the billing contact of a sponsor
+type SponsorInvoice struct {
+	ID            uint32
+	Number        string
+	SponsorID     uint32
+	InstallmentID uint32
+	BilledTo      string
+	BilledToEmail string
+	AmountCents   int64
+	IssuedAt      time.Time
+	DueOn         time.Time
+	Payments      []SponsorPayment
+	// Paid is set once payments cover the amount and any credit extended was covered too.
+	Paid bool
+	// OutstandingCents is what is left to receive, counting credit extended as not received.
+	OutstandingCents int64
+}
+
+// invoiceNumber returns the number printed on an invoice.
+func invoiceNumber(id uint32) string {
+	return fmt.Sprintf("SPONSOR-%06d", id)
+}
+
+// settle fills in Paid and OutstandingCents from the payments, as ClaimPayment.Paid does.
+func (i *SponsorInvoice) settle() {
+	instruments := make([]FinancialInstrument, 0, len(i.Payments))
+	for p := range i.Payments {
+		instruments = append(instruments, i.Payments[p].instrument())
+	}
+	fulfilled, missing := paymentFulfilled(i.AmountCents, instruments...)
+	balanced, _ := debtBalanced(instruments...)
+	i.Paid = fulfilled && balanced
+	i.OutstandingCents = missing
+	if i.OutstandingCents < 0 {
+		i.OutstandingCents = 0
+	}
+}
+
+// SponsorDeal is the contract with a sponsor and how it is being paid
+type SponsorDeal struct {
+	SponsorID           uint32
+	Stage               DealStage
+	ContractAmountCents int64
+	// BillingContactID is the contact of the sponsor invoices are sent to.
+	BillingContactID uint32
+	Schedule         []Installment
+	Invoices         []SponsorInvoice
+}
+
+// validateContract checks the schedule pays the contract amount in full.
+func (d *SponsorDeal) validateContract() error {
+	if d.ContractAmountCents <= 0 {
+		return fmt.Errorf("contract amount must be positive")
+	}
+	var scheduled int64
+	for _, i := range d.Schedule {
+		if i.AmountCents <= 0 {
+			return fmt.Errorf("installments must be positive")
+		}
+		if i.DueOn.IsZero() {
+			return fmt.Errorf("installments need a due date")
+		}
+		scheduled += i.AmountCents
+	}
+	if scheduled != d.ContractAmountCents {
+		return fmt.Errorf("installments add up to %d but the contract is for %d", scheduled, d.ContractAmountCents)
+	}
+	return nil
+}
+
+// canMoveTo returns an error unless organizers can move the deal to the stage.
+func (d *SponsorDeal) canMoveTo(stage DealStage) error {
+	switch {
+	case stage.index() == -1:
+		return fmt.Errorf("unknown deal stage %q", stage)
+	case stage.index() > DealSigned.index():
+		return fmt.Errorf("deals are %s through invoices and payments", stage)
+	case d.Stage.index() > DealSigned.index():
+		return fmt.Errorf("the deal was already %s", d.Stage)
+	case stage == DealSigned:
+		if err := d.validateContract(); err != nil {
+			return fmt.Errorf("signing needs a contract: %w", err)
+		}
+		if d.BillingContactID == 0 {
+			return fmt.Errorf("signing needs a billing contact")
+		}
+	}
+	return nil
+}
+
+// progress returns the stage the invoices and payments of a signed deal take it to.
+func (d *SponsorDeal) progress() DealStage {
+	if !d.Stage.signed() || len(d.Invoices) == 0 {
+		return d.Stage
+	}
+	for _, i := range d.Schedule {
+		if i.InvoiceID == 0 {
+			return DealInvoiced
+		}
+	}
+	for _, i := range d.Invoices {
+		if !i.Paid {
+			return DealInvoiced
+		}
+	}
+	return DealPaid
+}
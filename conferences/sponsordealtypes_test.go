@@ -0,0 +1,119 @@
+package conferences
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSponsorDealCanMoveTo(t *testing.T) {
+	due := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	contract := SponsorDeal{
+		ContractAmountCents: 1000000,
+		BillingContactID:    3,
+		Schedule:            []Installment{{DueOn: due, AmountCents: 500000}, {DueOn: due.AddDate(0, 3, 0), AmountCents: 500000}},
+	}
+
+	tests := []struct {
+		name    string
+		deal    SponsorDeal
+		stage   DealStage
+		wantErr bool
+	}{
+		{name: "contacted", deal: SponsorDeal{Stage: DealProspect}, stage: DealContacted},
+		{name: "back to prospect", deal: SponsorDeal{Stage: DealContractSent}, stage: DealProspect},
+		{name: "signed", deal: contract, stage: DealSigned},
+		{name: "unknown stage", deal: SponsorDeal{Stage: DealProspect}, stage: "lost", wantErr: true},
+		{name: "invoiced by hand", deal: contract, stage: DealInvoiced, wantErr: true},
+		{name: "paid by hand", deal: contract, stage: DealPaid, wantErr: true},
+		{name: "back from invoiced", deal: SponsorDeal{Stage: DealInvoiced}, stage: DealSigned, wantErr: true},
+		{name: "signed without a contract", deal: SponsorDeal{Stage: DealContractSent, BillingContactID: 3}, stage: DealSigned,
+			wantErr: true},
+		{name: "signed without a billing contact", deal: SponsorDeal{ContractAmountCents: 500000,
+			Schedule: []Installment{{DueOn: due, AmountCents: 500000}}}, stage: DealSigned, wantErr: true},
+		{name: "signed with a short schedule", deal: SponsorDeal{ContractAmountCents: 1000000, BillingContactID: 3,
+			Schedule: []Installment{{DueOn: due, AmountCents: 500000}}}, stage: DealSigned, wantErr: true},
+		{name: "signed with an undated installment", deal: SponsorDeal{ContractAmountCents: 500000, BillingContactID: 3,
+			Schedule: []Installment{{AmountCents: 500000}}}, stage: DealSigned, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.deal.canMoveTo(tt.stage); (err != nil) != tt.wantErr {
+				t.Errorf("canMoveTo() got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSponsorInvoiceSettle(t *testing.T) {
+	tests := []struct {
+		name            string
+		payments        []SponsorPayment
+		wantPaid        bool
+		wantOutstanding int64
+	}{
+		{name: "unpaid", wantOutstanding: 1000},
+		{name: "partly paid", payments: []SponsorPayment{{Type: ATCash, AmountCents: 400}}, wantOutstanding: 600},
+		{name: "paid with a discount", payments: []SponsorPayment{{Type: ATCash, AmountCents: 900}, {Type: ATDiscount, AmountCents: 100}},
+			wantPaid: true},
+		{name: "credit extended", payments: []SponsorPayment{{Type: ATReceivable, AmountCents: 1000}}, wantOutstanding: 1000},
+		{name: "credit covered", payments: []SponsorPayment{{Type: ATReceivable, AmountCents: 1000}, {Type: ATCash, AmountCents: 1000}},
+			wantPaid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := SponsorInvoice{AmountCents: 1000, Payments: tt.payments}
+			i.settle()
+			if i.Paid != tt.wantPaid || i.OutstandingCents != tt.wantOutstanding {
+				t.Errorf("settle() got paid %v with %d outstanding, want %v with %d", i.Paid, i.OutstandingCents,
+					tt.wantPaid, tt.wantOutstanding)
+			}
+		})
+	}
+}
+
+func TestSponsorDealProgress(t *testing.T) {
+	paid := SponsorInvoice{Paid: true}
+	tests := []struct {
+		name string
+		deal SponsorDeal
+		want DealStage
+	}{
+		{name: "not signed", deal: SponsorDeal{Stage: DealContacted}, want: DealContacted},
+		{name: "signed without invoices", deal: SponsorDeal{Stage: DealSigned, Schedule: []Installment{{ID: 1}}}, want: DealSigned},
+		{name: "some installments invoiced", deal: SponsorDeal{Stage: DealSigned,
+			Schedule: []Installment{{ID: 1, InvoiceID: 1}, {ID: 2}}, Invoices: []SponsorInvoice{paid}}, want: DealInvoiced},
+		{name: "invoices not paid", deal: SponsorDeal{Stage: DealInvoiced,
+			Schedule: []Installment{{ID: 1, InvoiceID: 1}}, Invoices: []SponsorInvoice{{}}}, want: DealInvoiced},
+		{name: "everything paid", deal: SponsorDeal{Stage: DealInvoiced,
+			Schedule: []Installment{{ID: 1, InvoiceID: 1}}, Invoices: []SponsorInvoice{paid}}, want: DealPaid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.deal.progress(); got != tt.want {
+				t.Errorf("progress() got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSponsorPaymentValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payment SponsorPayment
+		wantErr bool
+	}{
+		{name: "cash", payment: SponsorPayment{Type: ATCash, AmountCents: 100, Ref: " wire 42 "}},
+		{name: "discount", payment: SponsorPayment{Type: ATDiscount, AmountCents: 100}},
+		{name: "unknown type", payment: SponsorPayment{Type: "barter", AmountCents: 100}, wantErr: true},
+		{name: "no amount", payment: SponsorPayment{Type: ATDiscount}, wantErr: true},
+		{name: "cash without reference", payment: SponsorPayment{Type: ATCash, AmountCents: 100}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.payment
+			if err := p.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
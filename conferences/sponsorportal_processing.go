@@ -0,0 +1,167 @@
+package conferences
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+// sponsorLogoKey is where the logo of a sponsor is kept in the upload store.
+func sponsorLogoKey(sponsorID uint32) string {
+	return fmt.Sprintf("sponsor-logos/%d", sponsorID)
+}
+
+// joinSponsorPortal links the user to the contact of a sponsor an organizer invited with the
+// token and returns the sponsor, from then on they manage the sponsor in its portal.
+func joinSponsorPortal(ctx context.Context, userID uint32, token string) (uint32, error) {
+	return linkSponsorContact(ctx, userID, hashOneTimeToken(token), time.Now())
+}
+
+// contactSponsor returns a sponsor along with its contacts to one of them, the portal of a
+// sponsor is only open to the contacts who joined it.
+func contactSponsor(ctx context.Context, userID, sponsorID uint32) (*Sponsor, error) {
+	sponsor, err := readSponsorByID(ctx, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	if sponsor != nil {
+		for _, c := range sponsor.Contacts {
+			if c.UserID != 0 && c.UserID == userID {
+				return sponsor, nil
+			}
+		}
+	}
+	// others cannot tell a sponsor they are not a contact of from one that does not exist.
+	return nil, fmt.Errorf("no such sponsor")
+}
+
+// signedContactSponsor is contactSponsor for what the package of the sponsor pays for, which is
+// only available once its contract is signed.
+func signedContactSponsor(ctx context.Context, userID, sponsorID uint32) (*Sponsor, error) {
+	sponsor, err := contactSponsor(ctx, userID, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	if !sponsor.Stage.signed() {
+		return nil, fmt.Errorf("the sponsorship contract is not signed yet")
+	}
+	return sponsor, nil
+}
+
+// mySponsor returns a sponsor the user is a contact of with how much of its package it has used.
+func mySponsor(ctx context.Context, userID, sponsorID uint32) (*Sponsor, []EntitlementUsage, error) {
+	sponsor, err := contactSponsor(ctx, userID, sponsorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	usage, err := readSponsorEntitlements(ctx, sponsor)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sponsor, usage, nil
+}
+
+// updateMySponsorProfile saves the public profile of a sponsor the user is a contact of.
+func updateMySponsorProfile(ctx context.Context, userID, sponsorID uint32, profile SponsorProfile) (*Sponsor, error) {
+	sponsor, err := contactSponsor(ctx, userID, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	edited, err := profile.applyTo(*sponsor)
+	if err != nil {
+		return nil, err
+	}
+	return updateSponsorProfile(ctx, edited)
+}
+
+// uploadSponsorLogo stores an image as the logo of a sponsor the user is a contact of, replacing
+// any previous one.
+func uploadSponsorLogo(ctx context.Context, userID, sponsorID uint32, image []byte) error {
+	if _, err := contactSponsor(ctx, userID, sponsorID); err != nil {
+		return err
+	}
+	contentType, err := detectImage(image)
+	if err != nil {
+		return err
+	}
+	key := sponsorLogoKey(sponsorID)
+	if err := uploads.put(key, image); err != nil {
+		return err
+	}
+	return updateSponsorLogo(ctx, sponsorID, key, contentType)
+}
+
+// publicSponsorLogo returns the logo of a signed sponsor and its content type, others are not
+// published.
+func publicSponsorLogo(ctx context.Context, sponsorID uint32) ([]byte, string, error) {
+	sponsor, err := readSponsorByID(ctx, sponsorID)
+	if err != nil {
+		return nil, "", err
+	}
+	key, contentType, err := readSponsorLogo(ctx, sponsorID)
+	if err != nil {
+		return nil, "", err
+	}
+	if sponsor == nil || !sponsor.Stage.signed() || key == "" {
+		return nil, "", fmt.Errorf("no such logo")
+	}
+	image, err := uploads.get(key)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, "", fmt.Errorf("no such logo")
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return image, contentType, nil
+}
+
+// registerBoothStaff issues the tickets of a sponsor the user is a contact of to its staff.
+func registerBoothStaff(ctx context.Context, userID, sponsorID, entitlementID uint32, emails []string) ([]SlotClaim, error) {
+	sponsor, err := signedContactSponsor(ctx, userID, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	return issueTickets(ctx, sponsor, entitlementID, emails)
+}
+
+// postSponsorJob posts a job for a sponsor the user is a contact of, using one of its job post
+// entitlements. The job waits for approval like any other.
+func postSponsorJob(ctx context.Context, userID, sponsorID, entitlementID uint32, job Job) (*Job, error) {
+	sponsor, err := signedContactSponsor(ctx, userID, sponsorID)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := sqldb.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	var saved *Job
+	e, err := availableEntitlement(ctx, tx, sponsor, entitlementID, 1)
+	if err == nil && e.Kind != EntitlementJobPost {
+		err = fmt.Errorf("entitlement %d is not for job posts", e.ID)
+	}
+	if err == nil {
+		var posted *Job
+		posted, err = sponsorJob(sponsor, job)
+		if err == nil {
+			saved, err = insertSponsorJob(ctx, tx, sponsor.ID, posted)
+		}
+	}
+	if err == nil {
+		err = insertEntitlementUse(ctx, tx, sponsor.ID, e.ID, 0, fmt.Sprintf("job %d", saved.ID))
+	}
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return nil, err
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return saved, nil
+}
@@ -0,0 +1,121 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+
+	"encore.dev/storage/sqldb"
+)
+
+func TestSponsorPortal(t *testing.T) {
+	ctx := context.Background()
+
+	organizer := createOrganizer(t, ctx, "portal-admin@gophercon.com")
+	contact, err := createAttendee(ctx, nil, &User{Email: "portal-contact@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	outsider, err := createAttendee(ctx, nil, &User{Email: "portal-outsider@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+
+	row := sqldb.QueryRow(ctx, `INSERT INTO conference_slot (name, description, cost, capacity, start_date, end_date,
+	purchaseable_from, purchaseable_until, available_to_public, conference_id, location_id)
+	VALUES ('Booth staff pass', 'For booth staff', 30000, 100, NOW() + INTERVAL '90 days', NOW() + INTERVAL '92 days',
+	NOW(), NOW() + INTERVAL '89 days', FALSE, 2, 2) RETURNING id`)
+	var slotID uint32
+	assertDatabaseError(t, row.Scan(&slotID))
+
+	tier, err := saveTier(ctx, organizer.ID, &SponsorshipTier{ConferenceID: 2, Name: "Portal Test", Rank: 60})
+	assertDatabaseError(t, err)
+	tickets, err := addTierEntitlement(ctx, organizer.ID, &Entitlement{TierID: tier.ID, Kind: EntitlementTicket, Quantity: 1, SlotID: slotID})
+	assertDatabaseError(t, err)
+	jobPosts, err := addTierEntitlement(ctx, organizer.ID, &Entitlement{TierID: tier.ID, Kind: EntitlementJobPost, Quantity: 1})
+	assertDatabaseError(t, err)
+	sponsor, err := saveSponsor(ctx, organizer.ID, &Sponsor{Name: "Portal Gophers", ConferenceID: 2, Tier: SponsorshipTier{ID: tier.ID}})
+	assertDatabaseError(t, err)
+	sponsorContact, err := addSponsorContact(ctx, organizer.ID, sponsor.ID, &SponsorContactInformation{Name: "Portal Contact",
+		Role: ContactRoleMarketing, Email: "Portal-Contact@gophercon.com"})
+	assertDatabaseError(t, err)
+
+	t.Run("only invited contacts can join the portal", func(t *testing.T) {
+		if _, err := inviteSponsorContact(ctx, outsider.ID, sponsor.ID, sponsorContact.ID); err == nil {
+			t.Errorf("an outsider inviting a contact did not cause an error")
+		}
+		invite, err := inviteSponsorContact(ctx, organizer.ID, sponsor.ID, sponsorContact.ID)
+		assertDatabaseError(t, err)
+		if _, err := joinSponsorPortal(ctx, outsider.ID, "not an invite"); err == nil {
+			t.Errorf("joining the portal without an invite did not cause an error")
+		}
+		joined, err := joinSponsorPortal(ctx, contact.ID, invite)
+		assertDatabaseError(t, err)
+		if joined != sponsor.ID {
+			t.Errorf("incorrect sponsor joined got %v want %v", joined, sponsor.ID)
+		}
+		if _, err := joinSponsorPortal(ctx, outsider.ID, invite); err == nil {
+			t.Errorf("reusing an invite did not cause an error")
+		}
+
+		sponsors, err := readSponsorsByContact(ctx, contact.ID)
+		assertDatabaseError(t, err)
+		if len(sponsors) != 1 || sponsors[0].ID != sponsor.ID {
+			t.Errorf("incorrect sponsors of contact got %+v", sponsors)
+		}
+		if _, _, err := mySponsor(ctx, outsider.ID, sponsor.ID); err == nil {
+			t.Errorf("an outsider could read the sponsor")
+		}
+	})
+
+	t.Run("contacts edit the public profile", func(t *testing.T) {
+		updated, err := updateMySponsorProfile(ctx, contact.ID, sponsor.ID, SponsorProfile{Name: "Portal Gophers",
+			Website: "https://portal.example", Description: " We build portals. "})
+		assertDatabaseError(t, err)
+		if updated.Description != "We build portals." || updated.Tier.ID != tier.ID || updated.Stage != DealProspect {
+			t.Errorf("incorrect sponsor got %+v", updated)
+		}
+		if _, err := updateMySponsorProfile(ctx, outsider.ID, sponsor.ID, SponsorProfile{Name: "Hijacked"}); err == nil {
+			t.Errorf("an outsider could edit the profile")
+		}
+	})
+
+	t.Run("entitlements wait for the contract", func(t *testing.T) {
+		if _, err := registerBoothStaff(ctx, contact.ID, sponsor.ID, tickets.ID, []string{"early-staff@gophercon.com"}); err == nil {
+			t.Errorf("registering staff before signing did not cause an error")
+		}
+		_, err := sqldb.Exec(ctx, `UPDATE sponsor SET stage = 'signed' WHERE id = $1`, sponsor.ID)
+		assertDatabaseError(t, err)
+	})
+
+	t.Run("contacts register booth staff", func(t *testing.T) {
+		claims, err := registerBoothStaff(ctx, contact.ID, sponsor.ID, tickets.ID, []string{"booth-staff@gophercon.com"})
+		assertDatabaseError(t, err)
+		if len(claims) != 1 || claims[0].ConferenceSlot.ID != slotID {
+			t.Fatalf("incorrect claims got %+v", claims)
+		}
+		if _, err := registerBoothStaff(ctx, outsider.ID, sponsor.ID, tickets.ID, []string{"sneaky@gophercon.com"}); err == nil {
+			t.Errorf("an outsider could register staff")
+		}
+	})
+
+	t.Run("contacts post jobs of the sponsor", func(t *testing.T) {
+		job, err := postSponsorJob(ctx, contact.ID, sponsor.ID, jobPosts.ID, Job{CompanyName: "Someone Else",
+			Title: "Portal Engineer", Description: "Build portals", Link: "https://portal.example/jobs"})
+		assertDatabaseError(t, err)
+		if job.SponsorID != sponsor.ID || job.CompanyName != "Portal Gophers" || job.Approved {
+			t.Errorf("incorrect job got %+v", job)
+		}
+		if _, err := postSponsorJob(ctx, contact.ID, sponsor.ID, jobPosts.ID, Job{Title: "Another",
+			Description: "One too many", Link: "https://portal.example/jobs"}); err == nil {
+			t.Errorf("posting more jobs than entitled did not cause an error")
+		}
+	})
+
+	t.Run("changing the email of a contact needs a new invite", func(t *testing.T) {
+		changed := *sponsorContact
+		changed.Email = "portal-outsider@gophercon.com"
+		assertDatabaseError(t, updateSponsorContact(ctx, organizer.ID, &changed))
+		if _, err := contactSponsor(ctx, contact.ID, sponsor.ID); err == nil {
+			t.Errorf("the contact stayed linked after their email changed")
+		}
+		if _, err := contactSponsor(ctx, outsider.ID, sponsor.ID); err == nil {
+			t.Errorf("the new email was linked without joining with an invite")
+		}
+	})
+}
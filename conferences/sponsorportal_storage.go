@@ -0,0 +1,141 @@
+package conferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+// readSponsorsByContact returns the sponsors the user joined the portal of, without their contacts.
+func readSponsorsByContact(ctx context.Context, userID uint32) ([]Sponsor, error) {
+	rows, err := sqldb.Query(ctx, `SELECT `+sponsorColumns+` FROM `+sponsorTables+`
+	WHERE sponsor.id IN (SELECT sponsor_id FROM sponsor_contact_information WHERE user_id = $1)
+	ORDER BY sponsor.conference_id DESC, sponsor.name`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying sponsors of contact: %w", err)
+	}
+	defer rows.Close()
+
+	sponsors := []Sponsor{}
+	for rows.Next() {
+		sponsor, err := scanSponsor(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning sponsor: %w", err)
+		}
+		sponsors = append(sponsors, *sponsor)
+	}
+	return sponsors, nil
+}
+
+// linkSponsorContact links the user to the contact a valid, unused invite was issued to and
+// returns the sponsor of the contact.
+func linkSponsorContact(ctx context.Context, userID uint32, tokenHash string, now time.Time) (uint32, error) {
+	row := sqldb.QueryRow(ctx, `UPDATE sponsor_contact_information
+	SET user_id = $1, invite_token_hash = NULL, invite_expires_at = NULL
+	WHERE invite_token_hash = $2 AND invite_expires_at > $3 AND user_id IS NULL
+	RETURNING sponsor_id`,
+		userID, tokenHash, now)
+
+	var sponsorID uint32
+	err := row.Scan(&sponsorID)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("invite is invalid or expired")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("joining sponsor portal: %w", err)
+	}
+	return sponsorID, nil
+}
+
+// updateSponsorContactInvite replaces the invite of a contact who has not joined the portal of
+// their sponsor yet.
+func updateSponsorContactInvite(ctx context.Context, sponsorID, contactID uint32, tokenHash string, expiresAt time.Time) error {
+	res, err := sqldb.Exec(ctx, `UPDATE sponsor_contact_information
+	SET invite_token_hash = $1, invite_expires_at = $2
+	WHERE id = $3 AND sponsor_id = $4 AND user_id IS NULL AND email IS NOT NULL`,
+		tokenHash, expiresAt, contactID, sponsorID)
+	if err != nil {
+		return fmt.Errorf("saving sponsor contact invite: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	if ra == 0 {
+		return fmt.Errorf("no such contact to invite")
+	}
+	return nil
+}
+
+// updateSponsorProfile changes the public profile of a sponsor, its tier and deal are left as is.
+func updateSponsorProfile(ctx context.Context, s *Sponsor) (*Sponsor, error) {
+	row := sqldb.QueryRow(ctx, `WITH saved AS (
+		UPDATE sponsor SET name = $1, address = $2, website = $3, description = $4 WHERE id = $5 RETURNING *
+	) SELECT `+sponsorColumns+` FROM saved AS sponsor
+	JOIN sponsorship_tier ON sponsorship_tier.id = sponsor.tier_id`,
+		s.Name, s.Address, s.Website, s.Description, s.ID)
+
+	saved, err := scanSponsor(row.Scan)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no such sponsor")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("updating sponsor profile: %w", err)
+	}
+	return saved, nil
+}
+
+// updateSponsorLogo records where the logo of a sponsor is stored.
+func updateSponsorLogo(ctx context.Context, sponsorID uint32, key, contentType string) error {
+	res, err := sqldb.Exec(ctx, `UPDATE sponsor SET logo_key = $1, logo_content_type = $2 WHERE id = $3`,
+		key, contentType, sponsorID)
+	if err != nil {
+		return fmt.Errorf("saving sponsor logo: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	if ra == 0 {
+		return fmt.Errorf("no such sponsor")
+	}
+	return nil
+}
+
+// readSponsorLogo returns where the logo of a sponsor is stored and its content type, empty if
+// it has none.
+func readSponsorLogo(ctx context.Context, sponsorID uint32) (string, string, error) {
+	var key, contentType string
+	row := sqldb.QueryRow(ctx, `SELECT COALESCE(logo_key, ''), COALESCE(logo_content_type, '')
+	FROM sponsor WHERE id = $1`, sponsorID)
+	err := row.Scan(&key, &contentType)
+	if err == sql.ErrNoRows {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("reading sponsor logo: %w", err)
+	}
+	return key, contentType, nil
+}
+
+// insertSponsorJob saves a job posted by a sponsor, it waits for approval like any other job.
+func insertSponsorJob(ctx context.Context, tx *sqldb.Tx, sponsorID uint32, job *Job) (*Job, error) {
+	sqlStatement := `INSERT INTO job_board (company_name, title, description, link, discord, rank, sponsor_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING ` + jobColumns
+	sqlArgs := []interface{}{job.CompanyName, job.Title, job.Description, job.Link, job.Discord, job.Rank, sponsorID}
+	var row *sqldb.Row
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, sqlArgs...)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
+	}
+
+	saved, err := scanJob(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("saving sponsor job: %w", err)
+	}
+	return saved, nil
+}
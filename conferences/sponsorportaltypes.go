@@ -0,0 +1,57 @@
+package conferences
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SponsorProfile is the public profile of a sponsor its contacts edit in its portal, the tier and
+// the deal stay with the organizers.
+type SponsorProfile struct {
+	Name        string
+	Address     string
+	Website     string
+	Description string
+}
+
+// applyTo returns the sponsor with the profile in place of its own, checked to be saved.
+func (p SponsorProfile) applyTo(sponsor Sponsor) (*Sponsor, error) {
+	sponsor.Name = p.Name
+	sponsor.Address = p.Address
+	sponsor.Website = p.Website
+	sponsor.Description = p.Description
+	if err := sponsor.normalize(); err != nil {
+		return nil, err
+	}
+	return &sponsor, nil
+}
+
+// sponsorJob returns the job posted by a sponsor, listed under its name and ranked by its tier so
+// higher tiers come first.
+func sponsorJob(sponsor *Sponsor, job Job) (*Job, error) {
+	job.ID = 0
+	job.CompanyName = sponsor.Name
+	job.Rank = sponsor.Tier.Rank
+	job.Approved = false
+	job.SponsorID = sponsor.ID
+	job.Title = strings.TrimSpace(job.Title)
+	job.Description = strings.TrimSpace(job.Description)
+	job.Link = strings.TrimSpace(job.Link)
+	job.Discord = strings.TrimSpace(job.Discord)
+	if job.Title == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	if job.Description == "" {
+		return nil, fmt.Errorf("description is required")
+	}
+	if job.Link == "" {
+		return nil, fmt.Errorf("link is required")
+	}
+	if err := validateLink("link", job.Link); err != nil {
+		return nil, err
+	}
+	if err := validateLink("discord", job.Discord); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
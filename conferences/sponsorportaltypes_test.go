@@ -0,0 +1,54 @@
+package conferences
+
+import (
+	"testing"
+)
+
+func TestSponsorJob(t *testing.T) {
+	sponsor := &Sponsor{ID: 7, Name: "Gophers Inc", Tier: SponsorshipTier{Rank: 2}}
+	tests := []struct {
+		name    string
+		job     Job
+		wantErr bool
+	}{
+		{name: "valid", job: Job{ID: 3, CompanyName: "Someone Else", Title: " Gopher ", Description: "Dig tunnels",
+			Link: "https://gophers.example/jobs", Rank: 1, Approved: true}},
+		{name: "missing title", job: Job{Description: "Dig tunnels", Link: "https://gophers.example/jobs"}, wantErr: true},
+		{name: "missing description", job: Job{Title: "Gopher", Link: "https://gophers.example/jobs"}, wantErr: true},
+		{name: "missing link", job: Job{Title: "Gopher", Description: "Dig tunnels"}, wantErr: true},
+		{name: "discord is not a link", job: Job{Title: "Gopher", Description: "Dig tunnels",
+			Link: "https://gophers.example/jobs", Discord: "gophers"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := sponsorJob(sponsor, tt.job)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sponsorJob() got error %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if job.ID != 0 || job.SponsorID != 7 || job.CompanyName != "Gophers Inc" || job.Rank != 2 || job.Approved ||
+				job.Title != "Gopher" {
+				t.Errorf("sponsorJob() got %+v", job)
+			}
+		})
+	}
+}
+
+func TestSponsorProfileApplyTo(t *testing.T) {
+	sponsor := Sponsor{ID: 7, Name: "Gophers Inc", ConferenceID: 1, Tier: SponsorshipTier{ID: 3}, Stage: DealSigned}
+	got, err := SponsorProfile{Name: " Gophers Ltd ", Description: " Tunnels "}.applyTo(sponsor)
+	if err != nil {
+		t.Fatalf("applyTo() got error %v", err)
+	}
+	if got.Name != "Gophers Ltd" || got.Description != "Tunnels" || got.Tier.ID != 3 || got.Stage != DealSigned {
+		t.Errorf("applyTo() got %+v", got)
+	}
+	if sponsor.Name != "Gophers Inc" {
+		t.Errorf("applyTo() changed the sponsor it was passed")
+	}
+	if _, err := (SponsorProfile{Website: "gophers.example", Name: "Gophers"}).applyTo(sponsor); err == nil {
+		t.Errorf("applyTo() accepted a website that is not a link")
+	}
+}
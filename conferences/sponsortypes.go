@@ -0,0 +1,79 @@
+package conferences
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSponsorDescriptionLength is how long the description sponsors write in their portal can be.
+const maxSponsorDescriptionLength = 2000
+
+// normalize trims the tier details and checks they can be saved.
+func (t *SponsorshipTier) normalize() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if t.ConferenceID == 0 {
+		return fmt.Errorf("conference is required")
+	}
+	if t.Rank < 1 {
+		return fmt.Errorf("rank must be 1 or more, 1 being the highest")
+	}
+	if t.PriceCents < 0 {
+		return fmt.Errorf("price cannot be negative")
+	}
+	if t.MaxSponsors < 0 {
+		return fmt.Errorf("max sponsors cannot be negative")
+	}
+	benefits := make([]string, 0, len(t.Benefits))
+	for _, b := range t.Benefits {
+		if b = strings.TrimSpace(b); b != "" {
+			benefits = append(benefits, b)
+		}
+	}
+	t.Benefits = benefits
+	return nil
+}
+
+// normalize trims the sponsor details and checks they can be saved.
+func (s *Sponsor) normalize() error {
+	s.Name = strings.TrimSpace(s.Name)
+	s.Address = strings.TrimSpace(s.Address)
+	s.Website = strings.TrimSpace(s.Website)
+	s.Description = strings.TrimSpace(s.Description)
+	if s.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if s.ConferenceID == 0 {
+		return fmt.Errorf("conference is required")
+	}
+	if s.Tier.ID == 0 {
+		return fmt.Errorf("tier is required")
+	}
+	if utf8.RuneCountInString(s.Description) > maxSponsorDescriptionLength {
+		return fmt.Errorf("description must be at most %d characters long", maxSponsorDescriptionLength)
+	}
+	return validateLink("website", s.Website)
+}
+
+// normalize trims the contact details and checks they can be saved.
+func (c *SponsorContactInformation) normalize() error {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Email = strings.TrimSpace(c.Email)
+	c.Phone = strings.TrimSpace(c.Phone)
+	if c.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if c.Email == "" && c.Phone == "" {
+		return fmt.Errorf("an email or a phone is required")
+	}
+	if c.Email != "" && !strings.Contains(c.Email, "@") {
+		return fmt.Errorf("invalid email %q", c.Email)
+	}
+	if int(c.Role) > len(contactRoleMappings)-1 || c.Role < 0 {
+		return fmt.Errorf("invalid role provided")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package conferences
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSponsorNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		sponsor Sponsor
+		wantErr bool
+	}{
+		{name: "valid", sponsor: Sponsor{Name: " Gophers Inc ", Website: "https://gophers.example", ConferenceID: 1,
+			Tier: SponsorshipTier{ID: 1}}},
+		{name: "missing name", sponsor: Sponsor{Name: " ", ConferenceID: 1, Tier: SponsorshipTier{ID: 1}}, wantErr: true},
+		{name: "missing conference", sponsor: Sponsor{Name: "Gophers Inc", Tier: SponsorshipTier{ID: 1}}, wantErr: true},
+		{name: "missing tier", sponsor: Sponsor{Name: "Gophers Inc", ConferenceID: 1}, wantErr: true},
+		{name: "website is not a link", sponsor: Sponsor{Name: "Gophers Inc", Website: "gophers.example", ConferenceID: 1,
+			Tier: SponsorshipTier{ID: 1}}, wantErr: true},
+		{name: "description too long", sponsor: Sponsor{Name: "Gophers Inc", ConferenceID: 1, Tier: SponsorshipTier{ID: 1},
+			Description: strings.Repeat("g", maxSponsorDescriptionLength+1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.sponsor
+			err := s.normalize()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalize() got error %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && s.Name != "Gophers Inc" {
+				t.Errorf("normalize() did not trim the name, got %q", s.Name)
+			}
+		})
+	}
+}
+
+func TestSponsorshipTierNormalize(t *testing.T) {
+	tests := []struct {
+		name         string
+		tier         SponsorshipTier
+		wantErr      bool
+		wantBenefits []string
+	}{
+		{name: "valid", tier: SponsorshipTier{Name: " Diamond ", ConferenceID: 1, Rank: 1, PriceCents: 5000000,
+			Benefits: []string{" Booth ", "", "Keynote mention"}}, wantBenefits: []string{"Booth", "Keynote mention"}},
+		{name: "missing name", tier: SponsorshipTier{ConferenceID: 1, Rank: 1}, wantErr: true},
+		{name: "missing conference", tier: SponsorshipTier{Name: "Diamond", Rank: 1}, wantErr: true},
+		{name: "missing rank", tier: SponsorshipTier{Name: "Diamond", ConferenceID: 1}, wantErr: true},
+		{name: "negative price", tier: SponsorshipTier{Name: "Diamond", ConferenceID: 1, Rank: 1, PriceCents: -1}, wantErr: true},
+		{name: "negative limit", tier: SponsorshipTier{Name: "Diamond", ConferenceID: 1, Rank: 1, MaxSponsors: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tier := tt.tier
+			err := tier.normalize()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalize() got error %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tier.Name != "Diamond" || len(tier.Benefits) != len(tt.wantBenefits) {
+				t.Fatalf("normalize() got %+v", tier)
+			}
+			for i := range tier.Benefits {
+				if tier.Benefits[i] != tt.wantBenefits[i] {
+					t.Errorf("normalize() got benefits %v, want %v", tier.Benefits, tt.wantBenefits)
+				}
+			}
+		})
+	}
+}
+
+func TestSponsorContactNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		contact SponsorContactInformation
+		wantErr bool
+	}{
+		{name: "email only", contact: SponsorContactInformation{Name: "Gary", Email: "gary@gopher.com"}},
+		{name: "phone only", contact: SponsorContactInformation{Name: "Gary", Phone: "600613", Role: ContactRoleSoleContact}},
+		{name: "missing name", contact: SponsorContactInformation{Email: "gary@gopher.com"}, wantErr: true},
+		{name: "no way to reach", contact: SponsorContactInformation{Name: "Gary"}, wantErr: true},
+		{name: "invalid email", contact: SponsorContactInformation{Name: "Gary", Email: "gary"}, wantErr: true},
+		{name: "invalid role", contact: SponsorContactInformation{Name: "Gary", Email: "gary@gopher.com", Role: 5}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.contact
+			if err := c.normalize(); (err != nil) != tt.wantErr {
+				t.Errorf("normalize() got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
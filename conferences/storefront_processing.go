@@ -0,0 +1,33 @@
+package conferences
+
+import (
+	"context"
+	"time"
+)
+
+// storefrontCacheTTL is how long the tickets page may show stale remaining capacity.
+const storefrontCacheTTL = 5 * time.Second
+
+// storefrontCacheSize is how many conferences the tickets page keeps in memory at once.
+const storefrontCacheSize = 32
+
+var storefrontCache = &salesCache{ttl: storefrontCacheTTL, size: storefrontCacheSize}
+
+// slotAvailability returns the public slots of a conference with their remaining capacity and
+// sale status at now, the ones whose sale closed only if includeClosed is set.
+func slotAvailability(ctx context.Context, conferenceID uint32, includeClosed bool, now time.Time) ([]SlotAvailability, error) {
+	sales, err := storefrontCache.get(ctx, conferenceID, now, readSlotSales)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := []SlotAvailability{}
+	for i := range sales {
+		a := sales[i].availability(now)
+		if a.Status == SaleClosed && !includeClosed {
+			continue
+		}
+		slots = append(slots, a)
+	}
+	return slots, nil
+}
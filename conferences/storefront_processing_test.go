@@ -0,0 +1,54 @@
+package conferences
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"encore.dev/storage/sqldb"
+)
+
+func TestSlotAvailability(t *testing.T) {
+	ctx := context.Background()
+
+	row := sqldb.QueryRow(ctx, `INSERT INTO conference (name, slug, start_date, end_date, event_id, venue_id)
+	VALUES ('GopherCon Storefront Test', 'gc-storefront-test', NOW() + INTERVAL '90 days', NOW() + INTERVAL '93 days', 1, 1)
+	RETURNING id`)
+	var conferenceID uint32
+	assertDatabaseError(t, row.Scan(&conferenceID))
+
+	createSlot := func(name string, capacity int, public bool, opens, closes string, dependsOn uint32) *ConferenceSlot {
+		row := sqldb.QueryRow(ctx, `INSERT INTO conference_slot (name, description, cost, capacity, start_date, end_date,
+		purchaseable_from, purchaseable_until, available_to_public, conference_id, depends_on, location_id)
+		VALUES ($1, 'Storefront', 10000, $2, NOW() + INTERVAL '90 days', NOW() + INTERVAL '91 days',
+		NOW() + $3::INTERVAL, NOW() + $4::INTERVAL, $5, $6, NULLIF($7, 0), 1) RETURNING id`,
+			name, capacity, opens, closes, public, conferenceID, dependsOn)
+		var slotID uint32
+		assertDatabaseError(t, row.Scan(&slotID))
+		slot, err := readConferenceSlotByID(ctx, nil, uint64(slotID), false)
+		assertDatabaseError(t, err)
+		return slot
+	}
+	admission := createSlot("Storefront admission", 1, true, "-1 day", "30 days", 0)
+	workshop := createSlot("Storefront workshop", 20, true, "1 day", "30 days", admission.ID)
+	createSlot("Storefront sponsor pass", 20, false, "-1 day", "30 days", 0)
+	createSlot("Storefront early bird", 20, true, "-30 days", "-1 day", 0)
+
+	buyer, err := createAttendee(ctx, nil, &User{Email: "storefront-buyer@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	_, err = claimSlots(ctx, buyer, []ConferenceSlot{*admission})
+	assertDatabaseError(t, err)
+
+	slots, err := slotAvailability(ctx, conferenceID, false, time.Now())
+	assertDatabaseError(t, err)
+	if len(slots) != 2 {
+		t.Fatalf("expected the admission and the workshop, got %+v", slots)
+	}
+	if slots[0].Slot.ID != admission.ID || slots[0].Status != SaleSoldOut || slots[0].Remaining != 0 {
+		t.Errorf("incorrect admission availability got %+v", slots[0])
+	}
+	if slots[1].Slot.ID != workshop.ID || slots[1].Status != SaleUpcoming || slots[1].Remaining != 20 ||
+		slots[1].Requires == nil || slots[1].Requires.Name != "Storefront admission" || slots[1].Slot.Location.Name == "" {
+		t.Errorf("incorrect workshop availability got %+v", slots[1])
+	}
+}
@@ -0,0 +1,56 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+
+	"encore.dev/storage/sqldb"
+)
+
+// readSlotSales returns the public slots of a conference that were not retired, with the number of
+// claims made for each and the name of the slot they depend on.
+func readSlotSales(ctx context.Context, conferenceID uint32) ([]slotSales, error) {
+	var exists bool
+	row := sqldb.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM conference WHERE id = $1)`, conferenceID)
+	if err := row.Scan(&exists); err != nil {
+		return nil, fmt.Errorf("checking conference: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("no such conference")
+	}
+
+	rows, err := sqldb.Query(ctx, `SELECT `+conferenceSlotColumns+`,
+	(SELECT COUNT(*) FROM slot_claim WHERE slot_claim.conference_slot_id = conference_slot.id AND slot_claim.revoked = FALSE),
+	COALESCE((SELECT dependency.name FROM conference_slot AS dependency WHERE dependency.id = conference_slot.depends_on), '')
+	FROM conference_slot
+	WHERE conference_id = $1 AND available_to_public = TRUE AND retired_at IS NULL
+	ORDER BY start_date, id`, conferenceID)
+	if err != nil {
+		return nil, fmt.Errorf("querying slot sales: %w", err)
+	}
+	defer rows.Close()
+
+	sales := []slotSales{}
+	locationIDs := []uint32{}
+	for rows.Next() {
+		var s slotSales
+		slot, err := scanConferenceSlot(func(dest ...interface{}) error {
+			return rows.Scan(append(dest, &s.claims, &s.requiresName)...)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scanning slot sales: %w", err)
+		}
+		s.slot = *slot
+		sales = append(sales, s)
+		locationIDs = append(locationIDs, slot.Location.ID)
+	}
+
+	locations, err := readLocations(ctx, locationIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range sales {
+		sales[i].slot.Location = locations[sales[i].slot.Location.ID]
+	}
+	return sales, nil
+}
@@ -0,0 +1,150 @@
+package conferences
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SaleStatus tells whether a slot can be bought
+type SaleStatus string
+
+// Sale statuses of a slot
+const (
+	SaleUpcoming SaleStatus = "upcoming"
+	SaleOnSale   SaleStatus = "on_sale"
+	SaleSoldOut  SaleStatus = "sold_out"
+	SaleClosed   SaleStatus = "closed"
+)
+
+// SlotRequirement is the slot that has to be bought along with, or before, another one
+type SlotRequirement struct {
+	SlotID uint32
+	Name   string
+}
+
+// SlotAvailability is a slot as shown on the tickets page
+type SlotAvailability struct {
+	Slot      ConferenceSlot
+	Remaining int
+	Status    SaleStatus
+	// Requires is set when the slot depends on another one.
+	Requires *SlotRequirement
+}
+
+// slotSales is a public slot with the number of claims made for it.
+type slotSales struct {
+	slot         ConferenceSlot
+	claims       int
+	requiresName string
+}
+
+// availability returns the remaining capacity and sale status of the slot at now.
+func (s *slotSales) availability(now time.Time) SlotAvailability {
+	a := SlotAvailability{Slot: s.slot, Remaining: s.slot.Capacity - s.claims}
+	if a.Remaining < 0 {
+		a.Remaining = 0
+	}
+	switch {
+	case now.Before(s.slot.PurchaseableFrom):
+		a.Status = SaleUpcoming
+	case !now.Before(s.slot.PurchaseableUntil):
+		a.Status = SaleClosed
+	case a.Remaining == 0:
+		a.Status = SaleSoldOut
+	default:
+		a.Status = SaleOnSale
+	}
+	if s.slot.DependsOn != 0 {
+		a.Requires = &SlotRequirement{SlotID: s.slot.DependsOn, Name: s.requiresName}
+	}
+	return a
+}
+
+// salesCache keeps the slots of each conference for a short while, so the tickets page does not
+// count claims on every request when tickets go on sale. Requests for a conference that is being
+// loaded wait for that load instead of starting their own.
+type salesCache struct {
+	ttl time.Duration
+	// size bounds how many conferences are kept, the entry loaded the longest ago goes first.
+	size    int
+	mu      sync.Mutex
+	entries map[uint32]salesCacheEntry
+	loading map[uint32]*salesLoad
+}
+
+type salesCacheEntry struct {
+	sales     []slotSales
+	fetchedAt time.Time
+}
+
+// salesLoad is a load in progress, done is closed once sales and err are set.
+type salesLoad struct {
+	done  chan struct{}
+	sales []slotSales
+	err   error
+}
+
+// get returns the cached slots of a conference, loading them again once they are older than the
+// ttl. Failed loads, such as for conferences that do not exist, are not cached.
+func (c *salesCache) get(ctx context.Context, conferenceID uint32, now time.Time,
+	load func(ctx context.Context, conferenceID uint32) ([]slotSales, error)) ([]slotSales, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[conferenceID]
+	if ok && now.Sub(entry.fetchedAt) < c.ttl {
+		c.mu.Unlock()
+		return entry.sales, nil
+	}
+	if l, ok := c.loading[conferenceID]; ok {
+		c.mu.Unlock()
+		select {
+		case <-l.done:
+			return l.sales, l.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if c.loading == nil {
+		c.loading = map[uint32]*salesLoad{}
+	}
+	l := &salesLoad{done: make(chan struct{})}
+	c.loading[conferenceID] = l
+	c.mu.Unlock()
+
+	l.sales, l.err = load(ctx, conferenceID)
+
+	c.mu.Lock()
+	delete(c.loading, conferenceID)
+	if l.err == nil {
+		c.store(conferenceID, salesCacheEntry{sales: l.sales, fetchedAt: now}, now)
+	}
+	c.mu.Unlock()
+	close(l.done)
+	return l.sales, l.err
+}
+
+// store keeps an entry making room for it if the cache is full, expired entries go first. The
+// caller must hold mu.
+func (c *salesCache) store(conferenceID uint32, entry salesCacheEntry, now time.Time) {
+	if c.entries == nil {
+		c.entries = map[uint32]salesCacheEntry{}
+	}
+	if _, ok := c.entries[conferenceID]; !ok && c.size > 0 && len(c.entries) >= c.size {
+		for id, e := range c.entries {
+			if now.Sub(e.fetchedAt) >= c.ttl {
+				delete(c.entries, id)
+			}
+		}
+		for len(c.entries) >= c.size {
+			var oldest uint32
+			first := true
+			for id, e := range c.entries {
+				if first || e.fetchedAt.Before(c.entries[oldest].fetchedAt) {
+					oldest, first = id, false
+				}
+			}
+			delete(c.entries, oldest)
+		}
+	}
+	c.entries[conferenceID] = entry
+}
@@ -0,0 +1,123 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSlotSalesAvailability(t *testing.T) {
+	opens := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	closes := opens.Add(30 * 24 * time.Hour)
+	slot := ConferenceSlot{Capacity: 10, PurchaseableFrom: opens, PurchaseableUntil: closes, DependsOn: 3}
+
+	tests := []struct {
+		name          string
+		claims        int
+		now           time.Time
+		wantStatus    SaleStatus
+		wantRemaining int
+	}{
+		{name: "before the sale opens", now: opens.Add(-time.Second), wantStatus: SaleUpcoming, wantRemaining: 10},
+		{name: "on sale", claims: 4, now: opens, wantStatus: SaleOnSale, wantRemaining: 6},
+		{name: "sold out", claims: 10, now: opens.Add(time.Hour), wantStatus: SaleSoldOut},
+		{name: "oversold", claims: 12, now: opens.Add(time.Hour), wantStatus: SaleSoldOut},
+		{name: "sale closed", claims: 2, now: closes, wantStatus: SaleClosed, wantRemaining: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := slotSales{slot: slot, claims: tt.claims, requiresName: "General admission"}
+			a := s.availability(tt.now)
+			if a.Status != tt.wantStatus || a.Remaining != tt.wantRemaining {
+				t.Errorf("availability() got %s with %d left, want %s with %d", a.Status, a.Remaining, tt.wantStatus, tt.wantRemaining)
+			}
+			if a.Requires == nil || a.Requires.SlotID != 3 || a.Requires.Name != "General admission" {
+				t.Errorf("availability() got requirement %+v", a.Requires)
+			}
+		})
+	}
+}
+
+func TestSalesCache(t *testing.T) {
+	ctx := context.Background()
+	cache := &salesCache{ttl: time.Minute}
+	loads := 0
+	load := func(ctx context.Context, conferenceID uint32) ([]slotSales, error) {
+		loads++
+		return []slotSales{{claims: loads}}, nil
+	}
+
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{now, now.Add(59 * time.Second)} {
+		sales, err := cache.get(ctx, 1, at, load)
+		if err != nil || sales[0].claims != 1 {
+			t.Fatalf("get() = %+v, %v, want the first load", sales, err)
+		}
+	}
+	if _, err := cache.get(ctx, 2, now, load); err != nil || loads != 2 {
+		t.Errorf("another conference was not loaded, %d loads", loads)
+	}
+	sales, err := cache.get(ctx, 1, now.Add(time.Minute), load)
+	if err != nil || sales[0].claims != 3 {
+		t.Errorf("expired entry was not loaded again, got %+v", sales)
+	}
+}
+
+func TestSalesCacheBound(t *testing.T) {
+	ctx := context.Background()
+	cache := &salesCache{ttl: time.Minute, size: 2}
+	load := func(ctx context.Context, conferenceID uint32) ([]slotSales, error) {
+		return []slotSales{}, nil
+	}
+
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []uint32{1, 2, 3} {
+		if _, err := cache.get(ctx, id, now.Add(time.Duration(i)*time.Second), load); err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+	}
+	if _, ok := cache.entries[1]; ok || len(cache.entries) != 2 {
+		t.Errorf("the oldest entry was not evicted, got %v entries", len(cache.entries))
+	}
+
+	_, err := cache.get(ctx, 4, now, func(ctx context.Context, conferenceID uint32) ([]slotSales, error) {
+		return nil, fmt.Errorf("no such conference")
+	})
+	if _, ok := cache.entries[4]; err == nil || ok {
+		t.Errorf("failed load was cached")
+	}
+}
+
+func TestSalesCacheSharesLoads(t *testing.T) {
+	ctx := context.Background()
+	cache := &salesCache{ttl: time.Minute}
+	var loads int32
+	release := make(chan struct{})
+	load := func(ctx context.Context, conferenceID uint32) ([]slotSales, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return []slotSales{}, nil
+	}
+
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cache.get(ctx, 1, now, load); err != nil {
+				t.Errorf("get() error = %v", err)
+			}
+		}()
+	}
+	// let the requests find the cache empty before the load finishes.
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if loads != 1 {
+		t.Errorf("concurrent requests loaded %d times, want once", loads)
+	}
+}
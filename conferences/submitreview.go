@@ -0,0 +1,36 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// SubmitReviewParams defines the inputs used by the SubmitReview API method
+type SubmitReviewParams struct {
+	Review *Review
+}
+
+// SubmitReviewResponse defines the output returned by the SubmitReview API method
+type SubmitReviewResponse struct {
+	Review *Review
+}
+
+// SubmitReview scores a paper against the conference rubric, submitting again replaces the review
+// encore:api auth
+func SubmitReview(ctx context.Context, params *SubmitReviewParams) (*SubmitReviewResponse, error) {
+	if params.Review == nil {
+		return nil, fmt.Errorf("Review is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := reviewPaper(ctx, userID, params.Review)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit review: %w", err)
+	}
+
+	return &SubmitReviewResponse{Review: review}, nil
+}
@@ -0,0 +1,38 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SubmitSessionFeedbackParams defines the inputs used by the SubmitSessionFeedback API method
+type SubmitSessionFeedbackParams struct {
+	Feedback *SessionFeedback
+}
+
+// SubmitSessionFeedbackResponse defines the output returned by the SubmitSessionFeedback API method
+type SubmitSessionFeedbackResponse struct {
+	Feedback *SessionFeedback
+}
+
+// SubmitSessionFeedback rates a talk, or a slot when PaperID is zero, from 1 to 5 once it ended,
+// attendees need to have redeemed their admission and can only rate each session once
+// encore:api auth
+func SubmitSessionFeedback(ctx context.Context, params *SubmitSessionFeedbackParams) (*SubmitSessionFeedbackResponse, error) {
+	if params.Feedback == nil {
+		return nil, fmt.Errorf("Feedback is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	feedback, err := submitSessionFeedback(ctx, userID, params.Feedback, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit feedback: %w", err)
+	}
+
+	return &SubmitSessionFeedbackResponse{Feedback: feedback}, nil
+}
@@ -14,6 +14,21 @@ func claimSlots(ctx context.Context, attendee *User, slots []ConferenceSlot) ([]
 	if err != nil {
 		return nil, fmt.Errorf("failed to start transaction: %w", err)
 	}
+	claims, err := claimSlotsTx(ctx, tx, attendee, slots)
+	if err != nil {
+		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
+			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
+		}
+		return nil, err
+	}
+	if err := sqldb.Commit(tx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return claims, nil
+}
+
+// claimSlotsTx is claimSlots within a transaction the caller commits.
+func claimSlotsTx(ctx context.Context, tx *sqldb.Tx, attendee *User, slots []ConferenceSlot) ([]SlotClaim, error) {
 	var claims = make([]SlotClaim, len(slots))
 
 	for i := range slots {
@@ -28,49 +43,31 @@ func claimSlots(ctx context.Context, attendee *User, slots []ConferenceSlot) ([]
 		}
 		sc, err = createSlotClaim(ctx, tx, sc, attendee.ID)
 		if err != nil {
-			if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
-				err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
-			}
 			return nil, fmt.Errorf("claiming a slot: %w", err)
 		}
 		claims[i] = *sc
 	}
 	attendee.Claims = append(attendee.Claims, claims...)
-	_, err = updateAttendee(ctx, tx, attendee)
-	if err != nil {
-		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
-			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
-		}
+	if _, err := updateAttendee(ctx, tx, attendee); err != nil {
 		return nil, fmt.Errorf("Updating claimed slots for attendee: %w", err)
 	}
-	if err := sqldb.Commit(tx); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
-	}
 	return claims, nil
 }
 
 // payClaims assigns payments and/or credits to a set of claims.
 func payClaims(ctx context.Context, attendee *User, claims []SlotClaim,
 	payments []FinancialInstrument) (*ClaimPayment, error) {
-	ptrClaims := make([]*SlotClaim, len(claims))
-	for i := range claims {
-		ptrClaims[i] = &claims[i]
-	}
-	claimPayment := &ClaimPayment{
-		ClaimsPaid: ptrClaims,
-		Payment:    payments,
-	}
 	tx, err := sqldb.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("beginning transaction: %w", err)
 	}
 
-	claimPayment, err = createClaimPayment(ctx, tx, claimPayment)
+	claimPayment, err := payClaimsTx(ctx, tx, attendee, claims, payments)
 	if err != nil {
 		if atomicErr := sqldb.Rollback(tx); atomicErr != nil {
 			err = fmt.Errorf("%w (also rolling back transaction: %v)", err, atomicErr)
 		}
-		return nil, fmt.Errorf("paying for claims: %w", err)
+		return nil, err
 	}
 	if err := sqldb.Commit(tx); err != nil {
 		return nil, fmt.Errorf("committing transaction: %w", err)
@@ -79,6 +76,25 @@ func payClaims(ctx context.Context, attendee *User, claims []SlotClaim,
 	return claimPayment, nil
 }
 
+// payClaimsTx is payClaims within a transaction the caller commits.
+func payClaimsTx(ctx context.Context, tx *sqldb.Tx, attendee *User, claims []SlotClaim,
+	payments []FinancialInstrument) (*ClaimPayment, error) {
+	ptrClaims := make([]*SlotClaim, len(claims))
+	for i := range claims {
+		ptrClaims[i] = &claims[i]
+	}
+	claimPayment := &ClaimPayment{
+		ClaimsPaid: ptrClaims,
+		Payment:    payments,
+	}
+
+	claimPayment, err := createClaimPayment(ctx, tx, claimPayment)
+	if err != nil {
+		return nil, fmt.Errorf("paying for claims: %w", err)
+	}
+	return claimPayment, nil
+}
+
 // ErrInvalidCurrency should be returned when paying with the wrong kind of instrument
 // for instance covering credit with credit.
 type ErrInvalidCurrency struct {
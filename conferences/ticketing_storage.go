@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"encore.dev/storage/sqldb"
 	"github.com/lib/pq"
@@ -47,19 +48,19 @@ func readAttendeeByID(ctx context.Context, tx *sqldb.Tx, id uint32) (*User, erro
 
 func readAttendee(ctx context.Context, tx *sqldb.Tx, email string, id uint32) (*User, error) {
 	results := User{}
-	sqlStatement := `SELECT id, email, coc_accepted FROM users`
+	sqlStatement := `SELECT id, email, coc_accepted, flagged FROM users`
 	sqlArgs := []interface{}{}
 	switch {
 	case email != "":
-		sqlStatement = `SELECT id, email, coc_accepted FROM users WHERE email = $1`
+		sqlStatement = `SELECT id, email, coc_accepted, flagged FROM users WHERE email = $1`
 		sqlArgs = append(sqlArgs, email)
 
 	case email != "" && id != 0:
-		sqlStatement = `SELECT id, email, coc_accepted FROM users WHERE email = $1 AND id = $2`
+		sqlStatement = `SELECT id, email, coc_accepted, flagged FROM users WHERE email = $1 AND id = $2`
 		sqlArgs = append(sqlArgs, email, id)
 
 	case email == "" && id != 0:
-		sqlStatement = `SELECT id, email, coc_accepted FROM users WHERE id = $1`
+		sqlStatement = `SELECT id, email, coc_accepted, flagged FROM users WHERE id = $1`
 		sqlArgs = append(sqlArgs, id)
 	default:
 		return nil, errors.New("either email or ID has to be set")
@@ -73,7 +74,7 @@ func readAttendee(ctx context.Context, tx *sqldb.Tx, email string, id uint32) (*
 		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
 	}
 
-	err := row.Scan(&results.ID, &results.Email, &results.CoCAccepted)
+	err := row.Scan(&results.ID, &results.Email, &results.CoCAccepted, &results.Flagged)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -83,7 +84,7 @@ func readAttendee(ctx context.Context, tx *sqldb.Tx, email string, id uint32) (*
 
 	claims := []SlotClaim{}
 
-	sqlStatement = `SELECT id, ticket_id, redeemed FROM slot_claim
+	sqlStatement = `SELECT id, ticket_id, redeemed, revoked FROM slot_claim
 	WHERE user_id = $1`
 	sqlArgs = []interface{}{results.ID}
 	var rows *sqldb.Rows
@@ -101,7 +102,7 @@ func readAttendee(ctx context.Context, tx *sqldb.Tx, email string, id uint32) (*
 
 	for rows.Next() {
 		claim := SlotClaim{}
-		err := rows.Scan(&claim.ID, &claim.TicketID, &claim.Redeemed)
+		err := rows.Scan(&claim.ID, &claim.TicketID, &claim.Redeemed, &claim.Revoked)
 		if err != nil {
 			return nil, fmt.Errorf("scanning slot_claim for attendee: %w", err)
 		}
@@ -114,9 +115,9 @@ func readAttendee(ctx context.Context, tx *sqldb.Tx, email string, id uint32) (*
 
 // createConferenceSlot saves a slot in the database.
 func createConferenceSlot(ctx context.Context, tx *sqldb.Tx, cslot *ConferenceSlot, conferenceID int64) (*ConferenceSlot, error) {
-	var sqlStatement = `INSERT INTO conference_slot (conference_id, name, description, cost, capacity, start_date, end_date, purchaseable_from, purchaseable_until, available_to_public)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-	RETURNING conference_id, name, description, cost, capacity, start_date, end_date, purchaseable_from, purchaseable_until, available_to_public`
+	var sqlStatement = `INSERT INTO conference_slot (conference_id, name, description, cost, capacity, start_date, end_date, purchaseable_from, purchaseable_until, available_to_public, location_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	RETURNING ` + conferenceSlotColumns
 	var sqlArgs = []interface{}{
 		conferenceID,
 		cslot.Name,
@@ -128,11 +129,12 @@ func createConferenceSlot(ctx context.Context, tx *sqldb.Tx, cslot *ConferenceSl
 		cslot.PurchaseableFrom,
 		cslot.PurchaseableUntil,
 		cslot.AvailableToPublic,
+		cslot.Location.ID,
 	}
 	if cslot.DependsOn != 0 {
-		sqlStatement = `INSERT INTO conference_slot (conference_id, name, description, cost, capacity, start_date, end_date, purchaseable_from, purchaseable_until, available_to_public, depends_on)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		RETURNING id, name, description, cost, capacity, start_date, end_date, purchaseable_from, purchaseable_until, available_to_public`
+		sqlStatement = `INSERT INTO conference_slot (conference_id, name, description, cost, capacity, start_date, end_date, purchaseable_from, purchaseable_until, available_to_public, location_id, depends_on)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING ` + conferenceSlotColumns
 		sqlArgs = append(sqlArgs, cslot.DependsOn)
 	}
 	var row *sqldb.Row
@@ -143,8 +145,21 @@ func createConferenceSlot(ctx context.Context, tx *sqldb.Tx, cslot *ConferenceSl
 		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
 	}
 
+	results, err := scanConferenceSlot(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("creating new conference slot: %w", err)
+	}
+
+	return results, nil
+}
+
+const conferenceSlotColumns = `id, name, description, cost, capacity, start_date, end_date, purchaseable_from, purchaseable_until, available_to_public, COALESCE(depends_on, 0),
+	conference_id, location_id, retired_at IS NOT NULL`
+
+// scanConferenceSlot scans a row selected with conferenceSlotColumns, only the ID of the location is set.
+func scanConferenceSlot(scan func(dest ...interface{}) error) (*ConferenceSlot, error) {
 	results := ConferenceSlot{}
-	err := row.Scan(&results.ID,
+	err := scan(&results.ID,
 		&results.Name,
 		&results.Description,
 		&results.Cost,
@@ -153,20 +168,20 @@ func createConferenceSlot(ctx context.Context, tx *sqldb.Tx, cslot *ConferenceSl
 		&results.EndDate,
 		&results.PurchaseableFrom,
 		&results.PurchaseableUntil,
-		&results.AvailableToPublic)
+		&results.AvailableToPublic,
+		&results.DependsOn,
+		&results.ConferenceID,
+		&results.Location.ID,
+		&results.Retired)
 	if err != nil {
-		return nil, fmt.Errorf("creating new conference slot: %w", err)
+		return nil, err
 	}
-
-	// results.DependsOn = e.DependsOn
-
 	return &results, nil
 }
 
 func readConferenceSlotByID(ctx context.Context, tx *sqldb.Tx, id uint64, loadDeps bool) (*ConferenceSlot, error) {
-	results := ConferenceSlot{}
 	var row *sqldb.Row
-	sqlStatement := `SELECT id, name, description, cost, capacity, start_date, end_date, purchaseable_from, purchaseable_until, available_to_public, COALESCE(depends_on, 0)
+	sqlStatement := `SELECT ` + conferenceSlotColumns + `
 	FROM conference_slot
 	WHERE id = $1`
 	sqlArgs := []interface{}{id}
@@ -176,17 +191,7 @@ func readConferenceSlotByID(ctx context.Context, tx *sqldb.Tx, id uint64, loadDe
 		row = sqldb.QueryRow(ctx, sqlStatement, sqlArgs...)
 	}
 
-	err := row.Scan(&results.ID,
-		&results.Name,
-		&results.Description,
-		&results.Cost,
-		&results.Capacity,
-		&results.StartDate,
-		&results.EndDate,
-		&results.PurchaseableFrom,
-		&results.PurchaseableUntil,
-		&results.AvailableToPublic,
-		&results.DependsOn)
+	results, err := scanConferenceSlot(row.Scan)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -194,17 +199,17 @@ func readConferenceSlotByID(ctx context.Context, tx *sqldb.Tx, id uint64, loadDe
 		return nil, fmt.Errorf("reading conference slots by id: %w", err)
 	}
 
-	return &results, nil
+	return results, nil
 }
 
 // updateConferenceSlot updates conference slot fields from the passed instance
 func updateConferenceSlot(ctx context.Context, tx *sqldb.Tx, cslot *ConferenceSlot, conferenceID int64) error {
-	// Regular update
+	// Regular update, it also drops a previous dependency.
 	var sqlStatement = `UPDATE conference_slot
 	SET conference_id = $1, name = $2, description = $3, cost =$4, capacity=$5,
 	start_date = $6, end_date = $7, purchaseable_from = $8, purchaseable_until = $9,
-	available_to_public $10
-	WHERE id = $11`
+	available_to_public = $10, location_id = $11, depends_on = NULL
+	WHERE id = $12`
 	var args = []interface{}{
 		conferenceID,
 		cslot.Name,
@@ -216,6 +221,7 @@ func updateConferenceSlot(ctx context.Context, tx *sqldb.Tx, cslot *ConferenceSl
 		cslot.PurchaseableFrom,
 		cslot.PurchaseableUntil,
 		cslot.AvailableToPublic,
+		cslot.Location.ID,
 	}
 
 	// this slot depends on another
@@ -223,8 +229,8 @@ func updateConferenceSlot(ctx context.Context, tx *sqldb.Tx, cslot *ConferenceSl
 		sqlStatement = `UPDATE conference_slot
 	SET conference_id = $1, name = $2, description = $3, cost =$4, capacity=$5,
 	start_date = $6, end_date = $7, purchaseable_from = $8, purchaseable_until = $9,
-	available_to_public $10, depends_on = $11
-	WHERE id = $12`
+	available_to_public = $10, location_id = $11, depends_on = $12
+	WHERE id = $13`
 		args = append(args, cslot.DependsOn)
 	}
 
@@ -253,6 +259,48 @@ func updateConferenceSlot(ctx context.Context, tx *sqldb.Tx, cslot *ConferenceSl
 	return nil
 }
 
+// retireConferenceSlot takes a slot off sale, claims already made are still valid.
+func retireConferenceSlot(ctx context.Context, tx *sqldb.Tx, id uint32, retiredAt time.Time) error {
+	sqlStatement := `UPDATE conference_slot SET retired_at = $1, available_to_public = FALSE,
+	purchaseable_until = LEAST(purchaseable_until, $1)
+	WHERE id = $2 AND retired_at IS NULL`
+	var res sql.Result
+	var err error
+	if tx != nil {
+		res, err = sqldb.ExecTx(tx, ctx, sqlStatement, retiredAt, id)
+	} else {
+		res, err = sqldb.Exec(ctx, sqlStatement, retiredAt, id)
+	}
+	if err != nil {
+		return fmt.Errorf("retiring conference slot: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get number of rows affected by query: %w", err)
+	}
+	if ra == 0 {
+		return fmt.Errorf("no such slot or it was already retired")
+	}
+	return nil
+}
+
+// countSlotClaims returns how many claims of a slot were not revoked.
+func countSlotClaims(ctx context.Context, tx *sqldb.Tx, id uint32) (int, error) {
+	sqlStatement := `SELECT COUNT(*) FROM slot_claim WHERE conference_slot_id = $1 AND revoked = FALSE`
+	var row *sqldb.Row
+	if tx != nil {
+		row = sqldb.QueryRowTx(tx, ctx, sqlStatement, id)
+	} else {
+		row = sqldb.QueryRow(ctx, sqlStatement, id)
+	}
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("counting slot claims: %w", err)
+	}
+	return count, nil
+}
+
 // createSlotClaim saves a slot claim and returns it with the populated ID
 func createSlotClaim(ctx context.Context, tx *sqldb.Tx, slotClaim *SlotClaim, attendeeID uint32) (*SlotClaim, error) {
 	var err error
@@ -457,6 +505,18 @@ func createClaimPayment(ctx context.Context, tx *sqldb.Tx, c *ClaimPayment) (*Cl
 			return nil, fmt.Errorf("not sure how to process payments of type %T", cp)
 		}
 	}
+	for _, claim := range c.ClaimsPaid {
+		sqlStatement = `INSERT INTO claim_payment_slot_claim (claim_payment_id, slot_claim_id) VALUES ($1, $2)`
+		sqlArgs = []interface{}{claimPayments.ID, claim.ID}
+		if tx != nil {
+			_, err = sqldb.ExecTx(tx, ctx, sqlStatement, sqlArgs...)
+		} else {
+			_, err = sqldb.Exec(ctx, sqlStatement, sqlArgs...)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("linking claim %d to payment: %w", claim.ID, err)
+		}
+	}
 	claimPayments.ClaimsPaid = c.ClaimsPaid
 	claimPayments.Payment = processedPayments
 	return &claimPayments, nil
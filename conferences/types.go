@@ -2,7 +2,7 @@ package conferences
 
 import (
 	"database/sql/driver"
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -14,6 +14,8 @@ type Event struct {
 	Name        string
 	Slug        string
 	Conferences []Conference
+	// Archived events are kept for history but no longer listed.
+	Archived bool
 }
 
 // Conference is an instance like GopherCon 2020
@@ -24,6 +26,11 @@ type Conference struct {
 	StartDate time.Time
 	EndDate   time.Time
 	Venue     Venue
+	EventID   uint32
+	// Archived conferences are kept for history but no longer listed.
+	Archived bool
+	// Current is set on the conference of its event the website shows by default.
+	Current bool
 }
 
 // ConferenceSlot holds information for any sellable/giftable slot we have in the event for
@@ -51,6 +58,8 @@ type ConferenceSlot struct {
 	AvailableToPublic bool
 	Location          Location
 	ConferenceID      uint32
+	// Retired slots are no longer on sale, claims already made are still valid.
+	Retired bool
 }
 
 // Venue defines a venue that hosts a conference, such as DisneyWorld
@@ -62,6 +71,8 @@ type Venue struct {
 	Directions    string
 	GoogleMapsURL string
 	Capacity      int
+	// Archived venues cannot host new conferences.
+	Archived bool
 }
 
 // Location defines a location for a venue, such as a room or event space
@@ -74,6 +85,8 @@ type Location struct {
 	GoogleMapsURL string
 	Capacity      int
 	VenueID       uint32
+	// Archived locations cannot be used by new slots.
+	Archived bool
 }
 
 // ClaimPayment represents a payment for N claims
@@ -119,6 +132,8 @@ type SlotClaim struct {
 	// Redeemed represents whether this has been used (ie the Attendee enrolled in front desk
 	// or into the online conf system) until this is not true, transfer/refund might be possible.
 	Redeemed bool
+	// Revoked means the claim can no longer be used, for instance after a CoC breach.
+	Revoked bool
 }
 
 // Finance Section
@@ -249,80 +264,36 @@ func debtBalanced(payments ...FinancialInstrument) (bool, int64) {
 	return missing <= 0, missing
 }
 
-// SponsorshipLevel defines the type that encapsulates the different sponsorship levels
-type SponsorshipLevel int
-
-// Scan converts from database to Go value
-func (s *SponsorshipLevel) Scan(src interface{}) error {
-	if src == nil {
-		*s = SponsorshipLevelNone
-		return nil
-	}
-	if iv, err := driver.String.ConvertValue(src); err == nil {
-		if v, ok := iv.([]byte); ok {
-			sv := string(v)
-			switch sv {
-			case "none":
-				*s = SponsorshipLevelNone
-				return nil
-			case "diamond":
-				*s = SponsorshipLevelDiamond
-				return nil
-			case "platinum":
-				*s = SponsorshipLevelPlatinum
-				return nil
-			case "gold":
-				*s = SponsorshipLevelGold
-				return nil
-			case "silver":
-				*s = SponsorshipLevelSilver
-				return nil
-			case "bronze":
-				*s = SponsorshipLevelBronze
-				return nil
-			default:
-				*s = SponsorshipLevelNone
-				return nil
-			}
-		}
-	}
-	// otherwise, return an error
-	return errors.New("failed to scan SponsorshipLevel")
-}
-
-// Value - Implementation of valuer for database/sql
-func (s SponsorshipLevel) Value() (driver.Value, error) {
-	// value needs to be a base driver.Value type
-	// such as bool.
-	return s.String(), nil
-}
-
-// These are the valid sponsorship levels
-// Not all of these may be used each year, for
-// example 2020 had no Diamonds
-const (
-	SponsorshipLevelNone = iota
-	SponsorshipLevelDiamond
-	SponsorshipLevelPlatinum
-	SponsorshipLevelGold
-	SponsorshipLevelSilver
-	SponsorshipLevelBronze
-	SponsorshipLevelOther
-)
-
-func (s SponsorshipLevel) String() string {
-	return []string{"none", "diamond", "platinum", "gold", "silver", "bronze", "other"}[s]
+// SponsorshipTier is a level of sponsorship a conference offers, such as Gold for GopherCon 2020.
+// Tiers change every year, 2020 had no Diamonds.
+type SponsorshipTier struct {
+	ID           uint32
+	ConferenceID uint32
+	Name         string
+	// Rank orders the tiers of a conference, 1 is the highest.
+	Rank       int
+	PriceCents int64
+	// Benefits describe what sponsors at this tier get, as shown to prospective sponsors.
+	Benefits []string
+	// MaxSponsors is how many sponsors the tier can have, zero if there is no limit.
+	MaxSponsors int
 }
 
 // Sponsor defines a conference sponsor, such as Google
 type Sponsor struct {
-	ID               uint32
-	Name             string
-	Address          string
-	Website          string
-	SponsorshipLevel SponsorshipLevel
-	Contacts         []SponsorContactInformation
-	ConferenceID     uint32
+	ID      uint32
+	Name    string
+	Address string
+	Website string
+	// Tier is set in full when reading a sponsor, only its ID is used when saving one.
+	Tier         SponsorshipTier
+	Contacts     []SponsorContactInformation
+	ConferenceID uint32
+	// Stage is how far the deal with the sponsor got, sponsors are public once signed.
+	Stage DealStage
+	// Description is part of the public profile the contacts of the sponsor edit in its portal.
+	Description string
+	HasLogo     bool
 }
 
 // ContactRole defines the type that encapsulates the different contact roles
@@ -343,6 +314,32 @@ func (c ContactRole) String() string {
 	return contactRoleMappings[c]
 }
 
+// Scan converts from database to Go value
+func (c *ContactRole) Scan(src interface{}) error {
+	iv, err := driver.String.ConvertValue(src)
+	if err != nil {
+		return fmt.Errorf("failed to scan ContactRole: %w", err)
+	}
+	if v, ok := iv.([]byte); ok {
+		iv = string(v)
+	}
+	for i, role := range contactRoleMappings {
+		if iv == role {
+			*c = ContactRole(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown contact role %v", iv)
+}
+
+// Value - Implementation of valuer for database/sql
+func (c ContactRole) Value() (driver.Value, error) {
+	if c < 0 || int(c) >= len(contactRoleMappings) {
+		return nil, fmt.Errorf("invalid contact role %d", c)
+	}
+	return c.String(), nil
+}
+
 // SponsorContactInformation defines a contact
 //and their information for a sponsor
 type SponsorContactInformation struct {
@@ -351,6 +348,8 @@ type SponsorContactInformation struct {
 	Role  ContactRole
 	Email string
 	Phone string
+	// UserID is the account the contact manages the sponsor with, zero until they join its portal.
+	UserID uint32
 }
 
 // VoucherInformation represents the necessary information to create a new discount
@@ -374,4 +373,6 @@ type Job struct {
 	Discord     string
 	Rank        int
 	Approved    bool
+	// SponsorID is set on the jobs sponsors post from their portal.
+	SponsorID uint32
 }
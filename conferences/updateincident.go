@@ -0,0 +1,60 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// UpdateIncidentStatusParams defines the inputs used by the UpdateIncidentStatus API method
+type UpdateIncidentStatusParams struct {
+	IncidentID uint32
+	Status     IncidentStatus
+}
+
+// UpdateIncidentStatus moves an incident through the new, investigating, resolved workflow
+// encore:api auth
+func UpdateIncidentStatus(ctx context.Context, params *UpdateIncidentStatusParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+	if err := requireRole(ctx, userID, RoleCoCTeam); err != nil {
+		return err
+	}
+
+	if err := changeIncidentStatus(ctx, userID, params.IncidentID, params.Status); err != nil {
+		return fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	return nil
+}
+
+// AddIncidentNoteParams defines the inputs used by the AddIncidentNote API method
+type AddIncidentNoteParams struct {
+	IncidentID uint32
+	Body       string
+}
+
+// AddIncidentNoteResponse defines the output returned by the AddIncidentNote API method
+type AddIncidentNoteResponse struct {
+	Note *IncidentNote
+}
+
+// AddIncidentNote adds a CoC team note to an incident
+// encore:api auth
+func AddIncidentNote(ctx context.Context, params *AddIncidentNoteParams) (*AddIncidentNoteResponse, error) {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRole(ctx, userID, RoleCoCTeam); err != nil {
+		return nil, err
+	}
+
+	note, err := addIncidentNote(ctx, userID, params.IncidentID, params.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add note: %w", err)
+	}
+
+	return &AddIncidentNoteResponse{Note: note}, nil
+}
@@ -0,0 +1,31 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// UpdateMyTravelNeedsParams defines the inputs used by the UpdateMyTravelNeeds API method
+type UpdateMyTravelNeedsParams struct {
+	Travel *TravelNeeds
+}
+
+// UpdateMyTravelNeeds saves what the speaker needs to get to and stay at a conference they
+// present an accepted paper at
+// encore:api auth
+func UpdateMyTravelNeeds(ctx context.Context, params *UpdateMyTravelNeedsParams) error {
+	if params.Travel == nil {
+		return fmt.Errorf("Travel is required")
+	}
+
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := updateTravelNeeds(ctx, userID, params.Travel); err != nil {
+		return fmt.Errorf("failed to update travel needs: %w", err)
+	}
+
+	return nil
+}
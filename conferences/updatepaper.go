@@ -3,8 +3,6 @@ package conferences
 import (
 	"context"
 	"fmt"
-
-	"encore.dev/storage/sqldb"
 )
 
 // UpdatePaperParams defines the inputs used by the GetPaper API method
@@ -17,48 +15,22 @@ type UpdatePaperResponse struct {
 	Paper Paper
 }
 
-// UpdatePaper updates a paper submission for a specific paper id
-// encore:api public
+// UpdatePaper updates a paper submission for a specific paper id, only its speakers and organizers can edit it
+// encore:api auth
 func UpdatePaper(ctx context.Context, params *UpdatePaperParams) (*UpdatePaperResponse, error) {
+	if params.Paper == nil {
+		return nil, fmt.Errorf("Paper is required")
+	}
 
-	row := sqldb.QueryRow(ctx,
-		`
-		UPDATE paper_submission
-		SET title = $1,
-			elevator_pitch = $2,
-			description = $3,
-			notes = $4
-		WHERE id = $5
-		RETURNING id,
-			user_id,
-			conference_id,
-			title,
-			elevator_pitch,
-			description,
-			notes
-	`,
-		params.Paper.Title,
-		params.Paper.ElevatorPitch,
-		params.Paper.Description,
-		params.Paper.Notes,
-		params.Paper.ID,
-	)
-
-	var paper Paper
-
-	err := row.Scan(
-		&paper.ID,
-		&paper.UserID,
-		&paper.ConferenceID,
-		&paper.Title,
-		&paper.ElevatorPitch,
-		&paper.Description,
-		&paper.Notes,
-	)
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return nil, err
+	}
 
+	paper, err := editPaper(ctx, userID, params.Paper)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update paper submission: %w", err)
 	}
 
-	return &UpdatePaperResponse{Paper: paper}, nil
+	return &UpdatePaperResponse{Paper: *paper}, nil
 }
@@ -32,16 +32,13 @@ func TestUpdatePaperSubmission(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		response, err := AddPaper(ctx, &AddPaperParams{
-			Paper: originalPaper,
-		},
-		)
+		paperID, err := submitPaper(ctx, savedAttendee01.ID, originalPaper)
 		if err != nil {
 			t.Fatalf("unexpected database error: %v", err)
 		}
 
 		updatedPaper := &Paper{
-			ID:            response.PaperID,
+			ID:            paperID,
 			UserID:        savedAttendee01.ID,
 			ConferenceID:  1,
 			Title:         "Can anyone code?",
@@ -50,30 +47,30 @@ func TestUpdatePaperSubmission(t *testing.T) {
 			Notes:         "Target Audience: Anyone!",
 		}
 
-		result, err := UpdatePaper(ctx, &UpdatePaperParams{Paper: updatedPaper})
+		result, err := editPaper(ctx, savedAttendee01.ID, updatedPaper)
 
 		if err != nil {
 			t.Fatalf("unexpected database error: %v", err)
 		}
 
-		if result.Paper.UserID != originalPaper.UserID {
-			t.Errorf("UserID was unexpectedly updated got %v want %v", result.Paper.UserID, originalPaper.UserID)
+		if result.UserID != originalPaper.UserID {
+			t.Errorf("UserID was unexpectedly updated got %v want %v", result.UserID, originalPaper.UserID)
 		}
 
-		if result.Paper.Title == originalPaper.Title {
-			t.Errorf("title was not updated got %v want %v", result.Paper.UserID, originalPaper.UserID)
+		if result.Title == originalPaper.Title {
+			t.Errorf("title was not updated got %v want %v", result.UserID, originalPaper.UserID)
 		}
 
-		if result.Paper.ElevatorPitch == originalPaper.ElevatorPitch {
-			t.Errorf("elevator pitch was not updated got %v want %v", result.Paper.ElevatorPitch, originalPaper.ElevatorPitch)
+		if result.ElevatorPitch == originalPaper.ElevatorPitch {
+			t.Errorf("elevator pitch was not updated got %v want %v", result.ElevatorPitch, originalPaper.ElevatorPitch)
 		}
 
-		if result.Paper.Description == originalPaper.Description {
-			t.Errorf("description was not updated got %v want %v", result.Paper.Description, originalPaper.Description)
+		if result.Description == originalPaper.Description {
+			t.Errorf("description was not updated got %v want %v", result.Description, originalPaper.Description)
 		}
 
-		if result.Paper.Notes == originalPaper.Notes {
-			t.Errorf("notes was not updated got %v want %v", result.Paper.Notes, originalPaper.Notes)
+		if result.Notes == originalPaper.Notes {
+			t.Errorf("notes was not updated got %v want %v", result.Notes, originalPaper.Notes)
 		}
 	})
 
@@ -100,44 +97,173 @@ func TestUpdatePaperSubmission(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		response, err := AddPaper(ctx, &AddPaperParams{
-			Paper: originalPaper,
-		},
-		)
+		paperID, err := submitPaper(ctx, savedAttendee02.ID, originalPaper)
 		if err != nil {
 			t.Fatalf("unexpected database error: %v", err)
 		}
 
 		updatedPaper := *originalPaper
 
-		updatedPaper.ID = response.PaperID
+		updatedPaper.ID = paperID
 		updatedPaper.Title = "Get Great with Go"
 		updatedPaper.Notes = "Target audience: New Gophers"
 
-		result, err := UpdatePaper(ctx, &UpdatePaperParams{Paper: &updatedPaper})
+		result, err := editPaper(ctx, savedAttendee02.ID, &updatedPaper)
 
 		if err != nil {
 			t.Fatalf("unexpected database error: %v", err)
 		}
 
-		if result.Paper.UserID != originalPaper.UserID {
-			t.Errorf("UserID was unexpectedly updated got %v want %v", result.Paper.UserID, originalPaper.UserID)
+		if result.UserID != originalPaper.UserID {
+			t.Errorf("UserID was unexpectedly updated got %v want %v", result.UserID, originalPaper.UserID)
 		}
 
-		if result.Paper.Title == originalPaper.Title {
-			t.Errorf("title was not updated got %v want %v", result.Paper.UserID, originalPaper.UserID)
+		if result.Title == originalPaper.Title {
+			t.Errorf("title was not updated got %v want %v", result.UserID, originalPaper.UserID)
 		}
 
-		if result.Paper.ElevatorPitch != originalPaper.ElevatorPitch {
-			t.Errorf("elevator pitch was not updated got %v want %v", result.Paper.ElevatorPitch, originalPaper.ElevatorPitch)
+		if result.ElevatorPitch != originalPaper.ElevatorPitch {
+			t.Errorf("elevator pitch was not updated got %v want %v", result.ElevatorPitch, originalPaper.ElevatorPitch)
 		}
 
-		if result.Paper.Description != originalPaper.Description {
-			t.Errorf("description was not updated got %v want %v", result.Paper.Description, originalPaper.Description)
+		if result.Description != originalPaper.Description {
+			t.Errorf("description was not updated got %v want %v", result.Description, originalPaper.Description)
 		}
 
-		if result.Paper.Notes == originalPaper.Notes {
-			t.Errorf("notes was not updated got %v want %v", result.Paper.Notes, originalPaper.Notes)
+		if result.Notes == originalPaper.Notes {
+			t.Errorf("notes was not updated got %v want %v", result.Notes, originalPaper.Notes)
+		}
+	})
+}
+
+func TestPaperOwnership(t *testing.T) {
+	ctx := context.Background()
+
+	speaker, err := createAttendee(ctx, nil, &User{Email: "owner@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	stranger, err := createAttendee(ctx, nil, &User{Email: "stranger@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	organizer := createOrganizer(t, ctx, "organizer@gophercon.com")
+
+	paper := &Paper{
+		// a speaker cannot submit on behalf of someone else.
+		UserID:        stranger.ID,
+		ConferenceID:  1,
+		Title:         "Owning your code",
+		ElevatorPitch: "Who can change what",
+		Description:   "Ownership in large Go codebases",
+	}
+	paperID, err := submitPaper(ctx, speaker.ID, paper)
+	assertDatabaseError(t, err)
+
+	t.Run("speaker comes from the caller", func(t *testing.T) {
+		stored, err := readManagedPaper(ctx, speaker.ID, paperID)
+		assertDatabaseError(t, err)
+		if stored.UserID != speaker.ID {
+			t.Errorf("incorrect speaker got %v want %v", stored.UserID, speaker.ID)
+		}
+	})
+
+	t.Run("others cannot read or edit the paper", func(t *testing.T) {
+		if _, err := readManagedPaper(ctx, stranger.ID, paperID); err == nil {
+			t.Errorf("reading someone else's paper did not cause an error")
+		}
+		edited := *paper
+		edited.ID = paperID
+		edited.Title = "Stolen talk"
+		if _, err := editPaper(ctx, stranger.ID, &edited); err == nil {
+			t.Errorf("editing someone else's paper did not cause an error")
+		}
+	})
+
+	t.Run("organizers can read the paper", func(t *testing.T) {
+		if _, err := readManagedPaper(ctx, organizer.ID, paperID); err != nil {
+			t.Errorf("organizer could not read paper: %v", err)
+		}
+	})
+
+	t.Run("withdrawn papers cannot be edited", func(t *testing.T) {
+		assertDatabaseError(t, withdrawPaper(ctx, speaker.ID, paperID))
+
+		papers, err := readPapersByUser(ctx, speaker.ID)
+		assertDatabaseError(t, err)
+		if len(papers) != 1 || !papers[0].Withdrawn {
+			t.Fatalf("paper was not withdrawn got %+v", papers)
+		}
+
+		edited := *paper
+		edited.ID = paperID
+		if _, err := editPaper(ctx, speaker.ID, &edited); err == nil {
+			t.Errorf("editing a withdrawn paper did not cause an error")
+		}
+	})
+}
+
+func TestCoSpeakers(t *testing.T) {
+	ctx := context.Background()
+
+	speaker, err := createAttendee(ctx, nil, &User{Email: "lead@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+	coSpeaker, err := createAttendee(ctx, nil, &User{Email: "Partner@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+
+	paperID, err := submitPaper(ctx, speaker.ID, &Paper{
+		ConferenceID:  1,
+		Title:         "Pairing on stage",
+		ElevatorPitch: "Two voices, one talk",
+		Description:   "Presenting together",
+		Format:        TalkFormatWorkshop,
+		Tracks:        []string{"Community"},
+	})
+	assertDatabaseError(t, err)
+
+	t.Run("invitee cannot manage the paper before accepting", func(t *testing.T) {
+		assertDatabaseError(t, inviteCoSpeaker(ctx, speaker.ID, paperID, "partner@gophercon.com"))
+		if _, err := readManagedPaper(ctx, coSpeaker.ID, paperID); err == nil {
+			t.Errorf("reading a paper before accepting the invitation did not cause an error")
+		}
+	})
+
+	t.Run("accepted co-speakers can edit but not withdraw", func(t *testing.T) {
+		assertDatabaseError(t, answerCoSpeakerInvitation(ctx, coSpeaker.ID, paperID, true))
+
+		paper, err := readManagedPaper(ctx, coSpeaker.ID, paperID)
+		assertDatabaseError(t, err)
+		if len(paper.CoSpeakers) != 1 || paper.CoSpeakers[0].Status != CoSpeakerAccepted {
+			t.Fatalf("incorrect co-speakers got %+v", paper.CoSpeakers)
+		}
+		if paper.DurationMinutes != 180 {
+			t.Errorf("incorrect duration got %v want %v", paper.DurationMinutes, 180)
+		}
+
+		paper.Title = "Pairing on stage, again"
+		if _, err := editPaper(ctx, coSpeaker.ID, paper); err != nil {
+			t.Errorf("unexpected error editing as co-speaker: %v", err)
+		}
+		if err := withdrawPaper(ctx, coSpeaker.ID, paperID); err == nil {
+			t.Errorf("withdrawing as co-speaker did not cause an error")
+		}
+	})
+
+	t.Run("papers can be filtered by track and format", func(t *testing.T) {
+		papers, _, err := readPapersByFilter(ctx, PaperFilter{ConferenceID: 1, Format: TalkFormatWorkshop, Track: "community"},
+			Page{Descending: true})
+		assertDatabaseError(t, err)
+		found := false
+		for _, p := range papers {
+			found = found || p.ID == paperID
+		}
+		if !found {
+			t.Errorf("paper %v missing from filtered papers", paperID)
+		}
+
+		papers, _, err = readPapersByFilter(ctx, PaperFilter{ConferenceID: 1, Format: TalkFormatLightning, Track: "community"},
+			Page{Descending: true})
+		assertDatabaseError(t, err)
+		for _, p := range papers {
+			if p.ID == paperID {
+				t.Errorf("paper %v unexpectedly matched another format", paperID)
+			}
 		}
 	})
 }
@@ -3,8 +3,6 @@ package conferences
 import (
 	"context"
 	"fmt"
-
-	"encore.dev/storage/sqldb"
 )
 
 // UpdateSponsorContactParams defines the inputs used by the UpdateSponsorContactParams API method
@@ -16,36 +14,22 @@ type UpdateSponsorContactParams struct {
 type UpdateSponsorContactResponse struct {
 }
 
-// UpdateSponsorContact retrieves all conferences and events
-// encore:api public
+// UpdateSponsorContact changes the name, role, email and phone of a sponsor contact, only
+// organizers can do so
+// encore:api auth
 func UpdateSponsorContact(ctx context.Context, params *UpdateSponsorContactParams) (*UpdateSponsorContactResponse, error) {
 
 	if params.SponsorContactInformation == nil {
 		return nil, fmt.Errorf("SponsorContactInformation is required")
 	}
 
-	if int(params.SponsorContactInformation.Role) > len(contactRoleMappings)-1 || params.SponsorContactInformation.Role < 0 {
-		return nil, fmt.Errorf("invalid role provided")
-	}
-
-	result, err := sqldb.Exec(ctx, `
-	UPDATE sponsor_contact_information
-	SET name = $1,
-		role = $2,
-		email = $3,
-		phone = $4
-	WHERE id = $5`, params.SponsorContactInformation.Name, params.SponsorContactInformation.Role.String(), params.SponsorContactInformation.Email, params.SponsorContactInformation.Phone, params.SponsorContactInformation.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to update sponsor contact information: %w", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
+	userID, err := authenticatedUserID()
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve rows affected: %w", err)
+		return nil, err
 	}
 
-	if rowsAffected == 0 {
-		return nil, fmt.Errorf("no such contact found")
+	if err := updateSponsorContact(ctx, userID, params.SponsorContactInformation); err != nil {
+		return nil, fmt.Errorf("failed to update sponsor contact information: %w", err)
 	}
 
 	return &UpdateSponsorContactResponse{}, nil
@@ -10,19 +10,27 @@ import (
 func TestUpdateSponsorContactInformation(t *testing.T) {
 	ctx := context.Background()
 
+	organizer := createOrganizer(t, ctx, "contact-organizer@gophercon.com")
+	attendee, err := createAttendee(ctx, nil, &User{Email: "contact-attendee@gophercon.com", CoCAccepted: true})
+	assertDatabaseError(t, err)
+
 	t.Run("update a sponsor contact", func(t *testing.T) {
 
-		row := sqldb.QueryRow(ctx, `INSERT INTO sponsor (
+		row := sqldb.QueryRow(ctx, `WITH tier AS (
+		INSERT INTO sponsorship_tier (conference_id, name, rank) VALUES (1, 'Contact Test', 100) RETURNING id
+	) INSERT INTO sponsor (
 		name, 
 		address, 
 		website, 
-		sponsorship_level
-	) VALUES (
+		conference_id,
+		tier_id
+	) SELECT
 		'Crowdstrike', 
 		'Crow Tower, Strike City, 911 911', 
 		'https://www.crowdstrike.com', 
-		'platinum'
-	)
+		1,
+		id
+	FROM tier
 	RETURNING id;`)
 
 		var sponsorID uint32
@@ -69,9 +77,10 @@ func TestUpdateSponsorContactInformation(t *testing.T) {
 			Phone: sponsorContactInformation.Phone,
 		}
 
-		_, err = UpdateSponsorContact(ctx, &UpdateSponsorContactParams{
-			SponsorContactInformation: &updateSponsorContactInformation,
-		})
+		if err := updateSponsorContact(ctx, attendee.ID, &updateSponsorContactInformation); err == nil {
+			t.Fatalf("attendees updating sponsor contacts did not cause an error")
+		}
+		err = updateSponsorContact(ctx, organizer.ID, &updateSponsorContactInformation)
 		assertDatabaseError(t, err)
 
 		row = sqldb.QueryRow(ctx, `SELECT name, role, email, phone  FROM sponsor_contact_information WHERE id = $1;`, sponsorContactInformation.ID)
@@ -119,9 +128,7 @@ func TestUpdateSponsorContactInformation(t *testing.T) {
 			Phone: "555666777",
 		}
 
-		_, err := UpdateSponsorContact(ctx, &UpdateSponsorContactParams{
-			SponsorContactInformation: &sponsorContactInformation,
-		})
+		err := updateSponsorContact(ctx, organizer.ID, &sponsorContactInformation)
 
 		if err == nil {
 			t.Fatalf("invalid role did not cause an error")
@@ -138,9 +145,7 @@ func TestUpdateSponsorContactInformation(t *testing.T) {
 			Phone: "555666777",
 		}
 
-		_, err := UpdateSponsorContact(ctx, &UpdateSponsorContactParams{
-			SponsorContactInformation: &sponsorContactInformation,
-		})
+		err := updateSponsorContact(ctx, organizer.ID, &sponsorContactInformation)
 
 		if err == nil {
 			t.Fatalf("invalid role did not cause an error")
@@ -168,3 +173,12 @@ func assertDatabaseError(t *testing.T, err error) {
 		t.Fatalf("unexpected database error: %v", err)
 	}
 }
+
+// createOrganizer returns a new attendee with the organizer role.
+func createOrganizer(t *testing.T, ctx context.Context, email string) *User {
+	t.Helper()
+	organizer, err := createAttendee(ctx, nil, &User{Email: email, CoCAccepted: true})
+	assertDatabaseError(t, err)
+	assertDatabaseError(t, grantRole(ctx, nil, organizer.ID, RoleOrganizer))
+	return organizer
+}
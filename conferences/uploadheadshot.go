@@ -0,0 +1,49 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// UploadHeadshotParams defines the inputs used by the UploadHeadshot API method
+type UploadHeadshotParams struct {
+	// Image is a JPEG, PNG or WebP image of at most 2MiB.
+	Image []byte
+}
+
+// UploadHeadshot stores the headshot of the authenticated user for their speaker profile
+// encore:api auth
+func UploadHeadshot(ctx context.Context, params *UploadHeadshotParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := uploadHeadshot(ctx, userID, params.Image); err != nil {
+		return fmt.Errorf("failed to upload headshot: %w", err)
+	}
+
+	return nil
+}
+
+// GetHeadshotParams defines the inputs used by the GetHeadshot API method
+type GetHeadshotParams struct {
+	UserID uint32
+}
+
+// GetHeadshotResponse defines the output returned by the GetHeadshot API method
+type GetHeadshotResponse struct {
+	Image       []byte
+	ContentType string
+}
+
+// GetHeadshot retrieves the headshot of a speaker with an accepted talk
+// encore:api public
+func GetHeadshot(ctx context.Context, params *GetHeadshotParams) (*GetHeadshotResponse, error) {
+	image, contentType, err := publicHeadshot(ctx, params.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve headshot: %w", err)
+	}
+
+	return &GetHeadshotResponse{Image: image, ContentType: contentType}, nil
+}
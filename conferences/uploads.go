@@ -0,0 +1,104 @@
+package conferences
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// uploadStore keeps the files users upload. Encore has no object storage yet, so they are kept on
+// the local filesystem until it does and this can be swapped.
+type uploadStore interface {
+	put(key string, data []byte) error
+	// get returns os.ErrNotExist if there is no file for the key.
+	get(key string) ([]byte, error)
+	remove(key string) error
+}
+
+var uploads uploadStore = localUploadStore{dir: uploadDir()}
+
+// uploadDir is where uploads are kept: the UPLOADS_DIR environment variable, which deployments
+// must point to persistent storage, or a directory in the home of the user running the service.
+func uploadDir() string {
+	if dir := os.Getenv("UPLOADS_DIR"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "showrunner-uploads"
+	}
+	return filepath.Join(home, ".showrunner", "uploads")
+}
+
+// localUploadStore keeps uploads as files under dir, keys are generated by us and never by users.
+type localUploadStore struct {
+	dir string
+}
+
+func (s localUploadStore) path(key string) (string, error) {
+	if key == "" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid upload key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+func (s localUploadStore) put(key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating upload directory: %w", err)
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("writing upload: %w", err)
+	}
+	return nil
+}
+
+func (s localUploadStore) get(key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading upload: %w", err)
+	}
+	return data, nil
+}
+
+func (s localUploadStore) remove(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing upload: %w", err)
+	}
+	return nil
+}
+
+// maxImageSize is the largest image users can upload, in bytes.
+const maxImageSize = 2 << 20
+
+// detectImage returns the content type of an uploaded image, sniffed rather than trusting the
+// client, or an error if it is not an image we accept.
+func detectImage(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", fmt.Errorf("image is empty")
+	}
+	if len(data) > maxImageSize {
+		return "", fmt.Errorf("image must be at most %d bytes", maxImageSize)
+	}
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg", "image/png", "image/webp":
+		return contentType, nil
+	}
+	return "", fmt.Errorf("unsupported image type %s", contentType)
+}
@@ -0,0 +1,50 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// UploadSponsorLogoParams defines the inputs used by the UploadSponsorLogo API method
+type UploadSponsorLogoParams struct {
+	SponsorID uint32
+	// Image is a JPEG, PNG or WebP image of at most 2MiB.
+	Image []byte
+}
+
+// UploadSponsorLogo stores the logo of a sponsor the authenticated user is a contact of
+// encore:api auth
+func UploadSponsorLogo(ctx context.Context, params *UploadSponsorLogoParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := uploadSponsorLogo(ctx, userID, params.SponsorID, params.Image); err != nil {
+		return fmt.Errorf("failed to upload sponsor logo: %w", err)
+	}
+
+	return nil
+}
+
+// GetSponsorLogoParams defines the inputs used by the GetSponsorLogo API method
+type GetSponsorLogoParams struct {
+	SponsorID uint32
+}
+
+// GetSponsorLogoResponse defines the output returned by the GetSponsorLogo API method
+type GetSponsorLogoResponse struct {
+	Image       []byte
+	ContentType string
+}
+
+// GetSponsorLogo retrieves the logo of a sponsor once its contract is signed
+// encore:api public
+func GetSponsorLogo(ctx context.Context, params *GetSponsorLogoParams) (*GetSponsorLogoResponse, error) {
+	image, contentType, err := publicSponsorLogo(ctx, params.SponsorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve sponsor logo: %w", err)
+	}
+
+	return &GetSponsorLogoResponse{Image: image, ContentType: contentType}, nil
+}
@@ -0,0 +1,27 @@
+package conferences
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithdrawPaperParams defines the inputs used by the WithdrawPaper API method
+type WithdrawPaperParams struct {
+	PaperID uint32
+}
+
+// WithdrawPaper pulls a paper out of consideration, only the speaker who submitted it and organizers can
+// withdraw it
+// encore:api auth
+func WithdrawPaper(ctx context.Context, params *WithdrawPaperParams) error {
+	userID, err := authenticatedUserID()
+	if err != nil {
+		return err
+	}
+
+	if err := withdrawPaper(ctx, userID, params.PaperID); err != nil {
+		return fmt.Errorf("failed to withdraw paper: %w", err)
+	}
+
+	return nil
+}
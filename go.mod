@@ -7,5 +7,6 @@ require (
 	github.com/coreos/go-oidc/v3 v3.0.0-alpha.1
 	github.com/gofrs/uuid v3.3.0+incompatible
 	github.com/lib/pq v1.9.0
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 	golang.org/x/oauth2 v0.0.0-20201208152858-08078c50e5b5 // indirect
 )